@@ -12,6 +12,7 @@ import (
 
 	_ "github.com/geometry-labs/icon-addresses/api/docs" // import swagger docs
 	"github.com/geometry-labs/icon-addresses/api/routes/rest"
+	"github.com/geometry-labs/icon-addresses/api/routes/ws"
 	"github.com/geometry-labs/icon-addresses/config"
 	"github.com/geometry-labs/icon-addresses/global"
 )
@@ -57,6 +58,8 @@ func Start() {
 
 	// Add handlers
 	rest.AddressesAddHandlers(app)
+	rest.AdminAddHandlers(app)
+	ws.AddressesAddHandlers(app)
 
 	go app.Listen(":" + config.Config.Port)
 }
@@ -2,19 +2,49 @@ package rest
 
 import (
 	"encoding/json"
+	"errors"
 	"strconv"
+	"strings"
 
 	fiber "github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
 	"github.com/geometry-labs/icon-addresses/config"
 	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/models"
 )
 
 type AddressesQuery struct {
 	Limit     int    `query:"limit"`
 	Skip      int    `query:"skip"`
+	Cursor    string `query:"cursor"`
 	PublicKey string `query:"address"`
+
+	// Sort
+	// <field> for ascending, -<field> for descending
+	Sort string `query:"sort"`
+
+	// Filters
+	IsContract          *bool    `query:"is_contract"`
+	IsToken             *bool    `query:"is_token"`
+	IsPrep              *bool    `query:"is_prep"`
+	MinBalance          *float64 `query:"min_balance"`
+	MaxBalance          *float64 `query:"max_balance"`
+	MinTransactionCount *uint64  `query:"min_transaction_count"`
+	MaxTransactionCount *uint64  `query:"max_transaction_count"`
+	MinFirstSeen        *uint64  `query:"min_first_seen_timestamp"`
+	MaxFirstSeen        *uint64  `query:"max_first_seen_timestamp"`
+	MinLastActive       *uint64  `query:"min_last_active_timestamp"`
+	MaxLastActive       *uint64  `query:"max_last_active_timestamp"`
+	Label               string   `query:"label"` // <label>,<label>,... - any of
+	Type                string   `query:"type"`  // Label
+}
+
+// AddressAPIListItem - address list row with its type and labels
+type AddressAPIListItem struct {
+	*models.AddressAPIList
+	Type   string   `json:"type"` // Derived from labels
+	Labels []string `json:"labels"`
 }
 
 func AddressesAddHandlers(app *fiber.App) {
@@ -25,6 +55,13 @@ func AddressesAddHandlers(app *fiber.App) {
 	app.Get(prefix+"/details/:address", handlerGetAddressDetails)
 	app.Get(prefix+"/contracts", handlerGetContracts)
 	app.Get(prefix+"/address-tokens/:address", handlerGetAddressTokens)
+	app.Get(prefix+"/token-holders/:token_contract", handlerGetTokenHolders)
+	app.Get(prefix+"/transactions/:address", handlerGetAddressTransactions)
+	app.Get(prefix+"/balance-history/:address", handlerGetAddressBalanceHistory)
+	app.Get(prefix+"/balance-discrepancies", handlerGetBalanceDiscrepancies)
+	app.Get(prefix+"/search", handlerGetAddressesSearch)
+	app.Get(prefix+"/stats", handlerGetAddressesStats)
+	app.Get(prefix+"/stats/daily", handlerGetAddressesStatsDaily)
 }
 
 // Addresses
@@ -36,10 +73,24 @@ func AddressesAddHandlers(app *fiber.App) {
 // @Produce json
 // @Param limit query int false "amount of records"
 // @Param skip query int false "skip to a record"
-// @Param is_contract query bool false "contract addresses only"
+// @Param cursor query string false "next page cursor from X-NEXT-CURSOR, replaces skip"
 // @Param address query string false "find by address"
+// @Param sort query string false "sort field, prefix with - for descending (balance, transaction_count, log_count, created_timestamp, first_seen_timestamp, last_active_timestamp, public_key)"
+// @Param is_contract query bool false "filter contract addresses"
+// @Param is_token query bool false "filter token addresses"
+// @Param is_prep query bool false "filter prep addresses"
+// @Param min_balance query number false "minimum balance"
+// @Param max_balance query number false "maximum balance"
+// @Param min_transaction_count query int false "minimum transaction count"
+// @Param max_transaction_count query int false "maximum transaction count"
+// @Param min_first_seen_timestamp query int false "first seen at or after a timestamp in microseconds"
+// @Param max_first_seen_timestamp query int false "first seen at or before a timestamp in microseconds"
+// @Param min_last_active_timestamp query int false "last active at or after a timestamp in microseconds"
+// @Param max_last_active_timestamp query int false "last active at or before a timestamp in microseconds, dormant addresses"
+// @Param label query string false "filter by labels, comma separated, any of (e.g. exchange,bridge)"
+// @Param type query string false "filter by type, a label (e.g. treasury)"
 // @Router /api/v1/addresses [get]
-// @Success 200 {object} []models.AddressAPIList
+// @Success 200 {object} []AddressAPIListItem
 // @Failure 422 {object} map[string]interface{}
 func handlerGetAddresses(c *fiber.Ctx) error {
 	params := new(AddressesQuery)
@@ -50,10 +101,29 @@ func handlerGetAddresses(c *fiber.Ctx) error {
 		return c.SendString(`{"error": "could not parse query parameters"}`)
 	}
 
+	// Cursor
+	var cursor *listCursor
+	if params.Cursor != "" {
+		var err error
+		cursor, err = decodeListCursor(params.Cursor)
+		if err != nil {
+			c.Status(422)
+			return c.SendString(`{"error": "invalid cursor"}`)
+		}
+		if params.Sort != "" && params.Sort != cursor.Sort {
+			c.Status(422)
+			return c.SendString(`{"error": "sort does not match cursor"}`)
+		}
+		params.Sort = cursor.Sort
+	}
+
 	// Default Params
 	if params.Limit <= 0 {
 		params.Limit = 25
 	}
+	if params.Sort == "" {
+		params.Sort = "-balance"
+	}
 
 	// Check Params
 	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
@@ -64,12 +134,73 @@ func handlerGetAddresses(c *fiber.Ctx) error {
 		c.Status(422)
 		return c.SendString(`{"error": "invalid skip"}`)
 	}
+	sortDesc := strings.HasPrefix(params.Sort, "-")
+	sortField := strings.TrimPrefix(params.Sort, "-")
+	isSortFieldValid := false
+	for _, f := range crud.AddressesAPISortFields {
+		if f == sortField {
+			isSortFieldValid = true
+			break
+		}
+	}
+	if isSortFieldValid == false {
+		c.Status(422)
+		return c.SendString(`{"error": "invalid sort field"}`)
+	}
+	if cursor != nil && params.Skip != 0 {
+		c.Status(422)
+		return c.SendString(`{"error": "use either skip or cursor"}`)
+	}
+	var addressesCursor *crud.AddressesAPICursor
+	if cursor != nil {
+		var err error
+		addressesCursor, err = cursor.toAddressesAPICursor(sortField)
+		if err != nil {
+			c.Status(422)
+			return c.SendString(`{"error": "invalid cursor"}`)
+		}
+	}
+
+	filter := &crud.AddressesAPIFilter{
+		PublicKey:           params.PublicKey,
+		IsContract:          params.IsContract,
+		IsToken:             params.IsToken,
+		IsPrep:              params.IsPrep,
+		MinBalance:          params.MinBalance,
+		MaxBalance:          params.MaxBalance,
+		MinTransactionCount: params.MinTransactionCount,
+		MaxTransactionCount: params.MaxTransactionCount,
+		MinFirstSeen:        params.MinFirstSeen,
+		MaxFirstSeen:        params.MaxFirstSeen,
+		MinLastActive:       params.MinLastActive,
+		MaxLastActive:       params.MaxLastActive,
+	}
+	if params.Label != "" {
+		for _, label := range strings.Split(params.Label, ",") {
+			label = strings.TrimSpace(label)
+			if crud.IsAddressLabelValid(label) == false {
+				c.Status(422)
+				return c.SendString(`{"error": "invalid label"}`)
+			}
+			filter.Labels = append(filter.Labels, label)
+		}
+	}
+	if params.Type != "" {
+		if crud.IsAddressLabelValid(params.Type) == false {
+			c.Status(422)
+			return c.SendString(`{"error": "invalid type"}`)
+		}
+		filter.Type = params.Type
+	}
 
 	// Get Addresses
 	addresses, err := crud.GetAddressModel().SelectManyAPI(
 		params.Limit,
 		params.Skip,
-		params.PublicKey,
+		addressesCursor,
+		filter,
+		sortField,
+		sortDesc,
 	)
 	if err != nil {
 		zap.S().Warnf("Addresses CRUD ERROR: %s", err.Error())
@@ -83,18 +214,77 @@ func handlerGetAddresses(c *fiber.Ctx) error {
 	}
 
 	// Set X-TOTAL-COUNT
-	// Total count in the address_counts table
-	counter, err := crud.GetAddressCountModel().SelectCount("all")
-	if err != nil {
-		counter = 0
-		zap.S().Warn("Could not retrieve address count: ", err.Error())
+	counter := uint64(0)
+	if filter.IsEmpty() {
+		// Total count in the address_counts table
+		counter, err = crud.GetAddressCountModel().SelectCount("all")
+		if err != nil {
+			counter = 0
+			zap.S().Warn("Could not retrieve address count: ", err.Error())
+		}
+	} else {
+		// Count matching the filters
+		count, err := crud.GetAddressModel().CountAPI(filter)
+		if err != nil {
+			count = 0
+			zap.S().Warn("Could not retrieve address count: ", err.Error())
+		}
+		counter = uint64(count)
 	}
 	c.Append("X-TOTAL-COUNT", strconv.FormatUint(counter, 10))
 
-	body, _ := json.Marshal(addresses)
+	// Set X-NEXT-CURSOR
+	if len(*addresses) == params.Limit {
+		nextCursor := newAddressAPIListCursor(&(*addresses)[len(*addresses)-1], params.Sort, sortField)
+		if nextCursor != nil {
+			setNextCursorHeaders(c, nextCursor)
+		}
+	}
+
+	// Get Labels
+	publicKeys := []string{}
+	for i := range *addresses {
+		publicKeys = append(publicKeys, (*addresses)[i].PublicKey)
+	}
+	labels := map[string][]string{}
+	types := map[string]string{}
+	if len(publicKeys) > 0 {
+		labels, types, err = crud.GetAddressLabelModel().SelectLabelsByPublicKeys(publicKeys)
+		if err != nil {
+			zap.S().Warnf("Address labels CRUD ERROR: %s", err.Error())
+			c.Status(500)
+			return c.SendString(`{"error": "could not retrieve addresses"}`)
+		}
+	}
+
+	addressItems := []*AddressAPIListItem{}
+	for i := range *addresses {
+		address := &(*addresses)[i]
+
+		addressLabels, ok := labels[address.PublicKey]
+		if ok == false {
+			addressLabels = []string{}
+		}
+
+		addressItems = append(addressItems, &AddressAPIListItem{
+			AddressAPIList: address,
+			Type:           types[address.PublicKey],
+			Labels:         addressLabels,
+		})
+	}
+
+	body, _ := json.Marshal(addressItems)
 	return c.SendString(string(body))
 }
 
+// AddressDetails - address with its pending unstakes, type and labels
+type AddressDetails struct {
+	*models.Address
+	Unstakes []models.AddressUnstake `json:"unstakes"`
+	Type     string                  `json:"type"` // Derived from labels
+	Labels   []models.AddressLabel   `json:"labels"`
+}
+
 // Address Details
 // @Summary Get Address Details
 // @Description get details of an address
@@ -104,7 +294,7 @@ func handlerGetAddresses(c *fiber.Ctx) error {
 // @Produce json
 // @Param address path string true "find by address"
 // @Router /api/v1/addresses/details/{address} [get]
-// @Success 200 {object} models.Address
+// @Success 200 {object} AddressDetails
 // @Failure 422 {object} map[string]interface{}
 func handlerGetAddressDetails(c *fiber.Ctx) error {
 	publicKey := c.Params("address")
@@ -132,7 +322,34 @@ func handlerGetAddressDetails(c *fiber.Ctx) error {
 		return c.SendString(`{"error": "could not retrieve addresses"}`)
 	}
 
-	body, _ := json.Marshal(address)
+	// Get Unstakes
+	unstakes, err := crud.GetAddressUnstakeModel().SelectManyByPublicKey(
+		publicKey,
+	)
+	if err != nil {
+		c.Status(500)
+
+		zap.S().Warnf("Address Unstakes CRUD ERROR: %s", err.Error())
+		return c.SendString(`{"error": "could not retrieve addresses"}`)
+	}
+
+	// Get Labels
+	labels, err := crud.GetAddressLabelModel().SelectManyByPublicKey(
+		publicKey,
+	)
+	if err != nil {
+		c.Status(500)
+
+		zap.S().Warnf("Address Labels CRUD ERROR: %s", err.Error())
+		return c.SendString(`{"error": "could not retrieve addresses"}`)
+	}
+
+	body, _ := json.Marshal(&AddressDetails{
+		Address:  address,
+		Unstakes: *unstakes,
+		Type:     crud.GetAddressLabelsType(*labels),
+		Labels:   *labels,
+	})
 	return c.SendString(string(body))
 }
 
@@ -145,6 +362,7 @@ func handlerGetAddressDetails(c *fiber.Ctx) error {
 // @Produce json
 // @Param limit query int false "amount of records"
 // @Param skip query int false "skip to a record"
+// @Param cursor query string false "next page cursor from X-NEXT-CURSOR, replaces skip"
 // @Router /api/v1/addresses/contracts [get]
 // @Success 200 {object} []models.ContractAPIList
 // @Failure 422 {object} map[string]interface{}
@@ -172,10 +390,31 @@ func handlerGetContracts(c *fiber.Ctx) error {
 		return c.SendString(`{"error": "invalid skip"}`)
 	}
 
+	// Cursor
+	// NOTE contracts are always sorted by transaction count
+	var contractsCursor *crud.AddressesAPICursor
+	if params.Cursor != "" {
+		cursor, err := decodeListCursor(params.Cursor)
+		if err == nil && cursor.Sort == "-transaction_count" {
+			contractsCursor, err = cursor.toAddressesAPICursor("transaction_count")
+		} else if err == nil {
+			err = errors.New("Invalid cursor sort")
+		}
+		if err != nil {
+			c.Status(422)
+			return c.SendString(`{"error": "invalid cursor"}`)
+		}
+		if params.Skip != 0 {
+			c.Status(422)
+			return c.SendString(`{"error": "use either skip or cursor"}`)
+		}
+	}
+
 	// Get contracts
 	contracts, err := crud.GetAddressModel().SelectManyContractsAPI(
 		params.Limit,
 		params.Skip,
+		contractsCursor,
 	)
 	if err != nil {
 		zap.S().Warnf("Addresses CRUD ERROR: %s", err.Error())
@@ -197,26 +436,36 @@ func handlerGetContracts(c *fiber.Ctx) error {
 	}
 	c.Append("X-TOTAL-COUNT", strconv.FormatUint(counter, 10))
 
+	// Set X-NEXT-CURSOR
+	if len(*contracts) == params.Limit {
+		lastContract := &(*contracts)[len(*contracts)-1]
+		setNextCursorHeaders(c, &listCursor{
+			Sort:      "-transaction_count",
+			Value:     strconv.FormatUint(lastContract.TransactionCount, 10),
+			PublicKey: lastContract.PublicKey,
+		})
+	}
+
 	body, _ := json.Marshal(contracts)
 	return c.SendString(string(body))
 }
 
 // Address Tokens
 // @Summary Get Address Tokens
-// @Description get list of token contracts by address
+// @Description get list of tokens held by an address with balances
 // @Tags Addresses
 // @BasePath /api/v1
 // @Accept */*
 // @Produce json
 // @Param address path string true "address"
 // @Router /api/v1/addresses/address-tokens/{address} [get]
-// @Success 200 {object} []string
+// @Success 200 {object} []models.AddressTokenAPIList
 // @Failure 422 {object} map[string]interface{}
 func handlerGetAddressTokens(c *fiber.Ctx) error {
 	publicKey := c.Params("address")
 
 	// Get AddressTokens
-	addressTokens, err := crud.GetAddressTokenModel().SelectManyByPublicKey(publicKey)
+	addressTokens, err := crud.GetTokenBalanceModel().SelectManyAPIByPublicKey(publicKey)
 	if err != nil {
 		zap.S().Warnf("AddressTokens CRUD ERROR: %s", err.Error())
 		c.Status(500)
@@ -231,12 +480,83 @@ func handlerGetAddressTokens(c *fiber.Ctx) error {
 	// Set X-TOTAL-COUNT
 	c.Append("X-TOTAL-COUNT", strconv.FormatUint(uint64(len(*addressTokens)), 10))
 
-	// []models.AddressToken -> []string
-	var tokenContractAddresses []string
-	for _, a := range *addressTokens {
-		tokenContractAddresses = append(tokenContractAddresses, a.TokenContractAddress)
+	body, _ := json.Marshal(addressTokens)
+	return c.SendString(string(body))
+}
+
+type TokenHoldersQuery struct {
+	Limit int `query:"limit"`
+	Skip  int `query:"skip"`
+}
+
+// Token Holders
+// @Summary Get Token Holders
+// @Description get list of holders of a token contract sorted by token balance
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param token_contract path string true "token contract address"
+// @Param limit query int false "amount of records"
+// @Param skip query int false "skip to a record"
+// @Router /api/v1/addresses/token-holders/{token_contract} [get]
+// @Success 200 {object} []models.TokenHolderAPIList
+// @Failure 422 {object} map[string]interface{}
+func handlerGetTokenHolders(c *fiber.Ctx) error {
+	tokenContractAddress := c.Params("token_contract")
+	if tokenContractAddress == "" {
+		c.Status(422)
+		return c.SendString(`{"error": "token contract required"}`)
+	}
+
+	params := new(TokenHoldersQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Token Holders Get Handler ERROR: %s", err.Error())
+
+		c.Status(422)
+		return c.SendString(`{"error": "could not parse query parameters"}`)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+
+	// Check Params
+	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
+		c.Status(422)
+		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
+	}
+	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
+		c.Status(422)
+		return c.SendString(`{"error": "invalid skip"}`)
+	}
+
+	// Get Token Holders
+	tokenHolders, err := crud.GetTokenBalanceModel().SelectManyHoldersAPI(
+		params.Limit,
+		params.Skip,
+		tokenContractAddress,
+	)
+	if err != nil {
+		zap.S().Warnf("TokenHolders CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not retrieve token holders"}`)
+	}
+
+	if len(*tokenHolders) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// Set X-TOTAL-COUNT
+	counter, err := crud.GetAddressTokenModel().CountByTokenContractAddress(tokenContractAddress)
+	if err != nil {
+		counter = 0
+		zap.S().Warn("Could not retrieve token holder count: ", err.Error())
 	}
+	c.Append("X-TOTAL-COUNT", strconv.FormatInt(counter, 10))
 
-	body, _ := json.Marshal(&tokenContractAddresses)
+	body, _ := json.Marshal(tokenHolders)
 	return c.SendString(string(body))
 }
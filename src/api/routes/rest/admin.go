@@ -0,0 +1,254 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+type BuilderCheckpointsRewindQuery struct {
+	BlockNumber *uint64 `query:"block_number"`
+}
+
+func AdminAddHandlers(app *fiber.App) {
+
+	if config.Config.AdminAPIKey == "" {
+		// Admin endpoints disabled
+		return
+	}
+
+	prefix := config.Config.RestPrefix + "/addresses/admin"
+
+	admin := app.Group(prefix, handlerAdminAuth)
+	admin.Get("/builders/:builder/checkpoints", handlerGetBuilderCheckpoints)
+	admin.Post("/builders/:builder/checkpoints/rewind", handlerRewindBuilderCheckpoints)
+	admin.Post("/builders/:builder/checkpoints/reset", handlerResetBuilderCheckpoints)
+	admin.Put("/labels/:address/:label", handlerPutAddressLabel)
+	admin.Delete("/labels/:address/:label", handlerDeleteAddressLabel)
+}
+
+// handlerAdminAuth - require the admin api key in the X-API-KEY header
+func handlerAdminAuth(c *fiber.Ctx) error {
+	apiKey := c.Get("X-API-KEY")
+
+	if subtle.ConstantTimeCompare([]byte(apiKey), []byte(config.Config.AdminAPIKey)) != 1 {
+		c.Status(401)
+		return c.SendString(`{"error": "invalid api key"}`)
+	}
+
+	return c.Next()
+}
+
+// Builder Checkpoints
+// @Summary Get Builder Checkpoints
+// @Description get the last block completed by each shard of a builder
+// @Tags Admin
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-API-KEY header string true "admin api key"
+// @Param builder path string true "builder (balance or address_daily_stats)"
+// @Router /api/v1/addresses/admin/builders/{builder}/checkpoints [get]
+// @Success 200 {object} []models.BuilderCheckpoint
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+func handlerGetBuilderCheckpoints(c *fiber.Ctx) error {
+	builder := c.Params("builder")
+
+	// Check params
+	if crud.IsBuilderValid(builder) == false {
+		c.Status(422)
+		return c.SendString(`{"error": "invalid builder"}`)
+	}
+
+	builderCheckpoints, err := crud.GetBuilderCheckpointModel().SelectMany(builder)
+	if err != nil {
+		zap.S().Warnf("Builder checkpoints CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not retrieve builder checkpoints"}`)
+	}
+
+	if len(*builderCheckpoints) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// Set X-TOTAL-COUNT
+	c.Append("X-TOTAL-COUNT", strconv.Itoa(len(*builderCheckpoints)))
+
+	body, _ := json.Marshal(builderCheckpoints)
+	return c.SendString(string(body))
+}
+
+// Rewind Builder Checkpoints
+// @Summary Rewind Builder Checkpoints
+// @Description restart every shard of a builder from a block number, rows built at or above it are deleted and rebuilt
+// @Tags Admin
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-API-KEY header string true "admin api key"
+// @Param builder path string true "builder (balance or address_daily_stats)"
+// @Param block_number query int true "first block to rebuild"
+// @Router /api/v1/addresses/admin/builders/{builder}/checkpoints/rewind [post]
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+func handlerRewindBuilderCheckpoints(c *fiber.Ctx) error {
+	params := new(BuilderCheckpointsRewindQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Builder Checkpoints Rewind Handler ERROR: %s", err.Error())
+
+		c.Status(422)
+		return c.SendString(`{"error": "could not parse query parameters"}`)
+	}
+
+	// Check params
+	if params.BlockNumber == nil {
+		c.Status(422)
+		return c.SendString(`{"error": "block_number required"}`)
+	}
+
+	return rewindBuilderCheckpoints(c, *params.BlockNumber)
+}
+
+// Reset Builder Checkpoints
+// @Summary Reset Builder Checkpoints
+// @Description restart every shard of a builder from genesis
+// @Tags Admin
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-API-KEY header string true "admin api key"
+// @Param builder path string true "builder (balance or address_daily_stats)"
+// @Router /api/v1/addresses/admin/builders/{builder}/checkpoints/reset [post]
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+func handlerResetBuilderCheckpoints(c *fiber.Ctx) error {
+	return rewindBuilderCheckpoints(c, 0)
+}
+
+func rewindBuilderCheckpoints(c *fiber.Ctx, blockNumber uint64) error {
+	builder := c.Params("builder")
+
+	// Check params
+	if crud.IsBuilderValid(builder) == false {
+		c.Status(422)
+		return c.SendString(`{"error": "invalid builder"}`)
+	}
+
+	count, err := crud.GetBuilderCheckpointModel().RewindMany(builder, blockNumber)
+	if err != nil {
+		zap.S().Warnf("Builder checkpoints CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not rewind builder checkpoints"}`)
+	}
+
+	zap.S().Info(
+		"Builder=", builder,
+		",BlockNumber=", blockNumber,
+		",Checkpoints=", count,
+		" - Rewound builder checkpoints",
+	)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"builder":      builder,
+		"block_number": blockNumber,
+		"checkpoints":  count,
+	})
+	return c.SendString(string(body))
+}
+
+// Put Address Label
+// @Summary Put Address Label
+// @Description label an address, labels set here are kept when the labels file is reloaded
+// @Tags Admin
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-API-KEY header string true "admin api key"
+// @Param address path string true "address"
+// @Param label path string true "label (e.g. exchange)"
+// @Router /api/v1/addresses/admin/labels/{address}/{label} [put]
+// @Success 200 {object} models.AddressLabel
+// @Failure 401 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+func handlerPutAddressLabel(c *fiber.Ctx) error {
+	publicKey := c.Params("address")
+	label := c.Params("label")
+
+	// Check params
+	if crud.IsAddressPublicKeyValid(publicKey) == false {
+		c.Status(422)
+		return c.SendString(`{"error": "invalid address"}`)
+	}
+	if crud.IsAddressLabelValid(label) == false {
+		c.Status(422)
+		return c.SendString(`{"error": "invalid label"}`)
+	}
+
+	addressLabel := &models.AddressLabel{
+		PublicKey: publicKey,
+		Label:     label,
+		Source:    "admin",
+		Timestamp: uint64(time.Now().UnixNano() / 1000),
+	}
+	err := crud.GetAddressLabelModel().UpsertOne(addressLabel)
+	if err != nil {
+		zap.S().Warnf("Address labels CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not set address label"}`)
+	}
+
+	zap.S().Info("Address=", publicKey, ",Label=", label, " - Set address label")
+
+	body, _ := json.Marshal(addressLabel)
+	return c.SendString(string(body))
+}
+
+// Delete Address Label
+// @Summary Delete Address Label
+// @Description remove a label from an address
+// @Tags Admin
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param X-API-KEY header string true "admin api key"
+// @Param address path string true "address"
+// @Param label path string true "label (e.g. exchange)"
+// @Router /api/v1/addresses/admin/labels/{address}/{label} [delete]
+// @Success 200 {object} map[string]interface{}
+// @Failure 401 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+func handlerDeleteAddressLabel(c *fiber.Ctx) error {
+	publicKey := c.Params("address")
+	label := c.Params("label")
+
+	count, err := crud.GetAddressLabelModel().DeleteOne(publicKey, label)
+	if err != nil {
+		zap.S().Warnf("Address labels CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not delete address label"}`)
+	}
+	if count == 0 {
+		c.Status(404)
+		return c.SendString(`{"error": "no address label found"}`)
+	}
+
+	zap.S().Info("Address=", publicKey, ",Label=", label, " - Deleted address label")
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"public_key": publicKey,
+		"label":      label,
+	})
+	return c.SendString(string(body))
+}
@@ -0,0 +1,42 @@
+package rest
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-addresses/config"
+)
+
+func TestAdminUnknownBuilder(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.RestPrefix = "/api/v1"
+	config.Config.AdminAPIKey = "test-key"
+	defer func() { config.Config.AdminAPIKey = "" }()
+
+	app := fiber.New()
+	AdminAddHandlers(app)
+
+	for _, request := range []struct {
+		method string
+		path   string
+	}{
+		{"GET", "/api/v1/addresses/admin/builders/balances/checkpoints"},
+		{"POST", "/api/v1/addresses/admin/builders/balances/checkpoints/rewind?block_number=10"},
+		{"POST", "/api/v1/addresses/admin/builders/balances/checkpoints/reset"},
+	} {
+		req := httptest.NewRequest(request.method, request.path, nil)
+		req.Header.Set("X-API-KEY", "test-key")
+
+		resp, err := app.Test(req)
+		assert.Equal(nil, err)
+		assert.Equal(422, resp.StatusCode, request.path)
+
+		body, _ := ioutil.ReadAll(resp.Body)
+		assert.Equal(`{"error": "invalid builder"}`, string(body))
+	}
+}
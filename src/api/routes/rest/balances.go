@@ -0,0 +1,203 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+type BalanceHistoryQuery struct {
+	Limit       int    `query:"limit"`
+	Skip        int    `query:"skip"`
+	BlockNumber uint64 `query:"block_number"`
+	Timestamp   uint64 `query:"timestamp"`
+}
+
+// Address Balance History
+// @Summary Get Address Balance History
+// @Description get the balance ledger of an address, or the balance at a block number or timestamp
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param address path string true "address"
+// @Param limit query int false "amount of records"
+// @Param skip query int false "skip to a record"
+// @Param block_number query int false "balance at or before block number"
+// @Param timestamp query int false "balance at or before block timestamp (microseconds)"
+// @Router /api/v1/addresses/balance-history/{address} [get]
+// @Success 200 {object} []models.Balance
+// @Failure 422 {object} map[string]interface{}
+func handlerGetAddressBalanceHistory(c *fiber.Ctx) error {
+	publicKey := c.Params("address")
+	if publicKey == "" {
+		c.Status(422)
+		return c.SendString(`{"error": "address required"}`)
+	}
+
+	params := new(BalanceHistoryQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Balance History Get Handler ERROR: %s", err.Error())
+
+		c.Status(422)
+		return c.SendString(`{"error": "could not parse query parameters"}`)
+	}
+
+	isBlockNumberQuery := c.Query("block_number") != ""
+	isTimestampQuery := c.Query("timestamp") != ""
+
+	// Check Params
+	if isBlockNumberQuery && isTimestampQuery {
+		c.Status(422)
+		return c.SendString(`{"error": "only one of block_number or timestamp can be set"}`)
+	}
+
+	/////////////////////////
+	// Point in time query //
+	/////////////////////////
+	if isBlockNumberQuery || isTimestampQuery {
+		var balance *models.Balance
+		var err error
+		if isBlockNumberQuery {
+			balance, err = crud.GetBalanceModel().SelectOneByBlockNumber(publicKey, params.BlockNumber)
+		} else {
+			balance, err = crud.GetBalanceModel().SelectOneByTimestamp(publicKey, params.Timestamp)
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// No Content
+			c.Status(204)
+			return c.SendString("")
+		} else if err != nil {
+			zap.S().Warnf("Balances CRUD ERROR: %s", err.Error())
+			c.Status(500)
+			return c.SendString(`{"error": "could not retrieve balance"}`)
+		}
+
+		body, _ := json.Marshal(balance)
+		return c.SendString(string(body))
+	}
+
+	/////////////////
+	// Time series //
+	/////////////////
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+
+	// Check Params
+	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
+		c.Status(422)
+		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
+	}
+	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
+		c.Status(422)
+		return c.SendString(`{"error": "invalid skip"}`)
+	}
+
+	// Get Balances
+	balances, err := crud.GetBalanceModel().SelectManyByPublicKey(
+		params.Limit,
+		params.Skip,
+		publicKey,
+	)
+	if err != nil {
+		zap.S().Warnf("Balances CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not retrieve balances"}`)
+	}
+
+	if len(*balances) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// Set X-TOTAL-COUNT
+	counter, err := crud.GetBalanceModel().CountByPublicKey(publicKey)
+	if err != nil {
+		counter = 0
+		zap.S().Warn("Could not retrieve balance count: ", err.Error())
+	}
+	c.Append("X-TOTAL-COUNT", strconv.FormatInt(counter, 10))
+
+	body, _ := json.Marshal(balances)
+	return c.SendString(string(body))
+}
+
+type BalanceDiscrepanciesQuery struct {
+	Limit int `query:"limit"`
+	Skip  int `query:"skip"`
+}
+
+// Balance Discrepancies
+// @Summary Get Balance Discrepancies
+// @Description get list of addresses whose balance ledger does not match the node, sorted by the first block they differ
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param limit query int false "amount of records"
+// @Param skip query int false "skip to a record"
+// @Router /api/v1/addresses/balance-discrepancies [get]
+// @Success 200 {object} []models.BalanceDiscrepancy
+// @Failure 422 {object} map[string]interface{}
+func handlerGetBalanceDiscrepancies(c *fiber.Ctx) error {
+	params := new(BalanceDiscrepanciesQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Balance Discrepancies Get Handler ERROR: %s", err.Error())
+
+		c.Status(422)
+		return c.SendString(`{"error": "could not parse query parameters"}`)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+
+	// Check Params
+	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
+		c.Status(422)
+		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
+	}
+	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
+		c.Status(422)
+		return c.SendString(`{"error": "invalid skip"}`)
+	}
+
+	// Get Balance Discrepancies
+	balanceDiscrepancies, err := crud.GetBalanceDiscrepancyModel().SelectMany(
+		params.Limit,
+		params.Skip,
+	)
+	if err != nil {
+		zap.S().Warnf("Balance Discrepancies CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not retrieve balance discrepancies"}`)
+	}
+
+	if len(*balanceDiscrepancies) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// Set X-TOTAL-COUNT
+	counter, err := crud.GetBalanceDiscrepancyModel().Count()
+	if err != nil {
+		counter = 0
+		zap.S().Warn("Could not retrieve balance discrepancy count: ", err.Error())
+	}
+	c.Append("X-TOTAL-COUNT", strconv.FormatInt(counter, 10))
+
+	body, _ := json.Marshal(balanceDiscrepancies)
+	return c.SendString(string(body))
+}
@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/url"
+	"strconv"
+
+	fiber "github.com/gofiber/fiber/v2"
+
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// listCursor - opaque keyset pagination cursor
+// Encodes the sort and the position of the last row seen
+type listCursor struct {
+	Sort      string `json:"s"`
+	Value     string `json:"v"`
+	PublicKey string `json:"k"`
+}
+
+func encodeListCursor(cursor *listCursor) string {
+	cursorJSON, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(cursorJSON)
+}
+
+func decodeListCursor(cursorString string) (*listCursor, error) {
+	cursorJSON, err := base64.RawURLEncoding.DecodeString(cursorString)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor := &listCursor{}
+	err = json.Unmarshal(cursorJSON, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.PublicKey == "" {
+		return nil, errors.New("Invalid cursor")
+	}
+
+	return cursor, nil
+}
+
+// toAddressesAPICursor - convert cursor value to the column type of the sort field
+func (l *listCursor) toAddressesAPICursor(sortField string) (*crud.AddressesAPICursor, error) {
+	var value interface{}
+	var err error
+
+	switch sortField {
+	case "balance":
+		// Exact balance in loop
+		_, ok := new(big.Int).SetString(l.Value, 10)
+		if ok == false {
+			err = errors.New("Invalid balance cursor")
+		}
+		value = l.Value
+	case "transaction_count", "log_count", "created_timestamp", "first_seen_timestamp", "last_active_timestamp":
+		value, err = strconv.ParseUint(l.Value, 10, 64)
+	case "public_key":
+		value = l.PublicKey
+	default:
+		err = errors.New("Cursor not supported for sort field " + sortField)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &crud.AddressesAPICursor{
+		Value:     value,
+		PublicKey: l.PublicKey,
+	}, nil
+}
+
+// newAddressAPIListCursor - cursor pointing after an address list row
+// Returns nil if the sort field does not support cursors
+func newAddressAPIListCursor(address *models.AddressAPIList, sort string, sortField string) *listCursor {
+	value := ""
+
+	switch sortField {
+	case "balance":
+		value = address.BalanceLoop
+	case "transaction_count":
+		value = strconv.FormatUint(address.TransactionCount, 10)
+	case "log_count":
+		value = strconv.FormatUint(address.LogCount, 10)
+	case "created_timestamp":
+		value = strconv.FormatUint(address.CreatedTimestamp, 10)
+	case "first_seen_timestamp":
+		value = strconv.FormatUint(address.FirstSeenTimestamp, 10)
+	case "last_active_timestamp":
+		value = strconv.FormatUint(address.LastActiveTimestamp, 10)
+	case "public_key":
+		value = address.PublicKey
+	default:
+		return nil
+	}
+
+	return &listCursor{
+		Sort:      sort,
+		Value:     value,
+		PublicKey: address.PublicKey,
+	}
+}
+
+// setNextCursorHeaders - set X-NEXT-CURSOR and Link headers
+func setNextCursorHeaders(c *fiber.Ctx, cursor *listCursor) {
+	nextCursor := encodeListCursor(cursor)
+
+	// Next page query
+	query, _ := url.ParseQuery(string(c.Request().URI().QueryString()))
+	query.Del("skip")
+	query.Set("cursor", nextCursor)
+
+	c.Append("X-NEXT-CURSOR", nextCursor)
+	c.Append("Link", "<"+c.BaseURL()+c.Path()+"?"+query.Encode()+`>; rel="next"`)
+}
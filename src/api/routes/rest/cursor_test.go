@@ -0,0 +1,89 @@
+package rest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestListCursor(t *testing.T) {
+	assert := assert.New(t)
+
+	address := &models.AddressAPIList{
+		PublicKey:        "hx54f7853dc6481b670caf69c5a27c7c8fe5be8269",
+		TransactionCount: 42,
+		Balance:          800460000.123456789,
+		BalanceLoop:      "800460000123456789000000000",
+	}
+
+	// Balance
+	cursor := newAddressAPIListCursor(address, "-balance", "balance")
+	assert.NotEqual(nil, cursor)
+
+	decodedCursor, err := decodeListCursor(encodeListCursor(cursor))
+	assert.Equal(nil, err)
+	assert.Equal(cursor, decodedCursor)
+
+	addressesCursor, err := decodedCursor.toAddressesAPICursor("balance")
+	assert.Equal(nil, err)
+	assert.Equal(address.BalanceLoop, addressesCursor.Value)
+	assert.Equal(address.PublicKey, addressesCursor.PublicKey)
+
+	// Transaction count
+	cursor = newAddressAPIListCursor(address, "transaction_count", "transaction_count")
+	decodedCursor, err = decodeListCursor(encodeListCursor(cursor))
+	assert.Equal(nil, err)
+
+	addressesCursor, err = decodedCursor.toAddressesAPICursor("transaction_count")
+	assert.Equal(nil, err)
+	assert.Equal(uint64(42), addressesCursor.Value)
+
+	// Balance must be an integer loop amount
+	_, err = (&listCursor{Sort: "-balance", Value: "1.5", PublicKey: address.PublicKey}).toAddressesAPICursor("balance")
+	assert.NotEqual(nil, err)
+
+	// Unsupported sort field
+	assert.Nil(newAddressAPIListCursor(address, "name", "name"))
+
+	_, err = decodedCursor.toAddressesAPICursor("name")
+	assert.NotEqual(nil, err)
+
+	// Invalid cursor
+	_, err = decodeListCursor("not-a-cursor")
+	assert.NotEqual(nil, err)
+}
+
+func TestListCursorSortFields(t *testing.T) {
+	assert := assert.New(t)
+
+	address := &models.AddressAPIList{
+		PublicKey:           "hx54f7853dc6481b670caf69c5a27c7c8fe5be8269",
+		TransactionCount:    42,
+		LogCount:            7,
+		CreatedTimestamp:    1616000000000000,
+		BalanceLoop:         "800460000123456789000000000",
+		FirstSeenTimestamp:  1516819217223222,
+		LastActiveTimestamp: 1626000000000000,
+	}
+
+	// Every sort field has a cursor
+	for _, sortField := range crud.AddressesAPISortFields {
+		for _, sort := range []string{sortField, "-" + sortField} {
+			cursor := newAddressAPIListCursor(address, sort, sortField)
+			if assert.NotNil(cursor, sort) == false {
+				continue
+			}
+
+			decodedCursor, err := decodeListCursor(encodeListCursor(cursor))
+			assert.Equal(nil, err, sort)
+			assert.Equal(sort, decodedCursor.Sort)
+
+			addressesCursor, err := decodedCursor.toAddressesAPICursor(sortField)
+			assert.Equal(nil, err, sort)
+			assert.Equal(address.PublicKey, addressesCursor.PublicKey, sort)
+		}
+	}
+}
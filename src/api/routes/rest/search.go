@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+)
+
+type AddressesSearchQuery struct {
+	Query string `query:"q"`
+	Limit int    `query:"limit"`
+}
+
+// Addresses Search
+// @Summary Search Addresses
+// @Description search addresses by address prefix, contract name and label, best match first
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param q query string true "address prefix, contract name or label, at least 2 characters"
+// @Param limit query int false "amount of records"
+// @Router /api/v1/addresses/search [get]
+// @Success 200 {object} []models.AddressSearchAPIList
+// @Failure 422 {object} map[string]interface{}
+func handlerGetAddressesSearch(c *fiber.Ctx) error {
+	params := new(AddressesSearchQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Addresses Search Handler ERROR: %s", err.Error())
+
+		c.Status(422)
+		return c.SendString(`{"error": "could not parse query parameters"}`)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+	params.Query = strings.TrimSpace(params.Query)
+
+	// Check Params
+	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
+		c.Status(422)
+		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
+	}
+	if len(params.Query) < 2 || len(params.Query) > 100 {
+		c.Status(422)
+		return c.SendString(`{"error": "q must be between 2 and 100 characters"}`)
+	}
+
+	// Search Addresses
+	addresses, err := crud.GetAddressSearchModel().SearchAPI(
+		params.Query,
+		params.Limit,
+	)
+	if err != nil {
+		zap.S().Warnf("Addresses Search CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not search addresses"}`)
+	}
+
+	if len(*addresses) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// Set X-TOTAL-COUNT
+	// NOTE number of results returned, matches are not counted
+	c.Append("X-TOTAL-COUNT", strconv.Itoa(len(*addresses)))
+
+	body, _ := json.Marshal(addresses)
+	return c.SendString(string(body))
+}
@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// Max days of a daily stats request
+const addressesStatsDailyMaxDays = 366
+
+type AddressesStatsDailyQuery struct {
+	From string `query:"from"` // UTC, YYYY-MM-DD
+	To   string `query:"to"`   // UTC, YYYY-MM-DD
+}
+
+// AddressesStats - latest address stats snapshot grouped by type
+// Shares are of the balance of every address
+type AddressesStats struct {
+	Timestamp           uint64                `json:"timestamp"`
+	Total               *models.AddressStat   `json:"total"`
+	TopHolders          []*models.AddressStat `json:"top_holders"`
+	BalanceDistribution []*models.AddressStat `json:"balance_distribution"`
+	AddressTypes        []*models.AddressStat `json:"address_types"`
+	NewAddresses        []*models.AddressStat `json:"new_addresses"`
+}
+
+// Addresses Stats
+// @Summary Get Addresses Stats
+// @Description get top holder concentration, balance distribution in ICX, address type counts and new addresses by day
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Router /api/v1/addresses/stats [get]
+// @Success 200 {object} AddressesStats
+// @Failure 500 {object} map[string]interface{}
+func handlerGetAddressesStats(c *fiber.Ctx) error {
+
+	// Get Address Stats
+	addressStats, err := crud.GetAddressStatModel().SelectAll()
+	if err != nil {
+		zap.S().Warnf("Addresses Stats CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not retrieve address stats"}`)
+	}
+
+	if len(*addressStats) == 0 {
+		// No Content
+		// NOTE snapshot not computed yet
+		c.Status(204)
+	}
+
+	stats := &AddressesStats{
+		TopHolders:          []*models.AddressStat{},
+		BalanceDistribution: []*models.AddressStat{},
+		AddressTypes:        []*models.AddressStat{},
+		NewAddresses:        []*models.AddressStat{},
+	}
+	for i := range *addressStats {
+		addressStat := &(*addressStats)[i]
+
+		stats.Timestamp = addressStat.Timestamp
+
+		switch addressStat.Type {
+		case crud.AddressStatTypeTotal:
+			stats.Total = addressStat
+		case crud.AddressStatTypeTopHolders:
+			stats.TopHolders = append(stats.TopHolders, addressStat)
+		case crud.AddressStatTypeBalanceDistribution:
+			stats.BalanceDistribution = append(stats.BalanceDistribution, addressStat)
+		case crud.AddressStatTypeAddressType:
+			stats.AddressTypes = append(stats.AddressTypes, addressStat)
+		case crud.AddressStatTypeNewAddresses:
+			stats.NewAddresses = append(stats.NewAddresses, addressStat)
+		}
+	}
+
+	body, _ := json.Marshal(stats)
+	return c.SendString(string(body))
+}
+
+// Addresses Stats Daily
+// @Summary Get Addresses Daily Stats
+// @Description get active addresses, new addresses, transactions and ICX volume by UTC day, days without transactions are left out
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param from query string false "first day, YYYY-MM-DD, defaults to 29 days before to"
+// @Param to query string false "last day, YYYY-MM-DD, defaults to today"
+// @Router /api/v1/addresses/stats/daily [get]
+// @Success 200 {object} []models.AddressDailyStat
+// @Failure 422 {object} map[string]interface{}
+func handlerGetAddressesStatsDaily(c *fiber.Ctx) error {
+	params := new(AddressesStatsDailyQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Addresses Stats Daily Handler ERROR: %s", err.Error())
+
+		c.Status(422)
+		return c.SendString(`{"error": "could not parse query parameters"}`)
+	}
+
+	// Default Params
+	toDay := time.Now().UTC().Truncate(24 * time.Hour)
+	if params.To != "" {
+		day, err := time.Parse("2006-01-02", params.To)
+		if err != nil {
+			c.Status(422)
+			return c.SendString(`{"error": "to must be a day, YYYY-MM-DD"}`)
+		}
+		toDay = day
+	}
+	fromDay := toDay.AddDate(0, 0, -29)
+	if params.From != "" {
+		day, err := time.Parse("2006-01-02", params.From)
+		if err != nil {
+			c.Status(422)
+			return c.SendString(`{"error": "from must be a day, YYYY-MM-DD"}`)
+		}
+		fromDay = day
+	}
+
+	// Check Params
+	if fromDay.After(toDay) {
+		c.Status(422)
+		return c.SendString(`{"error": "from must be before to"}`)
+	}
+	if toDay.Sub(fromDay) >= addressesStatsDailyMaxDays*24*time.Hour {
+		c.Status(422)
+		return c.SendString(`{"error": "from and to must be less than 367 days apart"}`)
+	}
+
+	// Get Address Daily Stats
+	addressDailyStats, err := crud.GetAddressDailyStatModel().SelectManyByDayRange(
+		fromDay.Format("2006-01-02"),
+		toDay.Format("2006-01-02"),
+	)
+	if err != nil {
+		zap.S().Warnf("Addresses Stats Daily CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not retrieve address daily stats"}`)
+	}
+
+	if len(*addressDailyStats) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// Set X-TOTAL-COUNT
+	c.Append("X-TOTAL-COUNT", strconv.Itoa(len(*addressDailyStats)))
+
+	body, _ := json.Marshal(addressDailyStats)
+	return c.SendString(string(body))
+}
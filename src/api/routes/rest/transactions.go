@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"encoding/json"
+	"strconv"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+)
+
+type TransactionsQuery struct {
+	Limit            int    `query:"limit"`
+	Skip             int    `query:"skip"`
+	Direction        string `query:"direction"`
+	Type             string `query:"type"`
+	StartBlockNumber uint64 `query:"start_block_number"`
+	EndBlockNumber   uint64 `query:"end_block_number"`
+}
+
+// Address Transactions
+// @Summary Get Address Transactions
+// @Description get list of transactions by address, including internal ICX transfers
+// @Tags Addresses
+// @BasePath /api/v1
+// @Accept */*
+// @Produce json
+// @Param address path string true "address"
+// @Param limit query int false "amount of records"
+// @Param skip query int false "skip to a record"
+// @Param direction query string false "in, out, or both"
+// @Param type query string false "base, internal, or all"
+// @Param start_block_number query int false "block number range start"
+// @Param end_block_number query int false "block number range end"
+// @Router /api/v1/addresses/transactions/{address} [get]
+// @Success 200 {object} []models.Transaction
+// @Failure 422 {object} map[string]interface{}
+func handlerGetAddressTransactions(c *fiber.Ctx) error {
+	publicKey := c.Params("address")
+	if publicKey == "" {
+		c.Status(422)
+		return c.SendString(`{"error": "address required"}`)
+	}
+
+	params := new(TransactionsQuery)
+	if err := c.QueryParser(params); err != nil {
+		zap.S().Warnf("Transactions Get Handler ERROR: %s", err.Error())
+
+		c.Status(422)
+		return c.SendString(`{"error": "could not parse query parameters"}`)
+	}
+
+	// Default Params
+	if params.Limit <= 0 {
+		params.Limit = 25
+	}
+	if params.Direction == "" {
+		params.Direction = "both"
+	}
+	if params.Type == "all" {
+		params.Type = ""
+	}
+
+	// Check Params
+	if params.Limit < 1 || params.Limit > config.Config.MaxPageSize {
+		c.Status(422)
+		return c.SendString(`{"error": "limit must be greater than 0 and less than 101"}`)
+	}
+	if params.Skip < 0 || params.Skip > config.Config.MaxPageSkip {
+		c.Status(422)
+		return c.SendString(`{"error": "invalid skip"}`)
+	}
+	if params.Direction != "in" && params.Direction != "out" && params.Direction != "both" {
+		c.Status(422)
+		return c.SendString(`{"error": "direction must be in, out, or both"}`)
+	}
+	if params.Type != "" && params.Type != "base" && params.Type != "internal" {
+		c.Status(422)
+		return c.SendString(`{"error": "type must be base, internal, or all"}`)
+	}
+	if params.EndBlockNumber != 0 && params.StartBlockNumber > params.EndBlockNumber {
+		c.Status(422)
+		return c.SendString(`{"error": "start_block_number must be less than end_block_number"}`)
+	}
+
+	// Get Transactions
+	transactions, err := crud.GetTransactionModel().SelectManyByPublicKeyAPI(
+		params.Limit,
+		params.Skip,
+		publicKey,
+		params.Direction,
+		params.Type,
+		params.StartBlockNumber,
+		params.EndBlockNumber,
+	)
+	if err != nil {
+		zap.S().Warnf("Transactions CRUD ERROR: %s", err.Error())
+		c.Status(500)
+		return c.SendString(`{"error": "could not retrieve transactions"}`)
+	}
+
+	if len(*transactions) == 0 {
+		// No Content
+		c.Status(204)
+	}
+
+	// Set X-TOTAL-COUNT
+	// Total count in the transaction_count_by_public_keys table
+	counter, err := crud.GetTransactionCountByPublicKeyModel().SelectCount(publicKey)
+	if err != nil {
+		counter = 0
+		zap.S().Warn("Could not retrieve transaction count: ", err.Error())
+	}
+	c.Append("X-TOTAL-COUNT", strconv.FormatUint(counter, 10))
+
+	body, _ := json.Marshal(transactions)
+	return c.SendString(string(body))
+}
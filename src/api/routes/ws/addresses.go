@@ -0,0 +1,155 @@
+package ws
+
+import (
+	"encoding/json"
+	"strings"
+
+	fiber "github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/redis"
+)
+
+// AddressesFilter - per client filter for the addresses stream
+// NOTE clients can send a new filter as a JSON message at any time
+type AddressesFilter struct {
+	PublicKeys []string `json:"public_keys"`
+	IsContract bool     `json:"is_contract"`
+	IsToken    bool     `json:"is_token"`
+}
+
+func AddressesAddHandlers(app *fiber.App) {
+
+	prefix := config.Config.WebsocketPrefix + "/addresses"
+
+	app.Use(prefix, func(c *fiber.Ctx) error {
+		// Only accept websocket upgrades
+		if websocket.IsWebSocketUpgrade(c) {
+			return c.Next()
+		}
+		return fiber.ErrUpgradeRequired
+	})
+
+	app.Get(prefix+"/", websocket.New(handlerGetAddresses))
+}
+
+// Addresses
+// Streams address updates published by the worker
+// Query params: address (comma separated), is_contract, is_token
+func handlerGetAddresses(c *websocket.Conn) {
+
+	// Initial filter from query params
+	filter := &AddressesFilter{
+		PublicKeys: []string{},
+		IsContract: c.Query("is_contract") == "true",
+		IsToken:    c.Query("is_token") == "true",
+	}
+	if publicKeys := c.Query("address"); publicKeys != "" {
+		filter.PublicKeys = strings.Split(publicKeys, ",")
+	}
+
+	// Add broadcaster
+	// NOTE buffered, the broadcaster closes channels that block
+	msgChan := make(chan []byte, config.Config.WebsocketBufferSize)
+	id := redis.GetBroadcaster().AddBroadcastChannel(msgChan)
+	defer func() {
+		// Remove broadcaster
+		redis.GetBroadcaster().RemoveBroadcastChannel(id)
+	}()
+
+	// Read for filters and close
+	clientFilterChan := make(chan *AddressesFilter)
+	clientCloseSig := make(chan bool)
+	handlerDoneSig := make(chan bool)
+	defer close(handlerDoneSig)
+	go func() {
+		for {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				close(clientCloseSig)
+				break
+			}
+
+			newFilter := &AddressesFilter{}
+			err = json.Unmarshal(msg, newFilter)
+			if err != nil {
+				zap.S().Warn("Addresses WS: Invalid filter message: ", string(msg))
+				continue
+			}
+
+			select {
+			case clientFilterChan <- newFilter:
+			case <-handlerDoneSig:
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case msg, ok := <-msgChan:
+			if ok == false {
+				// Removed by the broadcaster, client too slow
+				c.WriteMessage(
+					websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client too slow"),
+				)
+				return
+			}
+
+			// Filter
+			address := &models.Address{}
+			err := json.Unmarshal(msg, address)
+			if err != nil {
+				zap.S().Warn("Addresses WS: Invalid broadcast message: ", err.Error())
+				continue
+			}
+			if filter.match(address) == false {
+				continue
+			}
+
+			// Broadcast
+			err = c.WriteMessage(websocket.TextMessage, msg)
+			if err != nil {
+				return
+			}
+		case newFilter := <-clientFilterChan:
+			filter = newFilter
+		case <-clientCloseSig:
+			return
+		}
+	}
+}
+
+func (f *AddressesFilter) match(address *models.Address) bool {
+
+	// Public keys
+	if len(f.PublicKeys) > 0 {
+		isMatch := false
+		for _, publicKey := range f.PublicKeys {
+			if publicKey == address.PublicKey {
+				isMatch = true
+				break
+			}
+		}
+
+		if isMatch == false {
+			return false
+		}
+	}
+
+	// Contracts only
+	if f.IsContract == true && address.IsContract == false {
+		return false
+	}
+
+	// Tokens only
+	if f.IsToken == true && address.IsToken == false {
+		return false
+	}
+
+	return true
+}
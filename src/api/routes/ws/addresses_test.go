@@ -0,0 +1,43 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestAddressesFilterMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	eoa := &models.Address{PublicKey: "hx0000000000000000000000000000000000000001"}
+	contract := &models.Address{PublicKey: "cx0000000000000000000000000000000000000002", IsContract: true}
+	token := &models.Address{PublicKey: "cx0000000000000000000000000000000000000003", IsContract: true, IsToken: true}
+
+	// No filter
+	filter := &AddressesFilter{}
+	assert.Equal(true, filter.match(eoa))
+	assert.Equal(true, filter.match(contract))
+
+	// Public keys
+	filter = &AddressesFilter{PublicKeys: []string{eoa.PublicKey, token.PublicKey}}
+	assert.Equal(true, filter.match(eoa))
+	assert.Equal(false, filter.match(contract))
+	assert.Equal(true, filter.match(token))
+
+	// Contracts only
+	filter = &AddressesFilter{IsContract: true}
+	assert.Equal(false, filter.match(eoa))
+	assert.Equal(true, filter.match(contract))
+
+	// Tokens only
+	filter = &AddressesFilter{IsToken: true}
+	assert.Equal(false, filter.match(contract))
+	assert.Equal(true, filter.match(token))
+
+	// Public keys and tokens only
+	filter = &AddressesFilter{PublicKeys: []string{eoa.PublicKey}, IsToken: true}
+	assert.Equal(false, filter.match(eoa))
+	assert.Equal(false, filter.match(token))
+}
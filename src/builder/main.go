@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/logging"
+)
+
+// Builder - inspect and move builder checkpoints
+// Usage: builder [-builder balance] status | builder -block-number <block number> rewind | builder reset
+// NOTE running builders pick up a rewind before their next batch
+func main() {
+	builder := flag.String("builder", "balance", "builder name")
+	blockNumber := flag.Int64("block-number", -1, "first block to rebuild with rewind")
+	flag.Parse()
+
+	config.ReadEnvironment()
+
+	logging.Init()
+	log.Printf("Main: Starting logging with level %s", config.Config.LogLevel)
+
+	if crud.IsBuilderValid(*builder) == false {
+		zap.S().Fatal("Builder: unknown builder ", *builder)
+	}
+
+	switch flag.Arg(0) {
+	case "status":
+		builderCheckpoints, err := crud.GetBuilderCheckpointModel().SelectMany(*builder)
+		if err != nil {
+			zap.S().Fatal("Unable to select builder checkpoints: ", err.Error())
+		}
+
+		for i := range *builderCheckpoints {
+			builderCheckpoint := &(*builderCheckpoints)[i]
+
+			fmt.Printf(
+				"%s\tshard %d/%d\tblock %d\tchecksum %s\n",
+				builderCheckpoint.Builder,
+				builderCheckpoint.Shard,
+				builderCheckpoint.ShardCount,
+				builderCheckpoint.BlockNumber,
+				builderCheckpoint.Checksum,
+			)
+		}
+	case "rewind":
+		if *blockNumber < 0 {
+			zap.S().Fatal("Builder: -block-number required")
+		}
+
+		rewindBuilderCheckpoints(*builder, uint64(*blockNumber))
+	case "reset":
+		rewindBuilderCheckpoints(*builder, 0)
+	default:
+		fmt.Fprintln(os.Stderr, "Usage: builder [-builder balance] status | builder -block-number <block number> rewind | builder reset")
+		os.Exit(2)
+	}
+}
+
+func rewindBuilderCheckpoints(builder string, blockNumber uint64) {
+	count, err := crud.GetBuilderCheckpointModel().RewindMany(builder, blockNumber)
+	if err != nil {
+		zap.S().Fatal("Unable to rewind builder checkpoints: ", err.Error())
+	}
+
+	zap.S().Info("Builder: Rewound ", count, " checkpoints of ", builder, " to block ", blockNumber)
+}
@@ -16,16 +16,35 @@ type configType struct {
 	MetricsPort string `envconfig:"METRICS_PORT" required:"false" default:"9400"`
 
 	// Prefix
-	RestPrefix    string `envconfig:"REST_PREFIX" required:"false" default:"/api/v1"`
-	HealthPrefix  string `envconfig:"HEALTH_PREFIX" required:"false" default:"/health"`
-	MetricsPrefix string `envconfig:"METRICS_PREFIX" required:"false" default:"/metrics"`
+	RestPrefix      string `envconfig:"REST_PREFIX" required:"false" default:"/api/v1"`
+	WebsocketPrefix string `envconfig:"WEBSOCKET_PREFIX" required:"false" default:"/ws/v1"`
+	HealthPrefix    string `envconfig:"HEALTH_PREFIX" required:"false" default:"/health"`
+	MetricsPrefix   string `envconfig:"METRICS_PREFIX" required:"false" default:"/metrics"`
 
 	// Endpoints
 	MaxPageSize int `envconfig:"MAX_PAGE_SIZE" required:"false" default:"100"`
 	MaxPageSkip int `envconfig:"MAX_PAGE_SKIP" required:"false" default:"1000000"`
 
+	// Websockets
+	WebsocketBufferSize int `envconfig:"WEBSOCKET_BUFFER_SIZE" required:"false" default:"100"` // messages queued per client before it is dropped
+
+	// Admin
+	// NOTE admin endpoints are disabled if ADMIN_API_KEY is empty
+	AdminAPIKey string `envconfig:"ADMIN_API_KEY" required:"false" default:""`
+
 	// Icon node service
-	IconNodeServiceURL string `envconfig:"ICON_NODE_SERVICE_URL" required:"false" default:"https://ctz.solidwallet.io/api/v3"`
+	// NOTE ICON_NODE_SERVICE_URLS="<url>,<url>,..." fails over in order, replaces ICON_NODE_SERVICE_URL
+	IconNodeServiceURL             string  `envconfig:"ICON_NODE_SERVICE_URL" required:"false" default:"https://ctz.solidwallet.io/api/v3"`
+	IconNodeServiceURLs            string  `envconfig:"ICON_NODE_SERVICE_URLS" required:"false" default:""`
+	IconNodeServiceTimeoutMilli    int     `envconfig:"ICON_NODE_SERVICE_TIMEOUT_MILLI" required:"false" default:"10000"`
+	IconNodeServiceMaxRetries      int     `envconfig:"ICON_NODE_SERVICE_MAX_RETRIES" required:"false" default:"5"`
+	IconNodeServiceBackoffMinMilli int     `envconfig:"ICON_NODE_SERVICE_BACKOFF_MIN_MILLI" required:"false" default:"250"`
+	IconNodeServiceBackoffMaxMilli int     `envconfig:"ICON_NODE_SERVICE_BACKOFF_MAX_MILLI" required:"false" default:"10000"`
+	IconNodeServiceRateLimit       float64 `envconfig:"ICON_NODE_SERVICE_RATE_LIMIT" required:"false" default:"20"`  // http requests per second, 0 for no limit
+	IconNodeServiceBatchSize       int     `envconfig:"ICON_NODE_SERVICE_BATCH_SIZE" required:"false" default:"100"` // JSON-RPC requests per http request
+
+	// Tokens
+	TokenDecimalsFallbackTTLSeconds int `envconfig:"TOKEN_DECIMALS_FALLBACK_TTL_SECONDS" required:"false" default:"3600"` // tokens without decimals use 18 until retried
 
 	// CORS
 	CORSAllowOrigins  string `envconfig:"CORS_ALLOW_ORIGINS" required:"false" default:"*"`
@@ -51,6 +70,15 @@ type configType struct {
 	SchemaRegistryURL string `envconfig:"SCHEMA_REGISTRY_URL" required:"false" default:"localhost:8081"`
 	KafkaGroupID      string `envconfig:"KAFKA_GROUP_ID" required:"false" default:"addresses-service"`
 
+	// Schema registry
+	// NOTE SCHEMA_REGISTRY_FILE replaces the registry at SCHEMA_REGISTRY_URL with a local file
+	SchemaRegistryValidate bool   `envconfig:"SCHEMA_REGISTRY_VALIDATE" required:"false" default:"true"`
+	SchemaRegistryFile     string `envconfig:"SCHEMA_REGISTRY_FILE" required:"false" default:""`
+
+	// NOTE registry lookups are retried with doubling backoff, messages are not committed if the registry stays unreachable
+	SchemaRegistryRetries                  int `envconfig:"SCHEMA_REGISTRY_RETRIES" required:"false" default:"5"`
+	SchemaRegistryRetryBackoffMilliseconds int `envconfig:"SCHEMA_REGISTRY_RETRY_BACKOFF_MILLISECONDS" required:"false" default:"500"`
+
 	// Topics
 	ConsumerGroup                         string `envconfig:"CONSUMER_GROUP" required:"false" default:"addresses-consumer-group"`
 	ConsumerIsTail                        bool   `envconfig:"CONSUMER_IS_TAIL" required:"false" default:"false"`
@@ -61,10 +89,12 @@ type configType struct {
 	ConsumerTopicLogs                     string `envconfig:"CONSUMER_TOPIC_LOGS" required:"false" default:"logs"`
 	ConsumerTopicContractsProcessed       string `envconfig:"CONSUMER_TOPIC_CONTRACTS_PROCESSED" required:"false" default:"contracts-processed"`
 	ConsumerTopicGovernancePrepsProcessed string `envconfig:"CONSUMER_TOPIC_GOVERNANCE_PREPS_PROCESSED" required:"false" default:"governance-preps-processed"`
+	ConsumerTopicFormats                  string `envconfig:"CONSUMER_TOPIC_FORMATS" required:"false" default:""` // topic:format,... - protobuf or json
 	ConsumerIsPartitionConsumer           bool   `envconfig:"CONSUMER_IS_PARTITION_CONSUMER" required:"false" default:"false"`
 	ConsumerPartition                     int    `envconfig:"CONSUMER_PARTITION" required:"false" default:"0"`
 	ConsumerPartitionTopic                string `envconfig:"CONSUMER_PARTITION_TOPIC" required:"false" default:"blocks"`
 	ConsumerPartitionStartOffset          int    `envconfig:"CONSUMER_PARTITION_START_OFFSET" required:"false" default:"1"`
+	ProducerTopicDeadLetter               string `envconfig:"PRODUCER_TOPIC_DEAD_LETTER" required:"false" default:"addresses-dead-letter"`
 
 	// DB
 	DbDriver             string `envconfig:"DB_DRIVER" required:"false" default:"postgres"`
@@ -77,6 +107,7 @@ type configType struct {
 	DbTimezone           string `envconfig:"DB_TIMEZONE" required:"false" default:"UTC"`
 	DbMaxIdleConnections int    `envconfig:"DB_MAX_IDLE_CONNECTIONS" required:"false" default:"2"`
 	DbMaxOpenConnections int    `envconfig:"DB_MAX_OPEN_CONNECTIONS" required:"false" default:"10"`
+	DbAutoMigrate        bool   `envconfig:"DB_AUTO_MIGRATE" required:"false" default:"false"` // schema is managed by the migrate command, true for local development only
 
 	// Redis
 	RedisHost                     string `envconfig:"REDIS_HOST" required:"false" default:"localhost"`
@@ -86,9 +117,45 @@ type configType struct {
 	RedisSentinelClientMode       bool   `envconfig:"REDIS_SENTINEL_CLIENT_MODE" required:"false" default:"false"`
 	RedisSentinelClientMasterName string `envconfig:"REDIS_SENTINEL_CLIENT_MASTER_NAME" required:"false" default:"master"`
 
+	// Loaders
+	// NOTE LOADER_BATCH_OVERRIDES="<loader>:<batch size>:<flush interval milli>,..."
+	LoaderBatchSize          int    `envconfig:"LOADER_BATCH_SIZE" required:"false" default:"500"`
+	LoaderFlushIntervalMilli int    `envconfig:"LOADER_FLUSH_INTERVAL_MILLI" required:"false" default:"1000"`
+	LoaderBatchOverrides     string `envconfig:"LOADER_BATCH_OVERRIDES" required:"false" default:""`
+
 	// GORM
 	GormLoggingThresholdMilli int `envconfig:"GORM_LOGGING_THRESHOLD_MILLI" required:"false" default:"250"`
 
+	// Labels
+	// NOTE ADDRESS_LABELS_FILE is a YAML or JSON list of {"address": "hx...", "labels": ["exchange"]}, loaded on start
+	AddressLabelsFile string `envconfig:"ADDRESS_LABELS_FILE" required:"false" default:""`
+
+	// Builders
+	// NOTE changing BALANCE_BUILDER_SHARDS resumes from the last block every shard of the previous count completed
+	BalanceBuilderShards      int `envconfig:"BALANCE_BUILDER_SHARDS" required:"false" default:"4"`
+	BalanceBuilderBatchBlocks int `envconfig:"BALANCE_BUILDER_BATCH_BLOCKS" required:"false" default:"100"`  // blocks per transaction
+	BalanceBuilderCacheSize   int `envconfig:"BALANCE_BUILDER_CACHE_SIZE" required:"false" default:"100000"` // balances per shard
+
+	// NOTE the address daily stat builder follows the last block every balance builder shard completed
+	AddressDailyStatBuilderBatchBlocks int `envconfig:"ADDRESS_DAILY_STAT_BUILDER_BATCH_BLOCKS" required:"false" default:"1000"` // blocks per transaction
+
+	// Routines
+	// NOTE the balance routine is a full scan, addresses touched by new blocks are refreshed by the balance refresh routine
+	BalanceRoutineIntervalSeconds        int `envconfig:"BALANCE_ROUTINE_INTERVAL_SECONDS" required:"false" default:"86400"`
+	BalanceRefreshIntervalMilli          int `envconfig:"BALANCE_REFRESH_INTERVAL_MILLI" required:"false" default:"1000"` // wait when no addresses are dirty
+	BalanceRefreshBatchSize              int `envconfig:"BALANCE_REFRESH_BATCH_SIZE" required:"false" default:"100"`      // addresses per node batch
+	BalanceReconciliationIntervalSeconds int `envconfig:"BALANCE_RECONCILIATION_INTERVAL_SECONDS" required:"false" default:"86400"`
+	AddressStatsIntervalSeconds          int `envconfig:"ADDRESS_STATS_INTERVAL_SECONDS" required:"false" default:"3600"`
+	AddressStatsNewAddressesDays         int `envconfig:"ADDRESS_STATS_NEW_ADDRESSES_DAYS" required:"false" default:"30"` // days of new addresses in the snapshot
+	AddressActivityIntervalSeconds       int `envconfig:"ADDRESS_ACTIVITY_INTERVAL_SECONDS" required:"false" default:"86400"`
+
+	// Reorgs
+	// NOTE forks deeper than REORG_MAX_DEPTH blocks below the latest block are not rolled back, 0 to disable
+	ReorgMaxDepth uint32 `envconfig:"REORG_MAX_DEPTH" required:"false" default:"100"`
+
+	// Shutdown
+	ShutdownDrainTimeout int `envconfig:"SHUTDOWN_DRAIN_TIMEOUT" required:"false" default:"30"` // seconds
+
 	// Feature flags
 	OnlyRunAllRoutines bool `envconfig:"ONLY_RUN_ALL_ROUTINES" required:"false" default:"false"`
 }
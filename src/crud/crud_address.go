@@ -1,15 +1,22 @@
 package crud
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"math/big"
 	"reflect"
+	"strconv"
 	"sync"
 
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/redis"
 )
 
 // AddressModel - type for address table model
@@ -42,7 +49,7 @@ func GetAddressModel() *AddressModel {
 			zap.S().Fatal("AddressModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartAddressLoader()
+		StartAddressLoader(global.ShutdownContext())
 	})
 
 	return addressModel
@@ -51,7 +58,7 @@ func GetAddressModel() *AddressModel {
 // Migrate - migrate addresss table
 func (m *AddressModel) Migrate() error {
 	// Only using AddressRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -114,25 +121,305 @@ func (m *AddressModel) SelectMany(
 	return addresses, db.Error
 }
 
+// SelectManyAfterPublicKey - select many addresses in public key order, after a public key
+// Pages by key so rows inserted while paging are not skipped or repeated
+func (m *AddressModel) SelectManyAfterPublicKey(
+	limit int,
+	afterPublicKey string,
+) (*[]models.Address, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Address{})
+
+	// After public key
+	db = db.Where("public_key > ?", afterPublicKey)
+
+	// Order
+	db = db.Order("public_key ASC")
+
+	// Limit
+	db = db.Limit(limit)
+
+	addresses := &[]models.Address{}
+	db = db.Find(addresses)
+
+	return addresses, db.Error
+}
+
+// SelectManyByPublicKeys - select many addresses by public key
+// Public keys without a row are left out
+func (m *AddressModel) SelectManyByPublicKeys(
+	publicKeys []string,
+) (*[]models.Address, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Address{})
+
+	// Public Keys
+	db = db.Where("public_key IN ?", publicKeys)
+
+	addresses := &[]models.Address{}
+	db = db.Find(addresses)
+
+	return addresses, db.Error
+}
+
+// SelectActivitiesByPublicKeys - first seen and last active of addresses from their base and internal transactions and token transfers
+// Only the public key and activity fields are set
+// Public keys without a transaction or token transfer are left out
+// NOTE slow operation, scans every transaction and token transfer of the addresses
+func (m *AddressModel) SelectActivitiesByPublicKeys(
+	publicKeys []string,
+) (*[]models.Address, error) {
+	db := m.db
+
+	addresses := &[]models.Address{}
+	db = db.Raw(
+		`WITH t AS (
+			SELECT from_address AS public_key, block_number, block_timestamp, hash, transaction_index, log_index
+			FROM transactions WHERE from_address IN ?
+			UNION ALL
+			SELECT to_address, block_number, block_timestamp, hash, transaction_index, log_index
+			FROM transactions WHERE to_address IN ?
+			UNION ALL
+			SELECT public_key, block_number, timestamp, transaction_hash, transaction_index, log_index
+			FROM token_balances WHERE public_key IN ?
+		), first_seen AS (
+			SELECT DISTINCT ON (public_key) public_key, block_number, block_timestamp, hash
+			FROM t
+			ORDER BY public_key, block_number ASC, transaction_index ASC, log_index ASC
+		), last_active AS (
+			SELECT DISTINCT ON (public_key) public_key, block_number, block_timestamp
+			FROM t
+			ORDER BY public_key, block_number DESC, transaction_index DESC, log_index DESC
+		)
+		SELECT
+			f.public_key,
+			f.block_number AS first_seen_block_number,
+			f.block_timestamp AS first_seen_timestamp,
+			f.hash AS first_seen_transaction_hash,
+			l.block_number AS last_active_block_number,
+			l.block_timestamp AS last_active_timestamp
+		FROM first_seen f
+		JOIN last_active l ON l.public_key = f.public_key`,
+		publicKeys,
+		publicKeys,
+		publicKeys,
+	).Scan(addresses)
+
+	return addresses, db.Error
+}
+
+// AddressesAPIFilter - filters for the addresses api list
+// NOTE nil fields are not filtered on
+type AddressesAPIFilter struct {
+	PublicKey           string
+	IsContract          *bool
+	IsToken             *bool
+	IsPrep              *bool
+	MinBalance          *float64
+	MaxBalance          *float64
+	MinTransactionCount *uint64
+	MaxTransactionCount *uint64
+	MinFirstSeen        *uint64  // Timestamp
+	MaxFirstSeen        *uint64  // Timestamp, seen addresses only
+	MinLastActive       *uint64  // Timestamp
+	MaxLastActive       *uint64  // Timestamp, seen addresses only
+	Labels              []string // Any of
+	Type                string   // Label
+}
+
+// IsEmpty - true if no filters are set
+func (f *AddressesAPIFilter) IsEmpty() bool {
+	return f.PublicKey == "" &&
+		f.IsContract == nil &&
+		f.IsToken == nil &&
+		f.IsPrep == nil &&
+		f.MinBalance == nil &&
+		f.MaxBalance == nil &&
+		f.MinTransactionCount == nil &&
+		f.MaxTransactionCount == nil &&
+		f.MinFirstSeen == nil &&
+		f.MaxFirstSeen == nil &&
+		f.MinLastActive == nil &&
+		f.MaxLastActive == nil &&
+		len(f.Labels) == 0 &&
+		f.Type == ""
+}
+
+func (f *AddressesAPIFilter) apply(db *gorm.DB) *gorm.DB {
+
+	// Public key
+	if f.PublicKey != "" {
+		db = db.Where("public_key = ?", f.PublicKey)
+	}
+
+	// Is contract
+	if f.IsContract != nil {
+		db = db.Where("is_contract = ?", *f.IsContract)
+	}
+
+	// Is token
+	if f.IsToken != nil {
+		db = db.Where("is_token = ?", *f.IsToken)
+	}
+
+	// Is prep
+	if f.IsPrep != nil {
+		db = db.Where("is_prep = ?", *f.IsPrep)
+	}
+
+	// Balance range
+	// NOTE on the exact loop column, as sorted
+	if f.MinBalance != nil {
+		db = db.Where("balance_loop >= ?::numeric", icxToLoop(*f.MinBalance, true))
+	}
+	if f.MaxBalance != nil {
+		db = db.Where("balance_loop <= ?::numeric", icxToLoop(*f.MaxBalance, false))
+	}
+
+	// Transaction count range
+	if f.MinTransactionCount != nil {
+		db = db.Where("transaction_count >= ?", *f.MinTransactionCount)
+	}
+	if f.MaxTransactionCount != nil {
+		db = db.Where("transaction_count <= ?", *f.MaxTransactionCount)
+	}
+
+	// First seen range
+	if f.MinFirstSeen != nil {
+		db = db.Where("first_seen_timestamp >= ?", *f.MinFirstSeen)
+	}
+	if f.MaxFirstSeen != nil {
+		db = db.Where("first_seen_timestamp > 0 AND first_seen_timestamp <= ?", *f.MaxFirstSeen)
+	}
+
+	// Last active range
+	if f.MinLastActive != nil {
+		db = db.Where("last_active_timestamp >= ?", *f.MinLastActive)
+	}
+	if f.MaxLastActive != nil {
+		db = db.Where("last_active_timestamp > 0 AND last_active_timestamp <= ?", *f.MaxLastActive)
+	}
+
+	// Labels
+	if len(f.Labels) > 0 {
+		db = db.Where("public_key IN (SELECT public_key FROM address_labels WHERE label IN ?)", f.Labels)
+	}
+
+	// Type
+	// NOTE types are labels, the type field of the address is derived from them
+	if f.Type != "" {
+		db = db.Where("public_key IN (SELECT public_key FROM address_labels WHERE label = ?)", f.Type)
+	}
+
+	return db
+}
+
+// icxToLoop - ICX amount in loop, a decimal string
+// Rounded up or down to a whole loop
+func icxToLoop(icx float64, roundUp bool) string {
+	// Shortest decimal of the float, 0.1 is not 0.1000000000000000055...
+	loop, _ := new(big.Rat).SetString(strconv.FormatFloat(icx, 'f', -1, 64))
+	loop.Mul(loop, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)))
+
+	quotient, remainder := new(big.Int).QuoRem(loop.Num(), loop.Denom(), new(big.Int))
+	if remainder.Sign() != 0 && (remainder.Sign() > 0) == roundUp {
+		if roundUp == true {
+			quotient.Add(quotient, big.NewInt(1))
+		} else {
+			quotient.Sub(quotient, big.NewInt(1))
+		}
+	}
+
+	return quotient.String()
+}
+
+// AddressesAPISortFields - columns the addresses api list can be sorted by
+var AddressesAPISortFields = []string{
+	"balance",
+	"transaction_count",
+	"log_count",
+	"created_timestamp",
+	"first_seen_timestamp",
+	"last_active_timestamp",
+	"public_key",
+}
+
+// AddressesAPICursor - keyset position in a sorted address list
+// Value is the sort field value of the last row seen
+type AddressesAPICursor struct {
+	Value     interface{}
+	PublicKey string
+}
+
+// addressesAPISortColumn - column to sort by for a sort field
+// NOTE balance sorts on the exact loop column
+func addressesAPISortColumn(sortField string) string {
+	if sortField == "balance" {
+		return "balance_loop"
+	}
+
+	return sortField
+}
+
+// applyAddressesAPICursor - keyset condition on (sortColumn, public_key)
+// NOTE sortColumn must be whitelisted by the caller
+func applyAddressesAPICursor(db *gorm.DB, cursor *AddressesAPICursor, sortColumn string, sortDesc bool) *gorm.DB {
+	if cursor == nil {
+		return db
+	}
+
+	operator := ">"
+	if sortDesc == true {
+		operator = "<"
+	}
+
+	if sortColumn == "public_key" {
+		return db.Where("public_key "+operator+" ?", cursor.PublicKey)
+	}
+
+	placeholder := "?"
+	if sortColumn == "balance_loop" {
+		// Exact balances are passed as decimal strings
+		placeholder = "CAST(? AS numeric)"
+	}
+
+	return db.Where("("+sortColumn+", public_key) "+operator+" ("+placeholder+", ?)", cursor.Value, cursor.PublicKey)
+}
+
 // SelectManyAPI - select many from addreses table
+// NOTE sortField must be one of AddressesAPISortFields
+// NOTE cursor is optional, use skip or cursor
 func (m *AddressModel) SelectManyAPI(
 	limit int,
 	skip int,
-	publicKey string,
+	cursor *AddressesAPICursor,
+	filter *AddressesAPIFilter,
+	sortField string,
+	sortDesc bool,
 ) (*[]models.AddressAPIList, error) {
 	db := m.db
 
 	// Set table
 	db = db.Model(&models.Address{})
 
-	// Order balances
-	db = db.Order("balance DESC")
-
-	// Public key
-	if publicKey != "" {
-		db = db.Where("public_key = ?", publicKey)
+	// Order
+	sortColumn := addressesAPISortColumn(sortField)
+	db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: sortColumn}, Desc: sortDesc})
+	if sortColumn != "public_key" {
+		// Tie breaker
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: "public_key"}, Desc: sortDesc})
 	}
 
+	// Filters
+	db = filter.apply(db)
+
+	// Cursor
+	db = applyAddressesAPICursor(db, cursor, sortColumn, sortDesc)
+
 	// Limit
 	db = db.Limit(limit)
 
@@ -147,22 +434,46 @@ func (m *AddressModel) SelectManyAPI(
 	return addresses, db.Error
 }
 
+// CountAPI - count addresses matching the api list filters
+// NOTE slow operation on large filters
+func (m *AddressModel) CountAPI(
+	filter *AddressesAPIFilter,
+) (int64, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Address{})
+
+	// Filters
+	db = filter.apply(db)
+
+	count := int64(0)
+	db = db.Count(&count)
+
+	return count, db.Error
+}
+
 // SelectManyContractsAPI - select many from addreses table
+// NOTE cursor is optional, use skip or cursor
 func (m *AddressModel) SelectManyContractsAPI(
 	limit int,
 	skip int,
+	cursor *AddressesAPICursor,
 ) (*[]models.ContractAPIList, error) {
 	db := m.db
 
 	// Set table
 	db = db.Model(&models.Address{})
 
-	// Order balances
-	db = db.Order("transaction_count DESC")
+	// Order transaction count
+	db = db.Order("transaction_count DESC, public_key DESC")
 
 	// Is contract
 	db = db.Where("is_contract = ?", true)
 
+	// Cursor
+	db = applyAddressesAPICursor(db, cursor, "transaction_count", true)
+
 	// Limit
 	db = db.Limit(limit)
 
@@ -236,6 +547,22 @@ func (m *AddressModel) UpsertOne(
 		reflect.TypeOf(*address),
 	)
 
+	// Activity only moves earlier or later, balance is written with balance loop
+	for column, coupledColumns := range addressCoupledColumns {
+		if _, ok := updateOnConflictValues[column]; ok == true {
+			for _, coupledColumn := range coupledColumns {
+				updateOnConflictValues[coupledColumn] = true
+			}
+		}
+	}
+	for column, expression := range addressActivityUpdateExpressions {
+		if _, ok := updateOnConflictValues[column]; ok == true {
+			updateOnConflictValues[column] = expression
+		}
+	}
+
+	setAddressInsertDefaults(address)
+
 	// Upsert
 	db = db.Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "public_key"}}, // NOTE set to primary keys for table
@@ -246,133 +573,284 @@ func (m *AddressModel) UpsertOne(
 }
 
 // StartAddressLoader starts loader
-func StartAddressLoader() {
-	go func() {
-
-		for {
-			// Read address
-			newAddress := <-GetAddressModel().LoaderChannel
-
-			/////////////////
-			// Enrichments //
-			/////////////////
-			transactionCount := uint64(0)
-			logCount := uint64(0)
-			// balance := float64(0)
-			name := ""                    // Only contracts
-			createdTimestamp := uint64(0) // Only contracts
-			status := ""                  // Only contracts
-			isToken := false              // Only contracts
-			isGovernancePrep := false
-
-			//////////////////////////////////
-			// Transaction Count By Address //
-			//////////////////////////////////
-
-			// transaction count
-			count, err := GetTransactionCountByPublicKeyModel().SelectCount(
-				newAddress.PublicKey,
-			)
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				count = 0
-			} else if err != nil {
-				// Postgres error
-				zap.S().Fatal(err.Error())
-			}
-			transactionCount = count
-
-			//////////////////////////
-			// Log Count By Address //
-			//////////////////////////
-
-			// transaction count
-			count, err = GetLogCountByPublicKeyModel().SelectCount(
-				newAddress.PublicKey,
-			)
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				count = 0
-			} else if err != nil {
-				// Postgres error
-				zap.S().Fatal(err.Error())
-			}
-			logCount = count
-
-			//////////////
-			// Balances //
-			//////////////
-
-			// TODO creating routine for balances temp
-			// current balance
-			// currentBalance, err := GetBalanceModel().SelectLatest(newAddress.PublicKey)
-			// if errors.Is(err, gorm.ErrRecordNotFound) {
-			// balance = 0
-			// } else if err != nil {
+func StartAddressLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"address",
+		GetAddressModel().db,
+		[]string{"public_key"}, // NOTE set to primary keys for table
+		true,
+	)
+
+	// Addresses to publish by public key, set for each batch
+	// NOTE only accessed from the loader go routine
+	publishedAddresses := map[string]*models.Address{}
+
+	loader.enrichBatch = func(rows []interface{}) []interface{} {
+		newAddresses := make([]*models.Address, len(rows))
+		for i, row := range rows {
+			newAddresses[i] = row.(*models.Address)
+		}
+
+		err := enrichAddresses(newAddresses)
+		if err != nil {
 			// Postgres error
-			//	zap.S().Fatal(err.Error())
-			// } else {
-			// balance = currentBalance.ValueDecimal
-			// }
-
-			///////////////
-			// Contracts //
-			///////////////
-
-			// Contract data
-			contract, err := GetContractModel().SelectOne(newAddress.PublicKey)
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				contract = &models.ContractProcessed{
-					Address:          newAddress.PublicKey,
-					Name:             "",
-					CreatedTimestamp: 0,
-					Status:           "",
-					IsToken:          false,
-				}
-			} else if err != nil {
-				// Postgres error
-				zap.S().Fatal(err.Error())
-			}
-			name = contract.Name
-			createdTimestamp = uint64(contract.CreatedTimestamp)
-			status = contract.Status
-			isToken = contract.IsToken
-
-			//////////////////////
-			// Governance Preps //
-			//////////////////////
-
-			// Is Governance Prep
-			governancePrep, err := GetGovernancePrepProcessedModel().SelectOne(newAddress.PublicKey)
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				governancePrep = &models.GovernancePrepProcessed{
-					Address: newAddress.PublicKey,
-					IsPrep:  false,
-				}
-			} else if err != nil {
-				// Postgres error
-				zap.S().Fatal(err.Error())
-			}
-			isGovernancePrep = governancePrep.IsPrep
-
-			newAddress.TransactionCount = transactionCount
-			newAddress.LogCount = logCount
-			// newAddress.Balance = balance
-			newAddress.Name = name
-			newAddress.CreatedTimestamp = createdTimestamp
-			newAddress.Status = status
-			newAddress.IsToken = isToken
-			newAddress.IsPrep = isGovernancePrep
-
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
-			err = GetAddressModel().UpsertOne(newAddress)
-			zap.S().Debug("Loader=Address, Address=", newAddress.PublicKey, " - Upserted")
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal("Loader=Address, Address=", newAddress.PublicKey, " - Error: ", err.Error())
-			}
+			zap.S().Fatal("Loader=Address - Error: ", err.Error())
+		}
+
+		publishedAddresses, err = getPublishedAddresses(newAddresses)
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal("Loader=Address - Error: ", err.Error())
+		}
+
+		return rows
+	}
+
+	loader.insertDefaults = func(row interface{}) {
+		setAddressInsertDefaults(row.(*models.Address))
+	}
+
+	loader.afterFlush = func(row interface{}) {
+		publishAddress(publishedAddresses[row.(*models.Address).PublicKey])
+	}
+
+	loader.merge = func(row interface{}, previousRow interface{}) {
+		mergeAddressActivity(row.(*models.Address), previousRow.(*models.Address))
+	}
+	loader.updateExpressions = addressActivityUpdateExpressions
+	loader.coupledColumns = addressCoupledColumns
+
+	loader.start(ctx, GetAddressModel().LoaderChannel)
+}
+
+// addressActivityUpdateExpressions - on conflict updates of the activity columns
+// First seen only moves to an earlier block and last active to a later block, rows are loaded out of order
+// NOTE a timestamp of 0 is not seen, block 0 is the genesis block
+var addressActivityUpdateExpressions = map[string]clause.Expr{
+	"first_seen_block_number":     addressActivityUpdateExpression("first_seen_block_number", addressFirstSeenCondition),
+	"first_seen_timestamp":        addressActivityUpdateExpression("first_seen_timestamp", addressFirstSeenCondition),
+	"first_seen_transaction_hash": addressActivityUpdateExpression("first_seen_transaction_hash", addressFirstSeenCondition),
+	"last_active_block_number":    addressActivityUpdateExpression("last_active_block_number", addressLastActiveCondition),
+	"last_active_timestamp":       addressActivityUpdateExpression("last_active_timestamp", addressLastActiveCondition),
+}
+
+// addressCoupledColumns - activity block numbers are updated with their timestamps, balance with balance loop
+// NOTE a drained address has a balance of 0, which is not a filled field
+var addressCoupledColumns = map[string][]string{
+	"first_seen_timestamp":  {"first_seen_block_number"},
+	"last_active_timestamp": {"last_active_block_number"},
+	"balance_loop":          {"balance"},
+}
+
+const addressFirstSeenCondition = `addresses.first_seen_timestamp = 0 OR (
+	EXCLUDED.first_seen_timestamp > 0 AND EXCLUDED.first_seen_block_number < addresses.first_seen_block_number
+)`
+
+const addressLastActiveCondition = `addresses.last_active_timestamp = 0 OR (
+	EXCLUDED.last_active_timestamp > 0 AND EXCLUDED.last_active_block_number > addresses.last_active_block_number
+)`
+
+func addressActivityUpdateExpression(column string, condition string) clause.Expr {
+	return gorm.Expr("CASE WHEN " + condition + " THEN EXCLUDED." + column + " ELSE addresses." + column + " END")
+}
+
+// mergeAddressActivity - keep the earliest first seen and the latest last active of two rows of an address
+// NOTE newAddress replaces previousAddress in the batch
+func mergeAddressActivity(newAddress *models.Address, previousAddress *models.Address) {
+
+	// First seen
+	if previousAddress.FirstSeenTimestamp != 0 &&
+		(newAddress.FirstSeenTimestamp == 0 || previousAddress.FirstSeenBlockNumber < newAddress.FirstSeenBlockNumber) {
+		newAddress.FirstSeenBlockNumber = previousAddress.FirstSeenBlockNumber
+		newAddress.FirstSeenTimestamp = previousAddress.FirstSeenTimestamp
+		newAddress.FirstSeenTransactionHash = previousAddress.FirstSeenTransactionHash
+	}
+
+	// Last active
+	if previousAddress.LastActiveTimestamp != 0 &&
+		(newAddress.LastActiveTimestamp == 0 || previousAddress.LastActiveBlockNumber > newAddress.LastActiveBlockNumber) {
+		newAddress.LastActiveBlockNumber = previousAddress.LastActiveBlockNumber
+		newAddress.LastActiveTimestamp = previousAddress.LastActiveTimestamp
+	}
+}
+
+// setAddressInsertDefaults - set columns that cannot be inserted empty
+// NOTE after the update columns are extracted, so stored values are not overwritten
+func setAddressInsertDefaults(newAddress *models.Address) {
+	// numeric columns
+	for _, loop := range []*string{
+		&newAddress.BalanceLoop,
+		&newAddress.AvailableBalanceLoop,
+		&newAddress.StakedBalanceLoop,
+		&newAddress.UnstakingBalanceLoop,
+		&newAddress.DelegatedBalanceLoop,
+		&newAddress.BondedBalanceLoop,
+		&newAddress.UnclaimedIscore,
+	} {
+		if *loop == "" {
+			*loop = "0"
 		}
-	}()
+	}
+}
+
+// enrichAddresses - set address fields from other tables
+// NOTE one query per table for the batch
+func enrichAddresses(newAddresses []*models.Address) error {
+	publicKeys := make([]string, len(newAddresses))
+	for i, newAddress := range newAddresses {
+		publicKeys[i] = newAddress.PublicKey
+	}
+
+	//////////////////////////////////
+	// Transaction Count By Address //
+	//////////////////////////////////
+	transactionCountByPublicKeys, err := GetTransactionCountByPublicKeyModel().SelectManyByPublicKeys(publicKeys)
+	if err != nil {
+		return err
+	}
+
+	transactionCounts := map[string]uint64{}
+	for _, transactionCountByPublicKey := range *transactionCountByPublicKeys {
+		transactionCounts[transactionCountByPublicKey.PublicKey] = transactionCountByPublicKey.Count
+	}
+
+	//////////////////////////
+	// Log Count By Address //
+	//////////////////////////
+	logCountByPublicKeys, err := GetLogCountByPublicKeyModel().SelectManyByPublicKeys(publicKeys)
+	if err != nil {
+		return err
+	}
+
+	logCounts := map[string]uint64{}
+	for _, logCountByPublicKey := range *logCountByPublicKeys {
+		logCounts[logCountByPublicKey.PublicKey] = logCountByPublicKey.Count
+	}
+
+	///////////////
+	// Contracts //
+	///////////////
+	contracts, err := GetContractModel().SelectManyByAddresses(publicKeys)
+	if err != nil {
+		return err
+	}
+
+	contractsByAddress := map[string]*models.ContractProcessed{}
+	for i := range *contracts {
+		contract := &(*contracts)[i]
+
+		contractsByAddress[contract.Address] = contract
+	}
+
+	//////////////////////
+	// Governance Preps //
+	//////////////////////
+	governancePreps, err := GetGovernancePrepProcessedModel().SelectManyByAddresses(publicKeys)
+	if err != nil {
+		return err
+	}
+
+	isGovernancePreps := map[string]bool{}
+	for _, governancePrep := range *governancePreps {
+		isGovernancePreps[governancePrep.Address] = governancePrep.IsPrep
+	}
+
+	for _, newAddress := range newAddresses {
+		enrichAddress(
+			newAddress,
+			transactionCounts[newAddress.PublicKey],
+			logCounts[newAddress.PublicKey],
+			contractsByAddress[newAddress.PublicKey],
+			isGovernancePreps[newAddress.PublicKey],
+		)
+	}
+
+	return nil
+}
+
+// enrichAddress - set address fields from other tables
+// NOTE contract is nil for addresses that are not contracts
+func enrichAddress(
+	newAddress *models.Address,
+	transactionCount uint64,
+	logCount uint64,
+	contract *models.ContractProcessed,
+	isGovernancePrep bool,
+) {
+	name := ""                    // Only contracts
+	createdTimestamp := uint64(0) // Only contracts
+	status := ""                  // Only contracts
+	isToken := false              // Only contracts
+
+	if contract != nil {
+		name = contract.Name
+		createdTimestamp = uint64(contract.CreatedTimestamp)
+		status = contract.Status
+		isToken = contract.IsToken
+	}
+
+	newAddress.TransactionCount = transactionCount
+	newAddress.LogCount = logCount
+	newAddress.Name = name
+	newAddress.CreatedTimestamp = createdTimestamp
+	newAddress.Status = status
+	newAddress.IsToken = isToken
+	newAddress.IsPrep = isGovernancePrep
+}
+
+// getPublishedAddresses - addresses as they are stored once the batch is written, by public key
+// NOTE upsert only updates filled fields, filled fields are set over the stored rows read before the write
+func getPublishedAddresses(newAddresses []*models.Address) (map[string]*models.Address, error) {
+	publicKeys := make([]string, len(newAddresses))
+	for i, newAddress := range newAddresses {
+		publicKeys[i] = newAddress.PublicKey
+	}
+
+	storedAddresses, err := GetAddressModel().SelectManyByPublicKeys(publicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	storedAddressesByPublicKey := map[string]*models.Address{}
+	for i := range *storedAddresses {
+		storedAddress := &(*storedAddresses)[i]
+
+		storedAddressesByPublicKey[storedAddress.PublicKey] = storedAddress
+	}
+
+	publishedAddresses := map[string]*models.Address{}
+	for _, newAddress := range newAddresses {
+		publishedAddresses[newAddress.PublicKey] = mergeStoredAddress(newAddress, storedAddressesByPublicKey[newAddress.PublicKey])
+	}
+
+	return publishedAddresses, nil
+}
+
+// mergeStoredAddress - address after an upsert of newAddress over storedAddress
+// NOTE storedAddress is nil for new addresses
+func mergeStoredAddress(newAddress *models.Address, storedAddress *models.Address) *models.Address {
+	mergedAddress := proto.Clone(newAddress).(*models.Address)
+
+	if storedAddress == nil {
+		// Inserted
+		setAddressInsertDefaults(mergedAddress)
+		return mergedAddress
+	}
+
+	mergeFilledFields(mergedAddress, storedAddress)
+	mergeAddressActivity(mergedAddress, storedAddress)
+
+	return mergedAddress
+}
+
+// publishAddress - publish the stored address to redis
+func publishAddress(address *models.Address) {
+	addressJSON, err := json.Marshal(address)
+	if err != nil {
+		zap.S().Warn("Loader=Address, Address=", address.PublicKey, " - Error: ", err.Error())
+		return
+	}
+	redis.GetRedisClient().Publish(addressJSON)
 }
 
 // reloadAddress - Send address back to loader for updates
@@ -391,3 +869,39 @@ func reloadAddress(publicKey string) error {
 
 	return nil
 }
+
+// reloadAddresses - Send many addresses back to loader for updates
+// NOTE one query per 1000 addresses
+func reloadAddresses(publicKeys []string) error {
+	for start := 0; start < len(publicKeys); start += 1000 {
+		end := start + 1000
+		if end > len(publicKeys) {
+			end = len(publicKeys)
+		}
+
+		curAddresses, err := GetAddressModel().SelectManyByPublicKeys(publicKeys[start:end])
+		if err != nil {
+			// Postgres error
+			return err
+		}
+
+		isStored := map[string]bool{}
+		for i := range *curAddresses {
+			curAddress := &(*curAddresses)[i]
+
+			isStored[curAddress.PublicKey] = true
+			GetAddressModel().LoaderChannel <- curAddress
+		}
+
+		for _, publicKey := range publicKeys[start:end] {
+			if isStored[publicKey] == true {
+				continue
+			}
+
+			// Create empty address
+			GetAddressModel().LoaderChannel <- &models.Address{PublicKey: publicKey}
+		}
+	}
+
+	return nil
+}
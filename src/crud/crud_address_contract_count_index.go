@@ -46,7 +46,7 @@ func GetAddressContractCountIndexModel() *AddressContractCountIndexModel {
 // Migrate - migrate addressContractCountIndexs table
 func (m *AddressContractCountIndexModel) Migrate() error {
 	// Only using AddressContractCountIndexRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -61,3 +61,23 @@ func (m *AddressContractCountIndexModel) Insert(addressContractCountIndex *model
 
 	return db.Error
 }
+
+// InsertMany - insert indexes not yet in the table
+// Returns the inserted rows, rows already in the table are left out
+func (m *AddressContractCountIndexModel) InsertMany(addressContractCountIndexes []*models.AddressContractCountIndex) (*[]models.AddressContractCountIndex, error) {
+	publicKeys := make([]string, len(addressContractCountIndexes))
+	for i, row := range addressContractCountIndexes {
+		publicKeys[i] = row.PublicKey
+	}
+
+	insertedAddressContractCountIndexes := &[]models.AddressContractCountIndex{}
+	db := m.db.Raw(
+		`INSERT INTO address_contract_count_indices (public_key)
+		SELECT * FROM unnest(?::text[])
+		ON CONFLICT DO NOTHING
+		RETURNING public_key`,
+		postgresArrayLiteral(publicKeys),
+	).Scan(insertedAddressContractCountIndexes)
+
+	return insertedAddressContractCountIndexes, db.Error
+}
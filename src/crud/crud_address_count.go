@@ -1,7 +1,7 @@
 package crud
 
 import (
-	"errors"
+	"context"
 	"reflect"
 	"sync"
 
@@ -9,8 +9,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
-	"github.com/geometry-labs/icon-addresses/redis"
 )
 
 // AddressCountModel - type for address table model
@@ -43,7 +43,7 @@ func GetAddressCountModel() *AddressCountModel {
 			zap.S().Fatal("AddressCountModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartAddressCountLoader()
+		StartAddressCountLoader(global.ShutdownContext())
 	})
 
 	return addressCountModel
@@ -52,7 +52,7 @@ func GetAddressCountModel() *AddressCountModel {
 // Migrate - migrate addressCounts table
 func (m *AddressCountModel) Migrate() error {
 	// Only using AddressCountRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -93,6 +93,25 @@ func (m *AddressCountModel) SelectCount(_type string) (uint64, error) {
 	return count, db.Error
 }
 
+// SelectManyByTypes - select many address counts by type
+// Types without a row are left out
+func (m *AddressCountModel) SelectManyByTypes(
+	types []string,
+) (*[]models.AddressCount, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.AddressCount{})
+
+	// Types
+	db = db.Where("type IN ?", types)
+
+	addressCounts := &[]models.AddressCount{}
+	db = db.Find(addressCounts)
+
+	return addressCounts, db.Error
+}
+
 func (m *AddressCountModel) UpsertOne(
 	addressCount *models.AddressCount,
 ) error {
@@ -114,116 +133,167 @@ func (m *AddressCountModel) UpsertOne(
 }
 
 // StartAddressCountLoader starts loader
-func StartAddressCountLoader() {
-	go func() {
-		postgresLoaderChan := GetAddressCountModel().LoaderChannel
+// NOTE counts are only incremented for addresses not yet in the index tables
+func StartAddressCountLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"address_count",
+		GetAddressCountModel().db,
+		[]string{"type", "public_key"}, // NOTE set to primary keys for the index tables
+		true,
+	)
+	loader.conflictKeys = []string{"type"} // NOTE set to primary keys for table
 
-		for {
-			// Read address
-			newAddressCount := <-postgresLoaderChan
+	loader.enrichBatch = func(rows []interface{}) []interface{} {
+		newAddressCounts := make([]*models.AddressCount, len(rows))
+		for i, row := range rows {
+			newAddressCounts[i] = row.(*models.AddressCount)
+		}
 
-			//////////////////////////
-			// Get count from redis //
-			//////////////////////////
-			countKey := "icon_addresses_address_count_" + newAddressCount.Type
+		addressCounts, err := countNewAddresses(newAddressCounts)
+		if err != nil {
+			// Postgres or redis error
+			zap.S().Fatal("Loader=AddressCount - Error: ", err.Error())
+		}
 
-			count, err := redis.GetRedisClient().GetCount(countKey)
-			if err != nil {
-				zap.S().Fatal(
-					"Loader=AddressCount,",
-					"PublicKey=", newAddressCount.PublicKey,
-					" Type=", newAddressCount.Type,
-					" - Error: ", err.Error())
-			}
+		rows = make([]interface{}, len(addressCounts))
+		for i, addressCount := range addressCounts {
+			rows[i] = addressCount
+		}
+		return rows
+	}
 
-			// No count set yet
-			// Get from database
-			if count == -1 {
-				curAddressCount, err := GetAddressCountModel().SelectOne(newAddressCount.Type)
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					count = 0
-				} else if err != nil {
-					zap.S().Fatal(
-						"Loader=AddressCount,",
-						"PublicKey=", newAddressCount.PublicKey,
-						" Type=", newAddressCount.Type,
-						" - Error: ", err.Error())
-				} else {
-					count = int64(curAddressCount.Count)
-				}
-
-				// Set count
-				err = redis.GetRedisClient().SetCount(countKey, int64(count))
-				if err != nil {
-					// Redis error
-					zap.S().Fatal(
-						"Loader=AddressCount,",
-						"PublicKey=", newAddressCount.PublicKey,
-						" Type=", newAddressCount.Type,
-						" - Error: ", err.Error())
-				}
-			}
+	loader.start(ctx, GetAddressCountModel().LoaderChannel)
+}
 
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
-
-			// Add address to indexed
-			if newAddressCount.Type == "all" {
-				newAddressCountIndex := &models.AddressCountIndex{
-					PublicKey: newAddressCount.PublicKey,
-				}
-				err = GetAddressCountIndexModel().Insert(newAddressCountIndex)
-				if err != nil {
-					// Record already exists, continue
-					continue
-				}
-			} else if newAddressCount.Type == "contract" {
-				newAddressContractCountIndex := &models.AddressContractCountIndex{
-					PublicKey: newAddressCount.PublicKey,
-				}
-				err = GetAddressContractCountIndexModel().Insert(newAddressContractCountIndex)
-				if err != nil {
-					// Record already exists, continue
-					continue
-				}
-			} else if newAddressCount.Type == "token" {
-				newAddressTokenCountIndex := &models.AddressTokenCountIndex{
-					PublicKey: newAddressCount.PublicKey,
-				}
-				err = GetAddressTokenCountIndexModel().Insert(newAddressTokenCountIndex)
-				if err != nil {
-					// Record already exists, continue
-					continue
-				}
-			}
+// countNewAddresses - insert addresses into the index tables and increment the counts of their types
+// Returns a count row for each type with new addresses
+func countNewAddresses(newAddressCounts []*models.AddressCount) ([]*models.AddressCount, error) {
+
+	// Public keys by type
+	types := []string{}
+	typePublicKeys := map[string][]string{}
+	for _, newAddressCount := range newAddressCounts {
+		if _, ok := typePublicKeys[newAddressCount.Type]; ok == false {
+			types = append(types, newAddressCount.Type)
+		}
+		typePublicKeys[newAddressCount.Type] = append(typePublicKeys[newAddressCount.Type], newAddressCount.PublicKey)
+	}
 
-			// Increment records
-			count, err = redis.GetRedisClient().IncCount(countKey)
+	//////////////////////////
+	// Load to index tables //
+	//////////////////////////
+	increments := map[string]int64{}
+	lastPublicKeys := map[string]string{}
+	for _, _type := range types {
+		insertedPublicKeys, err := insertAddressCountIndexes(_type, typePublicKeys[_type])
+		if err != nil {
+			return nil, err
+		}
+		if len(insertedPublicKeys) == 0 {
+			// Already counted
+			continue
+		}
+
+		increments[_type] = int64(len(insertedPublicKeys))
+		lastPublicKeys[_type] = insertedPublicKeys[len(insertedPublicKeys)-1]
+	}
+
+	////////////////////////////
+	// Increment redis counts //
+	////////////////////////////
+	counts, err := incrementRedisCounts(
+		"icon_addresses_address_count_",
+		increments,
+		func(types []string) (map[string]uint64, error) {
+			addressCounts, err := GetAddressCountModel().SelectManyByTypes(types)
 			if err != nil {
-				// Redis error
-				zap.S().Fatal(
-					"Loader=AddressCount,",
-					"PublicKey=", newAddressCount.PublicKey,
-					" Type=", newAddressCount.Type,
-					" - Error: ", err.Error())
+				return nil, err
 			}
-			newAddressCount.Count = uint64(count)
-
-			err = GetAddressCountModel().UpsertOne(newAddressCount)
-			zap.S().Debug(
-				"Loader=AddressCount,",
-				"PublicKey=", newAddressCount.PublicKey,
-				" Type=", newAddressCount.Type,
-				" - Upsert")
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal(
-					"Loader=AddressCount,",
-					"PublicKey=", newAddressCount.PublicKey,
-					" Type=", newAddressCount.Type,
-					" - Error: ", err.Error())
+
+			storedCounts := map[string]uint64{}
+			for _, addressCount := range *addressCounts {
+				storedCounts[addressCount.Type] = addressCount.Count
 			}
+			return storedCounts, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	addressCounts := []*models.AddressCount{}
+	for _, _type := range types {
+		count, ok := counts[_type]
+		if ok == false {
+			continue
+		}
+
+		addressCounts = append(addressCounts, &models.AddressCount{
+			Type:      _type,
+			PublicKey: lastPublicKeys[_type],
+			Count:     count,
+		})
+	}
+
+	return addressCounts, nil
+}
+
+// insertAddressCountIndexes - insert public keys into the index table of a type
+// Returns the public keys inserted, in order
+// NOTE types without an index table count every public key
+func insertAddressCountIndexes(_type string, publicKeys []string) ([]string, error) {
+	inserted := map[string]bool{}
+
+	switch _type {
+	case "all":
+		addressCountIndexes := make([]*models.AddressCountIndex, len(publicKeys))
+		for i, publicKey := range publicKeys {
+			addressCountIndexes[i] = &models.AddressCountIndex{PublicKey: publicKey}
+		}
+
+		insertedAddressCountIndexes, err := GetAddressCountIndexModel().InsertMany(addressCountIndexes)
+		if err != nil {
+			return nil, err
+		}
+		for _, addressCountIndex := range *insertedAddressCountIndexes {
+			inserted[addressCountIndex.PublicKey] = true
+		}
+	case "contract":
+		addressContractCountIndexes := make([]*models.AddressContractCountIndex, len(publicKeys))
+		for i, publicKey := range publicKeys {
+			addressContractCountIndexes[i] = &models.AddressContractCountIndex{PublicKey: publicKey}
 		}
-	}()
+
+		insertedAddressContractCountIndexes, err := GetAddressContractCountIndexModel().InsertMany(addressContractCountIndexes)
+		if err != nil {
+			return nil, err
+		}
+		for _, addressContractCountIndex := range *insertedAddressContractCountIndexes {
+			inserted[addressContractCountIndex.PublicKey] = true
+		}
+	case "token":
+		addressTokenCountIndexes := make([]*models.AddressTokenCountIndex, len(publicKeys))
+		for i, publicKey := range publicKeys {
+			addressTokenCountIndexes[i] = &models.AddressTokenCountIndex{PublicKey: publicKey}
+		}
+
+		insertedAddressTokenCountIndexes, err := GetAddressTokenCountIndexModel().InsertMany(addressTokenCountIndexes)
+		if err != nil {
+			return nil, err
+		}
+		for _, addressTokenCountIndex := range *insertedAddressTokenCountIndexes {
+			inserted[addressTokenCountIndex.PublicKey] = true
+		}
+	default:
+		return publicKeys, nil
+	}
+
+	insertedPublicKeys := []string{}
+	for _, publicKey := range publicKeys {
+		if inserted[publicKey] == true {
+			insertedPublicKeys = append(insertedPublicKeys, publicKey)
+		}
+	}
+
+	return insertedPublicKeys, nil
 }
@@ -46,7 +46,7 @@ func GetAddressCountIndexModel() *AddressCountIndexModel {
 // Migrate - migrate addressCountIndexs table
 func (m *AddressCountIndexModel) Migrate() error {
 	// Only using AddressCountIndexRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -61,3 +61,23 @@ func (m *AddressCountIndexModel) Insert(addressCountIndex *models.AddressCountIn
 
 	return db.Error
 }
+
+// InsertMany - insert indexes not yet in the table
+// Returns the inserted rows, rows already in the table are left out
+func (m *AddressCountIndexModel) InsertMany(addressCountIndexes []*models.AddressCountIndex) (*[]models.AddressCountIndex, error) {
+	publicKeys := make([]string, len(addressCountIndexes))
+	for i, row := range addressCountIndexes {
+		publicKeys[i] = row.PublicKey
+	}
+
+	insertedAddressCountIndexes := &[]models.AddressCountIndex{}
+	db := m.db.Raw(
+		`INSERT INTO address_count_indices (public_key)
+		SELECT * FROM unnest(?::text[])
+		ON CONFLICT DO NOTHING
+		RETURNING public_key`,
+		postgresArrayLiteral(publicKeys),
+	).Scan(insertedAddressCountIndexes)
+
+	return insertedAddressCountIndexes, db.Error
+}
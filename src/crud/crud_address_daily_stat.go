@@ -0,0 +1,223 @@
+package crud
+
+import (
+	"database/sql"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// AddressDailyStatModel - type for address_daily_stats table model
+type AddressDailyStatModel struct {
+	db       *gorm.DB
+	model    *models.AddressDailyStat
+	modelORM *models.AddressDailyStatORM
+}
+
+var addressDailyStatModel *AddressDailyStatModel
+var addressDailyStatModelOnce sync.Once
+
+// GetAddressDailyStatModel - create and/or return the address_daily_stats table model
+func GetAddressDailyStatModel() *AddressDailyStatModel {
+	addressDailyStatModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		addressDailyStatModel = &AddressDailyStatModel{
+			db:       dbConn,
+			model:    &models.AddressDailyStat{},
+			modelORM: &models.AddressDailyStatORM{},
+		}
+
+		err := addressDailyStatModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("AddressDailyStatModel: Unable migrate postgres table: ", err.Error())
+		}
+	})
+
+	return addressDailyStatModel
+}
+
+// Migrate - migrate address_daily_stats and address_daily_activities tables
+func (m *AddressDailyStatModel) Migrate() error {
+	// Only using AddressDailyStatORM (ORM version of the proto generated struct) to create the TABLE
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
+	if err != nil {
+		return err
+	}
+
+	err = autoMigrate(m.db, &models.AddressDailyActivityORM{})
+	return err
+}
+
+// SelectManyByDayRange - select days between two days, inclusive
+// Days are UTC, YYYY-MM-DD
+func (m *AddressDailyStatModel) SelectManyByDayRange(
+	fromDay string,
+	toDay string,
+) (*[]models.AddressDailyStat, error) {
+	db := m.db
+
+	// Order by day
+	db = db.Order("day ASC")
+
+	// Day
+	db = db.Where("day >= ?", fromDay)
+	db = db.Where("day <= ?", toDay)
+
+	addressDailyStats := &[]models.AddressDailyStat{}
+	db = db.Find(addressDailyStats)
+
+	return addressDailyStats, db.Error
+}
+
+// SelectRewindBlockNumber - first block of the days built at or above a block number
+// Days are only complete when rebuilt from their first block
+// Returns gorm.ErrRecordNotFound if no day is built at or above the block number
+func (m *AddressDailyStatModel) SelectRewindBlockNumber(
+	blockNumber uint64,
+) (uint64, error) {
+	db := m.db
+
+	var startBlockNumber sql.NullInt64
+	db = db.Raw(
+		"SELECT min(start_block_number) FROM address_daily_stats WHERE end_block_number >= ?",
+		blockNumber,
+	).Scan(&startBlockNumber)
+	if db.Error != nil {
+		return 0, db.Error
+	}
+	if startBlockNumber.Valid == false {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return uint64(startBlockNumber.Int64), nil
+}
+
+// UpsertManyWithCheckpoint - add the blocks from a block number to days and write a builder checkpoint in one transaction
+// Transaction counts and volumes are added to the days, active and new addresses are counted from the active public keys by day
+// NOTE days built at or above the block number are cleared first, see SelectRewindBlockNumber
+// NOTE days are expected in order, activities of days before the first day are pruned
+// Returns ErrBuilderCheckpointMoved if the checkpoint is no longer previousBuilderCheckpoint, nil if it did not exist
+func (m *AddressDailyStatModel) UpsertManyWithCheckpoint(
+	startBlockNumber uint64,
+	addressDailyStats []*models.AddressDailyStat,
+	activePublicKeys map[string][]string,
+	builderCheckpoint *models.BuilderCheckpoint,
+	previousBuilderCheckpoint *models.BuilderCheckpoint,
+) error {
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+
+		// Checkpoint rewound or reset while building
+		err := GetBuilderCheckpointModel().checkOne(tx, builderCheckpoint, previousBuilderCheckpoint)
+		if err != nil {
+			return err
+		}
+
+		////////////////
+		// Stale days //
+		////////////////
+		err = tx.Exec(
+			`DELETE FROM address_daily_activities
+			WHERE day IN (SELECT day FROM address_daily_stats WHERE end_block_number >= ?)`,
+			startBlockNumber,
+		).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("end_block_number >= ?", startBlockNumber).Delete(&models.AddressDailyStat{}).Error
+		if err != nil {
+			return err
+		}
+
+		//////////
+		// Days //
+		//////////
+		for _, addressDailyStat := range addressDailyStats {
+
+			// First block of the day, may be in a previous batch
+			dayStartBlockNumber := addressDailyStat.StartBlockNumber
+			var currentStartBlockNumber sql.NullInt64
+			err = tx.Raw(
+				"SELECT start_block_number FROM address_daily_stats WHERE day = ?",
+				addressDailyStat.Day,
+			).Scan(&currentStartBlockNumber).Error
+			if err != nil {
+				return err
+			}
+			if currentStartBlockNumber.Valid == true && uint64(currentStartBlockNumber.Int64) < dayStartBlockNumber {
+				dayStartBlockNumber = uint64(currentStartBlockNumber.Int64)
+			}
+
+			// Addresses not yet active in the day
+			// NOTE new addresses use the (from_address, block_number) and (to_address, block_number) indexes
+			addressCounts := &struct {
+				ActiveAddressCount uint64
+				NewAddressCount    uint64
+			}{}
+			err = tx.Raw(
+				`WITH activities AS (
+					INSERT INTO address_daily_activities (day, public_key)
+					SELECT ?, unnest(?::text[])
+					ON CONFLICT DO NOTHING
+					RETURNING public_key
+				)
+				SELECT
+					count(*) AS active_address_count,
+					count(*) FILTER (
+						WHERE NOT EXISTS (
+							SELECT 1 FROM transactions
+							WHERE from_address = activities.public_key AND block_number < ?
+						) AND NOT EXISTS (
+							SELECT 1 FROM transactions
+							WHERE to_address = activities.public_key AND block_number < ?
+						)
+					) AS new_address_count
+				FROM activities`,
+				addressDailyStat.Day,
+				postgresArrayLiteral(activePublicKeys[addressDailyStat.Day]),
+				dayStartBlockNumber,
+				dayStartBlockNumber,
+			).Scan(addressCounts).Error
+			if err != nil {
+				return err
+			}
+			addressDailyStat.ActiveAddressCount = addressCounts.ActiveAddressCount
+			addressDailyStat.NewAddressCount = addressCounts.NewAddressCount
+
+			// Upsert
+			err = tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "day"}}, // NOTE set to primary keys for table
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"start_block_number":   gorm.Expr("LEAST(address_daily_stats.start_block_number, EXCLUDED.start_block_number)"),
+					"end_block_number":     gorm.Expr("GREATEST(address_daily_stats.end_block_number, EXCLUDED.end_block_number)"),
+					"active_address_count": gorm.Expr("address_daily_stats.active_address_count + EXCLUDED.active_address_count"),
+					"new_address_count":    gorm.Expr("address_daily_stats.new_address_count + EXCLUDED.new_address_count"),
+					"transaction_count":    gorm.Expr("address_daily_stats.transaction_count + EXCLUDED.transaction_count"),
+					"volume_loop":          gorm.Expr("address_daily_stats.volume_loop + EXCLUDED.volume_loop"),
+					"updated_timestamp":    gorm.Expr("EXCLUDED.updated_timestamp"),
+				}),
+			}).Create(addressDailyStat).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Prune activities
+		if len(addressDailyStats) > 0 {
+			err = tx.Where("day < ?", addressDailyStats[0].Day).Delete(&models.AddressDailyActivity{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return GetBuilderCheckpointModel().upsertOne(tx, builderCheckpoint)
+	})
+}
@@ -0,0 +1,200 @@
+package crud
+
+import (
+	"regexp"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// AddressLabelModel - type for address_labels table model
+type AddressLabelModel struct {
+	db       *gorm.DB
+	model    *models.AddressLabel
+	modelORM *models.AddressLabelORM
+}
+
+var addressLabelModel *AddressLabelModel
+var addressLabelModelOnce sync.Once
+
+// GetAddressLabelModel - create and/or return the address_labels table model
+func GetAddressLabelModel() *AddressLabelModel {
+	addressLabelModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		addressLabelModel = &AddressLabelModel{
+			db:       dbConn,
+			model:    &models.AddressLabel{},
+			modelORM: &models.AddressLabelORM{},
+		}
+
+		err := addressLabelModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("AddressLabelModel: Unable migrate postgres table: ", err.Error())
+		}
+	})
+
+	return addressLabelModel
+}
+
+// Migrate - migrate address_labels table
+func (m *AddressLabelModel) Migrate() error {
+	// Only using AddressLabelORM (ORM version of the proto generated struct) to create the TABLE
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
+	return err
+}
+
+var addressLabelRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+var addressPublicKeyRegexp = regexp.MustCompile(`^(hx|cx)[0-9a-f]{40}$`)
+
+// addressLabelTypeOrder - order of the labels of an address, the first is its type
+const addressLabelTypeOrder = "(source = 'type') DESC, label ASC"
+
+// IsAddressLabelValid - labels are lower case slugs (e.g. treasury, exchange, bridge, scam, team)
+func IsAddressLabelValid(label string) bool {
+	return addressLabelRegexp.MatchString(label)
+}
+
+// IsAddressPublicKeyValid - hx or cx addresses
+func IsAddressPublicKeyValid(publicKey string) bool {
+	return addressPublicKeyRegexp.MatchString(publicKey)
+}
+
+// SelectManyByPublicKey - select labels of an address
+func (m *AddressLabelModel) SelectManyByPublicKey(
+	publicKey string,
+) (*[]models.AddressLabel, error) {
+	db := m.db
+
+	// Public key
+	db = db.Where("public_key = ?", publicKey)
+
+	// Order by label
+	db = db.Order("label ASC")
+
+	addressLabels := &[]models.AddressLabel{}
+	db = db.Find(addressLabels)
+
+	return addressLabels, db.Error
+}
+
+// SelectLabelsByPublicKeys - labels and types of many addresses
+// Public key -> labels, ordered, and public key -> type
+func (m *AddressLabelModel) SelectLabelsByPublicKeys(
+	publicKeys []string,
+) (map[string][]string, map[string]string, error) {
+	db := m.db
+
+	// Public keys
+	db = db.Where("public_key IN ?", publicKeys)
+
+	// Order by label
+	db = db.Order("label ASC")
+
+	addressLabels := &[]models.AddressLabel{}
+	db = db.Find(addressLabels)
+	if db.Error != nil {
+		return nil, nil, db.Error
+	}
+
+	labels := map[string][]string{}
+	labelsByPublicKey := map[string][]models.AddressLabel{}
+	for i := range *addressLabels {
+		addressLabel := &(*addressLabels)[i]
+
+		labels[addressLabel.PublicKey] = append(labels[addressLabel.PublicKey], addressLabel.Label)
+		labelsByPublicKey[addressLabel.PublicKey] = append(labelsByPublicKey[addressLabel.PublicKey], *addressLabel)
+	}
+
+	types := map[string]string{}
+	for publicKey, addressLabels := range labelsByPublicKey {
+		types[publicKey] = GetAddressLabelsType(addressLabels)
+	}
+
+	return labels, types, nil
+}
+
+// GetAddressLabelsType - type of an address, derived from its labels
+// The label kept from the old type field (source type) first, otherwise the first label
+// NOTE same order as addressLabelTypeOrder
+func GetAddressLabelsType(addressLabels []models.AddressLabel) string {
+	addressType := ""
+	isTypeSource := false
+	for i := range addressLabels {
+		addressLabel := &addressLabels[i]
+
+		labelIsTypeSource := addressLabel.Source == "type"
+		if addressType == "" ||
+			(labelIsTypeSource == true && isTypeSource == false) ||
+			(labelIsTypeSource == isTypeSource && addressLabel.Label < addressType) {
+			addressType = addressLabel.Label
+			isTypeSource = labelIsTypeSource
+		}
+	}
+
+	return addressType
+}
+
+// UpsertOne - upsert into address_labels table
+func (m *AddressLabelModel) UpsertOne(
+	addressLabel *models.AddressLabel,
+) error {
+	db := m.db
+
+	// Upsert
+	db = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "public_key"}, {Name: "label"}}, // NOTE set to primary keys for table
+		DoUpdates: clause.AssignmentColumns([]string{"source", "timestamp"}),
+	}).Create(addressLabel)
+
+	return db.Error
+}
+
+// DeleteOne - delete from address_labels table
+// Returns the number of labels deleted
+func (m *AddressLabelModel) DeleteOne(
+	publicKey string,
+	label string,
+) (int64, error) {
+	db := m.db
+
+	// Public key
+	db = db.Where("public_key = ?", publicKey)
+
+	// Label
+	db = db.Where("label = ?", label)
+
+	db = db.Delete(&models.AddressLabel{})
+
+	return db.RowsAffected, db.Error
+}
+
+// ReplaceManyBySource - replace every label of a source in one transaction
+// NOTE labels already set by another source are kept
+func (m *AddressLabelModel) ReplaceManyBySource(
+	source string,
+	addressLabels []*models.AddressLabel,
+) error {
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+
+		// Source
+		err := tx.Where("source = ?", source).Delete(&models.AddressLabel{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(addressLabels) == 0 {
+			return nil
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(addressLabels, 1000).Error
+	})
+}
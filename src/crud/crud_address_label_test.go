@@ -0,0 +1,29 @@
+package crud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestGetAddressLabelsType(t *testing.T) {
+	assert := assert.New(t)
+
+	// No labels
+	assert.Equal("", GetAddressLabelsType([]models.AddressLabel{}))
+
+	// First label
+	assert.Equal("bridge", GetAddressLabelsType([]models.AddressLabel{
+		{Label: "exchange", Source: "default"},
+		{Label: "bridge", Source: "admin"},
+	}))
+
+	// Label kept from the old type field first
+	assert.Equal("treasury", GetAddressLabelsType([]models.AddressLabel{
+		{Label: "exchange", Source: "default"},
+		{Label: "treasury", Source: "type"},
+		{Label: "bridge", Source: "admin"},
+	}))
+}
@@ -0,0 +1,130 @@
+package crud
+
+import (
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// AddressSearchModel - searches addresses by public key prefix, contract name and label
+// NOTE the extension and indexes are created by the address_search migration
+type AddressSearchModel struct {
+	db *gorm.DB
+}
+
+var addressSearchModel *AddressSearchModel
+var addressSearchModelOnce sync.Once
+
+// GetAddressSearchModel - create and/or return the address search model
+func GetAddressSearchModel() *AddressSearchModel {
+	addressSearchModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		addressSearchModel = &AddressSearchModel{
+			db: dbConn,
+		}
+	})
+
+	return addressSearchModel
+}
+
+// SearchAPI - addresses matching a query, best match first
+// Public keys score 4 exact or 3 prefix, contract names 1 + trigram similarity and 1 more on prefix,
+// labels 2 exact or 1.5 prefix
+// NOTE ties are broken by balance
+// NOTE each kind of match is limited before they are merged, a common label or name does not read every row
+func (m *AddressSearchModel) SearchAPI(
+	query string,
+	limit int,
+) (*[]models.AddressSearchAPIList, error) {
+	db := m.db
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	likeQuery := escapeLikePattern(query)
+
+	addresses := &[]models.AddressSearchAPIList{}
+	db = db.Raw(
+		`SELECT
+			m.public_key,
+			COALESCE((
+				SELECT label FROM address_labels
+				WHERE address_labels.public_key = m.public_key
+				ORDER BY `+addressLabelTypeOrder+`
+				LIMIT 1
+			), '') AS type,
+			COALESCE(a.balance, 0) AS balance,
+			COALESCE(a.balance_loop, 0)::text AS balance_loop,
+			COALESCE(a.is_contract, m.public_key LIKE 'cx%') AS is_contract,
+			COALESCE(a.is_token, false) AS is_token,
+			COALESCE(NULLIF(a.name, ''), c.name, '') AS name,
+			m.match_field,
+			m.match_value,
+			m.score
+		FROM (
+			SELECT DISTINCT ON (public_key) public_key, match_field, match_value, score
+			FROM (
+				(
+					SELECT
+						public_key,
+						'public_key' AS match_field,
+						public_key AS match_value,
+						CASE WHEN public_key = @query THEN 4 ELSE 3 END::double precision AS score
+					FROM addresses
+					WHERE public_key LIKE @prefix
+					ORDER BY public_key
+					LIMIT @limit
+				)
+				UNION ALL
+				(
+					SELECT
+						address,
+						'name',
+						name,
+						(1 + similarity(lower(name), @query) + CASE WHEN lower(name) LIKE @prefix THEN 1 ELSE 0 END)::double precision
+					FROM contract_processeds
+					WHERE lower(name) % @query OR lower(name) LIKE @contains
+					ORDER BY 4 DESC, address
+					LIMIT @limit
+				)
+				UNION ALL
+				(
+					SELECT
+						l.public_key,
+						'label',
+						l.label,
+						CASE WHEN l.label = @query THEN 2 ELSE 1.5 END::double precision
+					FROM address_labels l
+					LEFT JOIN addresses la ON la.public_key = l.public_key
+					WHERE l.label LIKE @prefix
+					ORDER BY 4 DESC, COALESCE(la.balance_loop, 0) DESC, l.public_key
+					LIMIT @limit
+				)
+			) AS matches
+			ORDER BY public_key, score DESC
+		) AS m
+		LEFT JOIN addresses a ON a.public_key = m.public_key
+		LEFT JOIN contract_processeds c ON c.address = m.public_key
+		ORDER BY m.score DESC, COALESCE(a.balance_loop, 0) DESC, m.public_key ASC
+		LIMIT @limit`,
+		map[string]interface{}{
+			"query":    query,
+			"prefix":   likeQuery + "%",
+			"contains": "%" + likeQuery + "%",
+			"limit":    limit,
+		},
+	).Scan(addresses)
+
+	return addresses, db.Error
+}
+
+// escapeLikePattern - match wildcards literally in a LIKE pattern
+func escapeLikePattern(pattern string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(pattern)
+}
@@ -0,0 +1,16 @@
+package crud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeLikePattern(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("hx12", escapeLikePattern("hx12"))
+	assert.Equal(`100\%`, escapeLikePattern("100%"))
+	assert.Equal(`my\_token`, escapeLikePattern("my_token"))
+	assert.Equal(`a\\b`, escapeLikePattern(`a\b`))
+}
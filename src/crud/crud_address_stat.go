@@ -0,0 +1,206 @@
+package crud
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// Address stat types
+const (
+	AddressStatTypeTotal               = "total"
+	AddressStatTypeTopHolders          = "top_holders"
+	AddressStatTypeBalanceDistribution = "balance_distribution"
+	AddressStatTypeAddressType         = "address_type"
+	AddressStatTypeNewAddresses        = "new_addresses"
+)
+
+// AddressStatModel - type for address_stats table model
+type AddressStatModel struct {
+	db       *gorm.DB
+	model    *models.AddressStat
+	modelORM *models.AddressStatORM
+}
+
+var addressStatModel *AddressStatModel
+var addressStatModelOnce sync.Once
+
+// GetAddressStatModel - create and/or return the address_stats table model
+func GetAddressStatModel() *AddressStatModel {
+	addressStatModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		addressStatModel = &AddressStatModel{
+			db:       dbConn,
+			model:    &models.AddressStat{},
+			modelORM: &models.AddressStatORM{},
+		}
+
+		err := addressStatModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("AddressStatModel: Unable migrate postgres table: ", err.Error())
+		}
+	})
+
+	return addressStatModel
+}
+
+// Migrate - migrate address_stats table
+func (m *AddressStatModel) Migrate() error {
+	// Only using AddressStatORM (ORM version of the proto generated struct) to create the TABLE
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
+	return err
+}
+
+// SelectAll - select the latest snapshot
+// Ordered by type and position
+func (m *AddressStatModel) SelectAll() (*[]models.AddressStat, error) {
+	db := m.db
+
+	// Order
+	db = db.Order("type ASC").Order("position ASC")
+
+	addressStats := &[]models.AddressStat{}
+	db = db.Find(addressStats)
+
+	return addressStats, db.Error
+}
+
+// ReplaceAll - replace the snapshot in one transaction
+func (m *AddressStatModel) ReplaceAll(
+	addressStats []*models.AddressStat,
+) error {
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+
+		err := tx.Where("true").Delete(&models.AddressStat{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(addressStats) == 0 {
+			return nil
+		}
+
+		return tx.Create(addressStats).Error
+	})
+}
+
+// ComputeTotal - number of addresses and sum of their balances
+// NOTE very slow operation
+func (m *AddressStatModel) ComputeTotal() (*models.AddressStat, error) {
+	db := m.db
+
+	addressStat := &models.AddressStat{}
+	db = db.Raw(
+		`SELECT
+			count(*) AS count,
+			COALESCE(sum(balance_loop), 0)::text AS balance_loop
+		FROM addresses`,
+	).Scan(addressStat)
+
+	return addressStat, db.Error
+}
+
+// ComputeTopHolders - number of addresses and sum of the balances of the top holders
+func (m *AddressStatModel) ComputeTopHolders(
+	top int,
+) (*models.AddressStat, error) {
+	db := m.db
+
+	addressStat := &models.AddressStat{}
+	db = db.Raw(
+		`SELECT
+			count(*) AS count,
+			COALESCE(sum(balance_loop), 0)::text AS balance_loop
+		FROM (
+			SELECT balance_loop
+			FROM addresses
+			WHERE balance_loop IS NOT NULL
+			ORDER BY balance_loop DESC
+			LIMIT ?
+		) AS top_holders`,
+		top,
+	).Scan(addressStat)
+
+	return addressStat, db.Error
+}
+
+// ComputeBalanceDistribution - number of addresses and sum of their balances by balance bucket
+// Position is the number of thresholds less than or equal to the balance, in loop, ascending
+// NOTE buckets without addresses are left out
+// NOTE very slow operation
+func (m *AddressStatModel) ComputeBalanceDistribution(
+	thresholdsLoop []string,
+) (*[]models.AddressStat, error) {
+	db := m.db
+
+	addressStats := &[]models.AddressStat{}
+	db = db.Raw(
+		`SELECT
+			width_bucket(COALESCE(balance_loop, 0), ?::numeric[]) AS position,
+			count(*) AS count,
+			COALESCE(sum(balance_loop), 0)::text AS balance_loop
+		FROM addresses
+		GROUP BY 1
+		ORDER BY 1`,
+		postgresArrayLiteral(thresholdsLoop),
+	).Scan(addressStats)
+
+	return addressStats, db.Error
+}
+
+// ComputeAddressTypes - number of addresses and sum of their balances of EOAs, contracts, tokens and preps
+// NOTE tokens are contracts, preps are EOAs
+// NOTE very slow operation
+func (m *AddressStatModel) ComputeAddressTypes() (*[]models.AddressStat, error) {
+	db := m.db
+
+	addressStats := &[]models.AddressStat{}
+	db = db.Raw(
+		`SELECT 'eoa' AS key, 0 AS position, count(*) AS count, COALESCE(sum(balance_loop), 0)::text AS balance_loop
+		FROM addresses WHERE is_contract = false
+		UNION ALL
+		SELECT 'contract', 1, count(*), COALESCE(sum(balance_loop), 0)::text
+		FROM addresses WHERE is_contract = true
+		UNION ALL
+		SELECT 'token', 2, count(*), COALESCE(sum(balance_loop), 0)::text
+		FROM addresses WHERE is_token = true
+		UNION ALL
+		SELECT 'prep', 3, count(*), COALESCE(sum(balance_loop), 0)::text
+		FROM addresses WHERE is_prep = true`,
+	).Scan(addressStats)
+
+	return addressStats, db.Error
+}
+
+// ComputeNewAddresses - number of addresses and sum of their balances by UTC day they were first seen
+// Only days from a block timestamp, in microseconds
+// NOTE days without new addresses are left out
+// NOTE addresses not yet backfilled by the address activity routine are left out
+func (m *AddressStatModel) ComputeNewAddresses(
+	fromTimestamp uint64,
+) (*[]models.AddressStat, error) {
+	db := m.db
+
+	addressStats := &[]models.AddressStat{}
+	db = db.Raw(
+		`SELECT
+			to_char(to_timestamp(first_seen_timestamp / 1000000) AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS key,
+			count(*) AS count,
+			COALESCE(sum(balance_loop), 0)::text AS balance_loop
+		FROM addresses
+		WHERE first_seen_timestamp >= ?
+		GROUP BY 1
+		ORDER BY 1`,
+		fromTimestamp,
+	).Scan(addressStats)
+
+	return addressStats, db.Error
+}
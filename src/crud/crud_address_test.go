@@ -0,0 +1,22 @@
+package crud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestICXToLoop(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("0", icxToLoop(0, true))
+	assert.Equal("1000000000000000000", icxToLoop(1, false))
+	assert.Equal("100000000000000000", icxToLoop(0.1, true))
+	assert.Equal("12345670000000000000000", icxToLoop(12345.67, false))
+
+	// Below a loop
+	assert.Equal("1", icxToLoop(1e-19, true))
+	assert.Equal("0", icxToLoop(1e-19, false))
+	assert.Equal("0", icxToLoop(-1e-19, true))
+	assert.Equal("-1", icxToLoop(-1e-19, false))
+}
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"reflect"
 	"sync"
 
@@ -8,6 +9,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
 )
 
@@ -41,7 +43,7 @@ func GetAddressTokenModel() *AddressTokenModel {
 			zap.S().Fatal("AddressTokenModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartAddressTokenLoader()
+		StartAddressTokenLoader(global.ShutdownContext())
 	})
 
 	return addressTokenModel
@@ -50,7 +52,7 @@ func GetAddressTokenModel() *AddressTokenModel {
 // Migrate - migrate addressTokens table
 func (m *AddressTokenModel) Migrate() error {
 	// Only using AddressTokenRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -72,6 +74,28 @@ func (m *AddressTokenModel) SelectManyByPublicKey(
 	return addressTokens, db.Error
 }
 
+// CountByTokenContractAddress - count holders of a token contract
+// Only addresses with a positive latest balance
+func (m *AddressTokenModel) CountByTokenContractAddress(
+	tokenContractAddress string,
+) (int64, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.AddressToken{})
+
+	// Token contract address
+	db = db.Where("token_contract_address = ?", tokenContractAddress)
+
+	// Current holders
+	db = db.Where("balance_decimal > 0")
+
+	count := int64(0)
+	db = db.Count(&count)
+
+	return count, db.Error
+}
+
 func (m *AddressTokenModel) UpsertOne(
 	address *models.AddressToken,
 ) error {
@@ -95,33 +119,44 @@ func (m *AddressTokenModel) UpsertOne(
 	return db.Error
 }
 
+// UpsertManyBalances - set the latest token balance of addresses
+// NOTE rows are created if the address token loader has not loaded them yet
+func (m *AddressTokenModel) UpsertManyBalances(
+	addressTokens []*models.AddressToken,
+) error {
+	if len(addressTokens) == 0 {
+		return nil
+	}
+
+	db := m.db
+
+	// Upsert
+	db = db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "public_key"},
+			{Name: "token_contract_address"},
+		}, // NOTE set to primary keys for table
+		DoUpdates: clause.AssignmentColumns([]string{"balance", "balance_decimal", "last_updated_block_number"}),
+	}).Create(&addressTokens)
+
+	return db.Error
+}
+
 // StartAddressTokenLoader starts loader
-func StartAddressTokenLoader() {
-	go func() {
-
-		for {
-			// Read address
-			newAddressToken := <-GetAddressTokenModel().LoaderChannel
-
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
-			err := GetAddressTokenModel().UpsertOne(newAddressToken)
-			zap.S().Debug(
-				"Loader=AddressToken",
-				",Address=", newAddressToken.PublicKey,
-				",TokenContractAddress=", newAddressToken.TokenContractAddress,
-				" - Upserted",
-			)
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal(
-					"Loader=AddressToken",
-					",Address=", newAddressToken.PublicKey,
-					",TokenContractAddress=", newAddressToken.TokenContractAddress,
-					" - Error: ", err.Error(),
-				)
-			}
+func StartAddressTokenLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"address_token",
+		GetAddressTokenModel().db,
+		[]string{"public_key", "token_contract_address"}, // NOTE set to primary keys for table
+		true,
+	)
+
+	loader.insertDefaults = func(row interface{}) {
+		addressToken := row.(*models.AddressToken)
+		if addressToken.Balance == "" {
+			addressToken.Balance = "0x0"
 		}
-	}()
+	}
+
+	loader.start(ctx, GetAddressTokenModel().LoaderChannel)
 }
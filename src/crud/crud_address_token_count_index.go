@@ -46,7 +46,7 @@ func GetAddressTokenCountIndexModel() *AddressTokenCountIndexModel {
 // Migrate - migrate addressTokenCountIndexs table
 func (m *AddressTokenCountIndexModel) Migrate() error {
 	// Only using AddressTokenCountIndexRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -61,3 +61,23 @@ func (m *AddressTokenCountIndexModel) Insert(addressTokenCountIndex *models.Addr
 
 	return db.Error
 }
+
+// InsertMany - insert indexes not yet in the table
+// Returns the inserted rows, rows already in the table are left out
+func (m *AddressTokenCountIndexModel) InsertMany(addressTokenCountIndexes []*models.AddressTokenCountIndex) (*[]models.AddressTokenCountIndex, error) {
+	publicKeys := make([]string, len(addressTokenCountIndexes))
+	for i, row := range addressTokenCountIndexes {
+		publicKeys[i] = row.PublicKey
+	}
+
+	insertedAddressTokenCountIndexes := &[]models.AddressTokenCountIndex{}
+	db := m.db.Raw(
+		`INSERT INTO address_token_count_indices (public_key)
+		SELECT * FROM unnest(?::text[])
+		ON CONFLICT DO NOTHING
+		RETURNING public_key`,
+		postgresArrayLiteral(publicKeys),
+	).Scan(insertedAddressTokenCountIndexes)
+
+	return insertedAddressTokenCountIndexes, db.Error
+}
@@ -0,0 +1,92 @@
+package crud
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// AddressUnstakeModel - type for address_unstakes table model
+type AddressUnstakeModel struct {
+	db       *gorm.DB
+	model    *models.AddressUnstake
+	modelORM *models.AddressUnstakeORM
+}
+
+var addressUnstakeModel *AddressUnstakeModel
+var addressUnstakeModelOnce sync.Once
+
+// GetAddressUnstakeModel - create and/or return the address_unstakes table model
+func GetAddressUnstakeModel() *AddressUnstakeModel {
+	addressUnstakeModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		addressUnstakeModel = &AddressUnstakeModel{
+			db:       dbConn,
+			model:    &models.AddressUnstake{},
+			modelORM: &models.AddressUnstakeORM{},
+		}
+
+		err := addressUnstakeModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("AddressUnstakeModel: Unable migrate postgres table: ", err.Error())
+		}
+	})
+
+	return addressUnstakeModel
+}
+
+// Migrate - migrate address_unstakes table
+func (m *AddressUnstakeModel) Migrate() error {
+	// Only using AddressUnstakeORM (ORM version of the proto generated struct) to create the TABLE
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
+	return err
+}
+
+// SelectManyByPublicKey - select pending unstakes of an address
+// Ordered by position in the unstakes list
+func (m *AddressUnstakeModel) SelectManyByPublicKey(
+	publicKey string,
+) (*[]models.AddressUnstake, error) {
+	db := m.db
+
+	// Public key
+	db = db.Where("public_key = ?", publicKey)
+
+	// Order by index
+	db = db.Order("unstake_index ASC")
+
+	addressUnstakes := &[]models.AddressUnstake{}
+	db = db.Find(addressUnstakes)
+
+	return addressUnstakes, db.Error
+}
+
+// ReplaceManyByPublicKeys - replace the pending unstakes of many addresses in one transaction
+// Addresses without unstakes are cleared
+func (m *AddressUnstakeModel) ReplaceManyByPublicKeys(
+	publicKeys []string,
+	addressUnstakes []*models.AddressUnstake,
+) error {
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+
+		// Public keys
+		err := tx.Where("public_key IN ?", publicKeys).Delete(&models.AddressUnstake{}).Error
+		if err != nil {
+			return err
+		}
+
+		if len(addressUnstakes) == 0 {
+			return nil
+		}
+
+		return tx.Create(addressUnstakes).Error
+	})
+}
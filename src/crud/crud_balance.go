@@ -13,10 +13,9 @@ import (
 
 // BalanceModel - type for balance table model
 type BalanceModel struct {
-	db            *gorm.DB
-	model         *models.Balance
-	modelORM      *models.BalanceORM
-	LoaderChannel chan *models.Balance
+	db       *gorm.DB
+	model    *models.Balance
+	modelORM *models.BalanceORM
 }
 
 var balanceModel *BalanceModel
@@ -31,17 +30,14 @@ func GetBalanceModel() *BalanceModel {
 		}
 
 		balanceModel = &BalanceModel{
-			db:            dbConn,
-			model:         &models.Balance{},
-			LoaderChannel: make(chan *models.Balance, 1),
+			db:    dbConn,
+			model: &models.Balance{},
 		}
 
 		err := balanceModel.Migrate()
 		if err != nil {
 			zap.S().Fatal("BalanceModel: Unable migrate postgres table: ", err.Error())
 		}
-
-		StartBalanceLoader()
 	})
 
 	return balanceModel
@@ -50,7 +46,7 @@ func GetBalanceModel() *BalanceModel {
 // Migrate - migrate balances table
 func (m *BalanceModel) Migrate() error {
 	// Only using BalanceRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -92,6 +88,77 @@ func (m *BalanceModel) SelectOneByBlockNumber(
 	return balance, db.Error
 }
 
+// SelectOneByTimestamp - select latest balance at or before a block timestamp
+func (m *BalanceModel) SelectOneByTimestamp(
+	publicKey string,
+	timestamp uint64,
+) (*models.Balance, error) {
+	db := m.db
+
+	// Order by block number, transaction_index, log_index
+	db = db.Order("block_number DESC, transaction_index DESC, log_index DESC")
+
+	// publicKey
+	db = db.Where("public_key = ?", publicKey)
+
+	// Timestamp
+	db = db.Where("timestamp <= ?", timestamp)
+
+	balance := &models.Balance{}
+	db = db.First(balance)
+
+	return balance, db.Error
+}
+
+// SelectManyByPublicKey - select balance history of a public key
+func (m *BalanceModel) SelectManyByPublicKey(
+	limit int,
+	skip int,
+	publicKey string,
+) (*[]models.Balance, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Balance{})
+
+	// Order by block number, transaction_index, log_index
+	db = db.Order("block_number DESC, transaction_index DESC, log_index DESC")
+
+	// publicKey
+	db = db.Where("public_key = ?", publicKey)
+
+	// Limit
+	db = db.Limit(limit)
+
+	// Skip
+	if skip != 0 {
+		db = db.Offset(skip)
+	}
+
+	balances := &[]models.Balance{}
+	db = db.Find(balances)
+
+	return balances, db.Error
+}
+
+// CountByPublicKey - count balance history entries of a public key
+func (m *BalanceModel) CountByPublicKey(
+	publicKey string,
+) (int64, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Balance{})
+
+	// publicKey
+	db = db.Where("public_key = ?", publicKey)
+
+	count := int64(0)
+	db = db.Count(&count)
+
+	return count, db.Error
+}
+
 func (m *BalanceModel) SelectOneByBlockNumberTransactionIndexLogIndex(
 	publicKey string,
 	blockNumber uint64,
@@ -135,6 +202,24 @@ func (m *BalanceModel) SelectLatestBlockNumber() (uint64, error) {
 	return blockNumber, db.Error
 }
 
+// SelectPublicKeysFromBlockNumber - public keys with balances at or above a block number
+func (m *BalanceModel) SelectPublicKeysFromBlockNumber(
+	blockNumber uint64,
+) ([]string, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Balance{})
+
+	// Block number
+	db = db.Where("block_number >= ?", blockNumber)
+
+	publicKeys := []string{}
+	db = db.Distinct().Pluck("public_key", &publicKeys)
+
+	return publicKeys, db.Error
+}
+
 func (m *BalanceModel) UpsertOne(
 	balance *models.Balance,
 ) error {
@@ -160,45 +245,95 @@ func (m *BalanceModel) UpsertOne(
 	return db.Error
 }
 
-// StartBalanceLoader starts loader
-func StartBalanceLoader() {
-	go func() {
-		postgresLoaderChan := GetBalanceModel().LoaderChannel
-
-		for {
-			// Read balance
-			newBalance := <-postgresLoaderChan
-
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
-			err := GetBalanceModel().UpsertOne(newBalance)
-			zap.S().Debug(
-				"Loader=Balance,",
-				"BlockNumber=", newBalance.BlockNumber,
-				"TransactionIndex=", newBalance.TransactionIndex,
-				"LogIndex=", newBalance.LogIndex,
-				"PublicKey=", newBalance.PublicKey,
-				" - Upserted",
-			)
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal(
-					"Loader=Balance,",
-					"BlockNumber=", newBalance.BlockNumber,
-					"TransactionIndex=", newBalance.TransactionIndex,
-					"LogIndex=", newBalance.LogIndex,
-					"PublicKey=", newBalance.PublicKey,
-					" - Error: ", err.Error(),
-				)
-			}
+// SelectManyLatestByPublicKeys - select the latest balance of each public key before a block number
+// Public keys without a balance are not returned
+func (m *BalanceModel) SelectManyLatestByPublicKeys(
+	publicKeys []string,
+	blockNumber uint64,
+) (*[]models.Balance, error) {
+	db := m.db
+
+	balances := &[]models.Balance{}
+	db = db.Raw(
+		`SELECT DISTINCT ON (public_key) * FROM balances
+		WHERE public_key IN ? AND block_number < ?
+		ORDER BY public_key, block_number DESC, transaction_index DESC, log_index DESC`,
+		publicKeys,
+		blockNumber,
+	).Scan(balances)
 
-			// Force addresses enrichment
-			err = reloadAddress(newBalance.PublicKey)
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal(err.Error())
+	return balances, db.Error
+}
+
+// UpsertManyWithCheckpoint - upsert balances and a builder checkpoint in one transaction
+// NOTE the builder checkpoint is only written if all balances are
+// Returns ErrBuilderCheckpointMoved if the checkpoint is no longer previousBuilderCheckpoint, nil if it did not exist
+func (m *BalanceModel) UpsertManyWithCheckpoint(
+	balances []*models.Balance,
+	builderCheckpoint *models.BuilderCheckpoint,
+	previousBuilderCheckpoint *models.BuilderCheckpoint,
+) error {
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+
+		// Checkpoint rewound or reset while building
+		err := GetBuilderCheckpointModel().checkOne(tx, builderCheckpoint, previousBuilderCheckpoint)
+		if err != nil {
+			return err
+		}
+
+		if len(balances) > 0 {
+			// Upsert
+			db := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{
+					{Name: "block_number"},
+					{Name: "transaction_index"},
+					{Name: "log_index"},
+					{Name: "public_key"},
+				}, // NOTE set to primary keys for table
+				DoUpdates: clause.AssignmentColumns([]string{"value", "value_decimal", "timestamp", "value_loop"}),
+			}).CreateInBatches(balances, 1000)
+			if db.Error != nil {
+				return db.Error
 			}
 		}
-	}()
+
+		return GetBuilderCheckpointModel().upsertOne(tx, builderCheckpoint)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Force addresses enrichment
+	publicKeys := []string{}
+	isReloaded := map[string]bool{}
+	for _, balance := range balances {
+		if isReloaded[balance.PublicKey] == true {
+			continue
+		}
+		isReloaded[balance.PublicKey] = true
+
+		publicKeys = append(publicKeys, balance.PublicKey)
+	}
+
+	return reloadAddresses(publicKeys)
+}
+
+// SelectManyByBlockNumberRange - select balances between two block numbers, inclusive
+func (m *BalanceModel) SelectManyByBlockNumberRange(
+	startBlockNumber uint64,
+	endBlockNumber uint64,
+) (*[]models.Balance, error) {
+	db := m.db
+
+	// Order by block number, transaction_index, log_index, public_key
+	db = db.Order("block_number ASC, transaction_index ASC, log_index ASC, public_key ASC")
+
+	// Block number
+	db = db.Where("block_number >= ?", startBlockNumber)
+	db = db.Where("block_number <= ?", endBlockNumber)
+
+	balances := &[]models.Balance{}
+	db = db.Find(balances)
+
+	return balances, db.Error
 }
@@ -0,0 +1,159 @@
+package crud
+
+import (
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// BalanceDiscrepancyModel - type for balance_discrepancies table model
+type BalanceDiscrepancyModel struct {
+	db       *gorm.DB
+	model    *models.BalanceDiscrepancy
+	modelORM *models.BalanceDiscrepancyORM
+}
+
+var balanceDiscrepancyModel *BalanceDiscrepancyModel
+var balanceDiscrepancyModelOnce sync.Once
+
+// GetBalanceDiscrepancyModel - create and/or return the balance_discrepancies table model
+func GetBalanceDiscrepancyModel() *BalanceDiscrepancyModel {
+	balanceDiscrepancyModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		balanceDiscrepancyModel = &BalanceDiscrepancyModel{
+			db:       dbConn,
+			model:    &models.BalanceDiscrepancy{},
+			modelORM: &models.BalanceDiscrepancyORM{},
+		}
+
+		err := balanceDiscrepancyModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("BalanceDiscrepancyModel: Unable migrate postgres table: ", err.Error())
+		}
+	})
+
+	return balanceDiscrepancyModel
+}
+
+// Migrate - migrate balance_discrepancies table
+func (m *BalanceDiscrepancyModel) Migrate() error {
+	// Only using BalanceDiscrepancyORM (ORM version of the proto generated struct) to create the TABLE
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
+	return err
+}
+
+// SelectOne - select from balance_discrepancies table
+func (m *BalanceDiscrepancyModel) SelectOne(
+	publicKey string,
+) (*models.BalanceDiscrepancy, error) {
+	db := m.db
+
+	// Public key
+	db = db.Where("public_key = ?", publicKey)
+
+	balanceDiscrepancy := &models.BalanceDiscrepancy{}
+	db = db.First(balanceDiscrepancy)
+
+	return balanceDiscrepancy, db.Error
+}
+
+// SelectManyByPublicKeys - select discrepancies of many public keys
+func (m *BalanceDiscrepancyModel) SelectManyByPublicKeys(
+	publicKeys []string,
+) (*[]models.BalanceDiscrepancy, error) {
+	db := m.db
+
+	// Public keys
+	db = db.Where("public_key IN ?", publicKeys)
+
+	balanceDiscrepancies := &[]models.BalanceDiscrepancy{}
+	db = db.Find(balanceDiscrepancies)
+
+	return balanceDiscrepancies, db.Error
+}
+
+// SelectMany - select from balance_discrepancies table
+// Ordered by the first block the values differ
+func (m *BalanceDiscrepancyModel) SelectMany(
+	limit int,
+	skip int,
+) (*[]models.BalanceDiscrepancy, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.BalanceDiscrepancy{})
+
+	// Order by first block number
+	db = db.Order("first_block_number ASC, public_key ASC")
+
+	// Limit
+	db = db.Limit(limit)
+
+	// Skip
+	if skip != 0 {
+		db = db.Offset(skip)
+	}
+
+	balanceDiscrepancies := &[]models.BalanceDiscrepancy{}
+	db = db.Find(balanceDiscrepancies)
+
+	return balanceDiscrepancies, db.Error
+}
+
+// Count - count all entries in balance_discrepancies table
+func (m *BalanceDiscrepancyModel) Count() (int64, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.BalanceDiscrepancy{})
+
+	count := int64(0)
+	db = db.Count(&count)
+
+	return count, db.Error
+}
+
+// UpsertOne - upsert into balance_discrepancies table
+// NOTE detected_timestamp is only set on insert
+func (m *BalanceDiscrepancyModel) UpsertOne(
+	balanceDiscrepancy *models.BalanceDiscrepancy,
+) error {
+	db := m.db
+
+	// Upsert
+	db = db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "public_key"}}, // NOTE set to primary keys for table
+		DoUpdates: clause.AssignmentColumns([]string{
+			"block_number",
+			"first_block_number",
+			"ledger_value",
+			"node_value",
+			"difference",
+			"updated_timestamp",
+		}),
+	}).Create(balanceDiscrepancy)
+
+	return db.Error
+}
+
+// DeleteOne - delete from balance_discrepancies table
+func (m *BalanceDiscrepancyModel) DeleteOne(
+	publicKey string,
+) error {
+	db := m.db
+
+	// Public key
+	db = db.Where("public_key = ?", publicKey)
+
+	db = db.Delete(&models.BalanceDiscrepancy{})
+
+	return db.Error
+}
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"sync"
@@ -9,6 +10,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
 )
 
@@ -42,7 +44,7 @@ func GetBlockModel() *BlockModel {
 			zap.S().Fatal("BlockModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartBlockLoader()
+		StartBlockLoader(global.ShutdownContext())
 	})
 
 	return blockModel
@@ -51,7 +53,7 @@ func GetBlockModel() *BlockModel {
 // Migrate - migrate blocks table
 func (m *BlockModel) Migrate() error {
 	// Only using BlockRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -69,6 +71,42 @@ func (m *BlockModel) SelectOne(
 	return block, db.Error
 }
 
+// SelectLatestNumber - select the highest block number in blocks table
+func (m *BlockModel) SelectLatestNumber() (uint32, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Block{})
+
+	// Get max number
+	number := uint32(0)
+	row := db.Select("COALESCE(max(number), 0)").Row()
+	err := row.Scan(&number)
+
+	return number, err
+}
+
+// SelectManyFromNumber - select blocks at or above a block number, in order
+func (m *BlockModel) SelectManyFromNumber(
+	number uint32,
+) (*[]models.Block, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Block{})
+
+	// Number
+	db = db.Where("number >= ?", number)
+
+	// Order
+	db = db.Order("number ASC")
+
+	blocks := &[]models.Block{}
+	db = db.Find(blocks)
+
+	return blocks, db.Error
+}
+
 // UpdateOne - select from blocks table
 func (m *BlockModel) UpdateOne(
 	block *models.Block,
@@ -103,53 +141,48 @@ func (m *BlockModel) UpsertOne(
 }
 
 // StartBlockLoader starts loader
-func StartBlockLoader() {
-	go func() {
-
-		for {
-			// Read block
-			newBlock := <-GetBlockModel().LoaderChannel
-
-			/////////////////
-			// Enrichments //
-			/////////////////
-			logCount := uint32(0)
-
-			////////////////////////
-			// Log Count By Block //
-			////////////////////////
-			allLogCountsByBlockNumber, err := GetLogCountByBlockNumberModel().SelectManyByBlockNumber(uint64(newBlock.Number))
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal(err.Error())
-			}
-
-			// logCount
-			seenTransactionHashesInAllLogCountsByBlockNumber := map[string]bool{}
-			for _, logCountsByBlockNumber := range allLogCountsByBlockNumber {
-				transactionHash := logCountsByBlockNumber.TransactionHash
-				_, ok := seenTransactionHashesInAllLogCountsByBlockNumber[transactionHash]
-				if ok == false {
-					// new transaction hash
-					logCount += logCountsByBlockNumber.MaxCountByTransaction
-				}
-
-				seenTransactionHashesInAllLogCountsByBlockNumber[transactionHash] = true
-			}
-
-			newBlock.LogCount = logCount
-
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
-			err = GetBlockModel().UpsertOne(newBlock)
-			zap.S().Debug("Loader=Block, Number=", newBlock.Number, " - Upserted")
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal("Loader=Block, Number=", newBlock.Number, " - Error: ", err.Error())
-			}
+func StartBlockLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"block",
+		GetBlockModel().db,
+		[]string{"number"}, // NOTE set to primary keys for table
+		true,
+	)
+
+	loader.enrich = func(row interface{}) {
+		enrichBlock(row.(*models.Block))
+	}
+
+	loader.start(ctx, GetBlockModel().LoaderChannel)
+}
+
+// enrichBlock - set block fields from other tables
+func enrichBlock(newBlock *models.Block) {
+	logCount := uint32(0)
+
+	////////////////////////
+	// Log Count By Block //
+	////////////////////////
+	allLogCountsByBlockNumber, err := GetLogCountByBlockNumberModel().SelectManyByBlockNumber(uint64(newBlock.Number), newBlock.Hash)
+	if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	}
+
+	// logCount
+	seenTransactionHashesInAllLogCountsByBlockNumber := map[string]bool{}
+	for _, logCountsByBlockNumber := range allLogCountsByBlockNumber {
+		transactionHash := logCountsByBlockNumber.TransactionHash
+		_, ok := seenTransactionHashesInAllLogCountsByBlockNumber[transactionHash]
+		if ok == false {
+			// new transaction hash
+			logCount += logCountsByBlockNumber.MaxCountByTransaction
 		}
-	}()
+
+		seenTransactionHashesInAllLogCountsByBlockNumber[transactionHash] = true
+	}
+
+	newBlock.LogCount = logCount
 }
 
 // reloadBlock - Send block back to loader for updates
@@ -0,0 +1,344 @@
+package crud
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// ErrBuilderCheckpointMoved - checkpoint changed since a builder read it, see BalanceModel.UpsertManyWithCheckpoint
+var ErrBuilderCheckpointMoved = errors.New("Builder checkpoint moved")
+
+// Builder names, see worker/builders
+const (
+	BalanceBuilderName          = "balance"
+	AddressDailyStatBuilderName = "address_daily_stats"
+)
+
+// Rows built by block, deleted at or above the block number when a builder is rewound
+// NOTE address daily stats clear the days of a rewound block number when they are rebuilt
+var builderRewindTables = map[string][]string{
+	BalanceBuilderName:          {"balances"},
+	AddressDailyStatBuilderName: {},
+}
+
+// IsBuilderValid - check if a builder name is known
+func IsBuilderValid(builder string) bool {
+	_, ok := builderRewindTables[builder]
+	return ok
+}
+
+// BuilderCheckpointModel - type for builder_checkpoints table model
+type BuilderCheckpointModel struct {
+	db       *gorm.DB
+	model    *models.BuilderCheckpoint
+	modelORM *models.BuilderCheckpointORM
+}
+
+var builderCheckpointModel *BuilderCheckpointModel
+var builderCheckpointModelOnce sync.Once
+
+// GetBuilderCheckpointModel - create and/or return the builder_checkpoints table model
+func GetBuilderCheckpointModel() *BuilderCheckpointModel {
+	builderCheckpointModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		builderCheckpointModel = &BuilderCheckpointModel{
+			db:       dbConn,
+			model:    &models.BuilderCheckpoint{},
+			modelORM: &models.BuilderCheckpointORM{},
+		}
+
+		err := builderCheckpointModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("BuilderCheckpointModel: Unable migrate postgres table: ", err.Error())
+		}
+	})
+
+	return builderCheckpointModel
+}
+
+// Migrate - migrate builder_checkpoints table
+func (m *BuilderCheckpointModel) Migrate() error {
+	// Only using BuilderCheckpointORM (ORM version of the proto generated struct) to create the TABLE
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
+	return err
+}
+
+// SelectOne - select from builder_checkpoints table
+func (m *BuilderCheckpointModel) SelectOne(
+	builder string,
+	shard uint32,
+	shardCount uint32,
+) (*models.BuilderCheckpoint, error) {
+	db := m.db
+
+	// Builder
+	db = db.Where("builder = ?", builder)
+
+	// Shard
+	db = db.Where("shard = ?", shard)
+
+	// Shard count
+	db = db.Where("shard_count = ?", shardCount)
+
+	builderCheckpoint := &models.BuilderCheckpoint{}
+	db = db.First(builderCheckpoint)
+
+	return builderCheckpoint, db.Error
+}
+
+// SelectMany - select all checkpoints of a builder
+func (m *BuilderCheckpointModel) SelectMany(
+	builder string,
+) (*[]models.BuilderCheckpoint, error) {
+	db := m.db
+
+	// Order by shard count, shard
+	db = db.Order("shard_count ASC, shard ASC")
+
+	// Builder
+	db = db.Where("builder = ?", builder)
+
+	builderCheckpoints := &[]models.BuilderCheckpoint{}
+	db = db.Find(builderCheckpoints)
+
+	return builderCheckpoints, db.Error
+}
+
+// SelectResumeBlockNumber - last block completed by every shard of another shard count
+// Used when the number of shards changes
+// Returns gorm.ErrRecordNotFound if no other shard count has a checkpoint for all of its shards
+func (m *BuilderCheckpointModel) SelectResumeBlockNumber(
+	builder string,
+	shardCount uint32,
+) (uint64, error) {
+	db := m.db
+
+	var blockNumber sql.NullInt64
+	db = db.Raw(
+		`SELECT max(shard_count_block_number) FROM (
+			SELECT min(block_number) AS shard_count_block_number FROM builder_checkpoints
+			WHERE builder = ? AND shard_count != ?
+			GROUP BY shard_count
+			HAVING count(*) = shard_count
+		) shard_counts`,
+		builder,
+		shardCount,
+	).Scan(&blockNumber)
+	if db.Error != nil {
+		return 0, db.Error
+	}
+	if blockNumber.Valid == false {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return uint64(blockNumber.Int64), nil
+}
+
+// SelectCompletedBlockNumber - last block completed by every shard of a shard count
+// Returns gorm.ErrRecordNotFound if a shard has no checkpoint
+func (m *BuilderCheckpointModel) SelectCompletedBlockNumber(
+	builder string,
+	shardCount uint32,
+) (uint64, error) {
+	db := m.db
+
+	var blockNumber sql.NullInt64
+	db = db.Raw(
+		`SELECT min(block_number) FROM builder_checkpoints
+		WHERE builder = ? AND shard_count = ?
+		HAVING count(*) = ?`,
+		builder,
+		shardCount,
+		shardCount,
+	).Scan(&blockNumber)
+	if db.Error != nil {
+		return 0, db.Error
+	}
+	if blockNumber.Valid == false {
+		return 0, gorm.ErrRecordNotFound
+	}
+
+	return uint64(blockNumber.Int64), nil
+}
+
+// upsertOne - upsert a checkpoint in a transaction
+func (m *BuilderCheckpointModel) upsertOne(
+	tx *gorm.DB,
+	builderCheckpoint *models.BuilderCheckpoint,
+) error {
+	db := tx
+
+	// Upsert
+	db = db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "builder"},
+			{Name: "shard"},
+			{Name: "shard_count"},
+		}, // NOTE set to primary keys for table
+		DoUpdates: clause.AssignmentColumns([]string{
+			"block_number",
+			"updated_timestamp",
+			"checksum",
+			"checksum_start_block_number",
+		}),
+	}).Create(builderCheckpoint)
+
+	return db.Error
+}
+
+// checkOne - lock a checkpoint in a transaction and check it has not moved
+// previousBuilderCheckpoint is nil if the checkpoint did not exist
+func (m *BuilderCheckpointModel) checkOne(
+	tx *gorm.DB,
+	builderCheckpoint *models.BuilderCheckpoint,
+	previousBuilderCheckpoint *models.BuilderCheckpoint,
+) error {
+	db := tx
+
+	// Lock row
+	db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+
+	// Builder
+	db = db.Where("builder = ?", builderCheckpoint.Builder)
+
+	// Shard
+	db = db.Where("shard = ?", builderCheckpoint.Shard)
+
+	// Shard count
+	db = db.Where("shard_count = ?", builderCheckpoint.ShardCount)
+
+	currentBuilderCheckpoint := &models.BuilderCheckpoint{}
+	db = db.First(currentBuilderCheckpoint)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		if previousBuilderCheckpoint != nil {
+			return ErrBuilderCheckpointMoved
+		}
+		return nil
+	} else if db.Error != nil {
+		return db.Error
+	}
+
+	if previousBuilderCheckpoint == nil ||
+		currentBuilderCheckpoint.BlockNumber != previousBuilderCheckpoint.BlockNumber ||
+		currentBuilderCheckpoint.Checksum != previousBuilderCheckpoint.Checksum {
+		return ErrBuilderCheckpointMoved
+	}
+
+	return nil
+}
+
+// RewindMany - restart all checkpoints of a builder from a block number
+// Rows the builder built at or above the block number are deleted in the same transaction
+// Rewinding to block 0 resets the builder
+// Returns the number of checkpoints changed
+func (m *BuilderCheckpointModel) RewindMany(
+	builder string,
+	blockNumber uint64,
+) (int64, error) {
+	count := int64(0)
+
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		// NOTE checkpoints are locked first, batches being written fail with ErrBuilderCheckpointMoved
+		count, err = m.rewind(tx.Where("builder = ?", builder), blockNumber)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range builderRewindTables[builder] {
+			err = tx.Exec("DELETE FROM "+table+" WHERE block_number >= ?", blockNumber).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// RewindOne - restart a builder shard checkpoint from a block number
+// Rows of the shard public keys the builder built at or above the block number are deleted in the same transaction
+// NOTE rows of other shards are kept, nil public keys delete the rows of every public key
+func (m *BuilderCheckpointModel) RewindOne(
+	builder string,
+	shard uint32,
+	shardCount uint32,
+	blockNumber uint64,
+	shardPublicKeys []string,
+) error {
+
+	return m.db.Transaction(func(tx *gorm.DB) error {
+		db := tx
+
+		// Builder
+		db = db.Where("builder = ?", builder)
+
+		// Shard
+		db = db.Where("shard = ?", shard)
+
+		// Shard count
+		db = db.Where("shard_count = ?", shardCount)
+
+		_, err := m.rewind(db, blockNumber)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range builderRewindTables[builder] {
+			if shardPublicKeys == nil {
+				err = tx.Exec("DELETE FROM "+table+" WHERE block_number >= ?", blockNumber).Error
+			} else {
+				err = tx.Exec(
+					"DELETE FROM "+table+" WHERE block_number >= ? AND public_key = ANY(?::text[])",
+					blockNumber,
+					postgresArrayLiteral(shardPublicKeys),
+				).Error
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// rewind - restart checkpoints at or above a block number from it
+func (m *BuilderCheckpointModel) rewind(
+	db *gorm.DB,
+	blockNumber uint64,
+) (int64, error) {
+
+	// Set table
+	db = db.Model(&models.BuilderCheckpoint{})
+
+	// Block number
+	db = db.Where("block_number >= ?", blockNumber)
+
+	if blockNumber == 0 {
+		db = db.Delete(&models.BuilderCheckpoint{})
+	} else {
+		db = db.Updates(map[string]interface{}{
+			"block_number":      blockNumber - 1,
+			"updated_timestamp": uint64(time.Now().UnixNano() / 1000),
+			"checksum":          "",
+		})
+	}
+
+	return db.RowsAffected, db.Error
+}
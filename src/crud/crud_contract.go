@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"reflect"
 	"sync"
 
@@ -8,6 +9,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
 )
 
@@ -41,7 +43,7 @@ func GetContractModel() *ContractModel {
 			zap.S().Fatal("ContractModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartContractLoader()
+		StartContractLoader(global.ShutdownContext())
 	})
 
 	return contractModel
@@ -50,7 +52,7 @@ func GetContractModel() *ContractModel {
 // Migrate - migrate contracts table
 func (m *ContractModel) Migrate() error {
 	// Only using ContractRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -72,6 +74,25 @@ func (m *ContractModel) SelectOne(
 	return contract, db.Error
 }
 
+// SelectManyByAddresses - select many contracts by address
+// Addresses without a row are left out
+func (m *ContractModel) SelectManyByAddresses(
+	addresses []string,
+) (*[]models.ContractProcessed, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.ContractProcessed{})
+
+	// Addresses
+	db = db.Where("address IN ?", addresses)
+
+	contracts := &[]models.ContractProcessed{}
+	db = db.Find(contracts)
+
+	return contracts, db.Error
+}
+
 func (m *ContractModel) UpsertOne(
 	contract *models.ContractProcessed,
 ) error {
@@ -93,29 +114,24 @@ func (m *ContractModel) UpsertOne(
 }
 
 // StartContractLoader starts loader
-func StartContractLoader() {
-	go func() {
-
-		for {
-			// Read contract
-			newContract := <-GetContractModel().LoaderChannel
-
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
-			err := GetContractModel().UpsertOne(newContract)
-			zap.S().Debug("Loader=Contract, Address=", newContract.Address, " - Upserted")
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal("Loader=Contract, Address=", newContract.Address, " - Error: ", err.Error())
-			}
-
-			// Force addresses enrichment
-			err = reloadAddress(newContract.Address)
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal(err.Error())
-			}
+func StartContractLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"contract",
+		GetContractModel().db,
+		[]string{"address"}, // NOTE set to primary keys for table
+		true,
+	)
+
+	loader.afterFlush = func(row interface{}) {
+		newContract := row.(*models.ContractProcessed)
+
+		// Force addresses enrichment
+		err := reloadAddress(newContract.Address)
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
 		}
-	}()
+	}
+
+	loader.start(ctx, GetContractModel().LoaderChannel)
 }
@@ -0,0 +1,146 @@
+package crud
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geometry-labs/icon-addresses/global"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// FailedMessageModel - type for failed_message table model
+type FailedMessageModel struct {
+	db            *gorm.DB
+	model         *models.FailedMessage
+	modelORM      *models.FailedMessageORM
+	LoaderChannel chan *models.FailedMessage
+}
+
+var failedMessageModel *FailedMessageModel
+var failedMessageModelOnce sync.Once
+
+// GetFailedMessageModel - create and/or return the failed_messages table model
+func GetFailedMessageModel() *FailedMessageModel {
+	failedMessageModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		failedMessageModel = &FailedMessageModel{
+			db:            dbConn,
+			model:         &models.FailedMessage{},
+			LoaderChannel: make(chan *models.FailedMessage, 1),
+		}
+
+		err := failedMessageModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("FailedMessageModel: Unable migrate postgres table: ", err.Error())
+		}
+
+		StartFailedMessageLoader(global.ShutdownContext())
+	})
+
+	return failedMessageModel
+}
+
+// Migrate - migrate failed_messages table
+func (m *FailedMessageModel) Migrate() error {
+	// Only using FailedMessageORM (ORM version of the proto generated struct) to create the TABLE
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
+	return err
+}
+
+// SelectManyNotReplayed - select failed messages that have not been replayed
+// NOTE topic is optional
+func (m *FailedMessageModel) SelectManyNotReplayed(
+	limit int,
+	topic string,
+) (*[]models.FailedMessage, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.FailedMessage{})
+
+	// Order by position in topic
+	db = db.Order(`topic, partition, "offset"`)
+
+	// Not replayed
+	db = db.Where("replayed_timestamp = ?", 0)
+
+	// Topic
+	if topic != "" {
+		db = db.Where("topic = ?", topic)
+	}
+
+	// Limit
+	if limit != 0 {
+		db = db.Limit(limit)
+	}
+
+	failedMessages := &[]models.FailedMessage{}
+	db = db.Find(failedMessages)
+
+	return failedMessages, db.Error
+}
+
+// UpdateReplayedTimestamp - mark a failed message as replayed
+func (m *FailedMessageModel) UpdateReplayedTimestamp(
+	failedMessage *models.FailedMessage,
+	replayedTimestamp uint64,
+) error {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.FailedMessage{})
+
+	// Primary keys
+	db = db.Where("topic = ?", failedMessage.Topic)
+	db = db.Where("partition = ?", failedMessage.Partition)
+	db = db.Where(`"offset" = ?`, failedMessage.Offset)
+
+	db = db.Update("replayed_timestamp", replayedTimestamp)
+
+	return db.Error
+}
+
+func (m *FailedMessageModel) UpsertOne(
+	failedMessage *models.FailedMessage,
+) error {
+	db := m.db
+
+	// map[string]interface{}
+	updateOnConflictValues := extractAllFieldsFromModel(
+		reflect.ValueOf(*failedMessage),
+		reflect.TypeOf(*failedMessage),
+	)
+
+	// Upsert
+	db = db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "topic"},
+			{Name: "partition"},
+			{Name: "offset"},
+		}, // NOTE set to primary keys for table
+		DoUpdates: clause.Assignments(updateOnConflictValues),
+	}).Create(failedMessage)
+
+	return db.Error
+}
+
+// StartFailedMessageLoader starts loader
+func StartFailedMessageLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"failed_message",
+		GetFailedMessageModel().db,
+		[]string{"topic", "partition", "offset"}, // NOTE set to primary keys for table
+		false,
+	)
+
+	loader.start(ctx, GetFailedMessageModel().LoaderChannel)
+}
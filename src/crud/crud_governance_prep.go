@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"reflect"
 	"sync"
 
@@ -8,6 +9,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
 )
 
@@ -41,7 +43,7 @@ func GetGovernancePrepProcessedModel() *GovernancePrepProcessedModel {
 			zap.S().Fatal("GovernancePrepProcessedModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartGovernancePrepProcessedLoader()
+		StartGovernancePrepProcessedLoader(global.ShutdownContext())
 	})
 
 	return goveranancePrepModel
@@ -50,7 +52,7 @@ func GetGovernancePrepProcessedModel() *GovernancePrepProcessedModel {
 // Migrate - migrate goveranancePreps table
 func (m *GovernancePrepProcessedModel) Migrate() error {
 	// Only using GovernancePrepProcessedRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -72,6 +74,25 @@ func (m *GovernancePrepProcessedModel) SelectOne(
 	return goveranancePrep, db.Error
 }
 
+// SelectManyByAddresses - select many governance preps by address
+// Addresses without a row are left out
+func (m *GovernancePrepProcessedModel) SelectManyByAddresses(
+	addresses []string,
+) (*[]models.GovernancePrepProcessed, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.GovernancePrepProcessed{})
+
+	// Addresses
+	db = db.Where("address IN ?", addresses)
+
+	governancePreps := &[]models.GovernancePrepProcessed{}
+	db = db.Find(governancePreps)
+
+	return governancePreps, db.Error
+}
+
 func (m *GovernancePrepProcessedModel) UpsertOne(
 	goveranancePrep *models.GovernancePrepProcessed,
 ) error {
@@ -93,29 +114,24 @@ func (m *GovernancePrepProcessedModel) UpsertOne(
 }
 
 // StartGovernancePrepProcessedLoader starts loader
-func StartGovernancePrepProcessedLoader() {
-	go func() {
-
-		for {
-			// Read goveranancePrep
-			newGovernancePrepProcessed := <-GetGovernancePrepProcessedModel().LoaderChannel
-
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
-			err := GetGovernancePrepProcessedModel().UpsertOne(newGovernancePrepProcessed)
-			zap.S().Debug("Loader=GovernancePrepProcessed, Address=", newGovernancePrepProcessed.Address, " - Upserted")
-			if err != nil {
-				// Postgres error
-				zap.S().Error("Loader=GovernancePrepProcessed, Address=", newGovernancePrepProcessed.Address, " - Error: ", err.Error())
-			}
-
-			// Force addresses enrichment
-			err = reloadAddress(newGovernancePrepProcessed.Address)
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal(err.Error())
-			}
+func StartGovernancePrepProcessedLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"governance_prep",
+		GetGovernancePrepProcessedModel().db,
+		[]string{"address"}, // NOTE set to primary keys for table
+		true,
+	)
+
+	loader.afterFlush = func(row interface{}) {
+		newGovernancePrepProcessed := row.(*models.GovernancePrepProcessed)
+
+		// Force addresses enrichment
+		err := reloadAddress(newGovernancePrepProcessed.Address)
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
 		}
-	}()
+	}
+
+	loader.start(ctx, GetGovernancePrepProcessedModel().LoaderChannel)
 }
@@ -0,0 +1,94 @@
+package crud
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/global"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// IScoreClaimModel - type for i_score_claims table model
+type IScoreClaimModel struct {
+	db            *gorm.DB
+	model         *models.IScoreClaim
+	modelORM      *models.IScoreClaimORM
+	LoaderChannel chan *models.IScoreClaim
+}
+
+var iScoreClaimModel *IScoreClaimModel
+var iScoreClaimModelOnce sync.Once
+
+// GetIScoreClaimModel - create and/or return the i_score_claims table model
+func GetIScoreClaimModel() *IScoreClaimModel {
+	iScoreClaimModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		iScoreClaimModel = &IScoreClaimModel{
+			db:            dbConn,
+			model:         &models.IScoreClaim{},
+			modelORM:      &models.IScoreClaimORM{},
+			LoaderChannel: make(chan *models.IScoreClaim, 1),
+		}
+
+		err := iScoreClaimModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("IScoreClaimModel: Unable migrate postgres table: ", err.Error())
+		}
+
+		StartIScoreClaimLoader(global.ShutdownContext())
+	})
+
+	return iScoreClaimModel
+}
+
+// Migrate - migrate i_score_claims table
+func (m *IScoreClaimModel) Migrate() error {
+	// Only using IScoreClaimORM (ORM version of the proto generated struct) to create the TABLE
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
+	return err
+}
+
+// SelectManyByBlockNumber - select many by block number and block hash
+// NOTE rows loaded before block hashes were stored have an empty block hash
+func (m *IScoreClaimModel) SelectManyByBlockNumber(
+	blockNumber uint64,
+	blockHash string,
+) (*[]models.IScoreClaim, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.IScoreClaim{})
+
+	// Order by transaction index, log index
+	db = db.Order("transaction_index ASC, log_index ASC")
+
+	// Block Number
+	db = db.Where("block_number = ?", blockNumber)
+
+	// Block Hash
+	db = db.Where("block_hash IN ?", []string{blockHash, ""})
+
+	iScoreClaims := &[]models.IScoreClaim{}
+	db = db.Find(iScoreClaims)
+
+	return iScoreClaims, db.Error
+}
+
+// StartIScoreClaimLoader starts loader
+func StartIScoreClaimLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"iscore_claim",
+		GetIScoreClaimModel().db,
+		[]string{"transaction_hash", "log_index"}, // NOTE set to primary keys for table
+		true,
+	)
+
+	loader.start(ctx, GetIScoreClaimModel().LoaderChannel)
+}
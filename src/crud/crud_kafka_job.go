@@ -47,7 +47,7 @@ func GetKafkaJobModel() *KafkaJobModel {
 // Migrate - migrate kafkaJobs table
 func (m *KafkaJobModel) Migrate() error {
 	// Only using KafkaJobRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -1,12 +1,14 @@
 package crud
 
 import (
+	"context"
 	"errors"
 	"sync"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
 )
 
@@ -40,7 +42,7 @@ func GetLogCountByBlockNumberModel() *LogCountByBlockNumberModel {
 			zap.S().Fatal("LogCountByBlockNumberModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartLogCountByBlockNumberLoader()
+		StartLogCountByBlockNumberLoader(global.ShutdownContext())
 	})
 
 	return logCountByBlockNumberModel
@@ -49,7 +51,7 @@ func GetLogCountByBlockNumberModel() *LogCountByBlockNumberModel {
 // Migrate - migrate logCountByBlockNumbers table
 func (m *LogCountByBlockNumberModel) Migrate() error {
 	// Only using LogCountByBlockNumberRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -66,7 +68,7 @@ func (m *LogCountByBlockNumberModel) Insert(logCountByBlockNumber *models.LogCou
 }
 
 // Select - select from logCountByBlockNumbers table
-func (m *LogCountByBlockNumberModel) SelectOne(transactionHash string, logIndex uint64) (*models.LogCountByBlockNumber, error) {
+func (m *LogCountByBlockNumberModel) SelectOne(transactionHash string, logIndex uint64, blockHash string) (*models.LogCountByBlockNumber, error) {
 	db := m.db
 
 	// Set table
@@ -78,6 +80,9 @@ func (m *LogCountByBlockNumberModel) SelectOne(transactionHash string, logIndex
 	// Log Index
 	db = db.Where("log_index = ?", logIndex)
 
+	// Block Hash
+	db = db.Where("block_hash = ?", blockHash)
+
 	logCountByBlockNumber := &models.LogCountByBlockNumber{}
 	db = db.First(logCountByBlockNumber)
 
@@ -85,7 +90,8 @@ func (m *LogCountByBlockNumberModel) SelectOne(transactionHash string, logIndex
 }
 
 // SelectManyByBlockNumber - select from logCountByBlockNumbers table
-func (m *LogCountByBlockNumberModel) SelectManyByBlockNumber(blockNumber uint64) ([]models.LogCountByBlockNumber, error) {
+// NOTE rows loaded before block hashes were stored have an empty block hash
+func (m *LogCountByBlockNumberModel) SelectManyByBlockNumber(blockNumber uint64, blockHash string) ([]models.LogCountByBlockNumber, error) {
 	db := m.db
 
 	// Set table
@@ -94,13 +100,19 @@ func (m *LogCountByBlockNumberModel) SelectManyByBlockNumber(blockNumber uint64)
 	// Block number
 	db = db.Where("block_number = ?", blockNumber)
 
+	// Block hash
+	db = db.Where("block_hash IN ?", []string{blockHash, ""})
+
 	logCountByBlockNumber := []models.LogCountByBlockNumber{}
 	db = db.Find(&logCountByBlockNumber)
 
 	return logCountByBlockNumber, db.Error
 }
 
-func (m *LogCountByBlockNumberModel) SelectLargestCountByBlockNumber(blockNumber uint64) (uint64, error) {
+// SelectLargestCountByBlockNumber - count of the rows loaded for a block
+// NOTE counted by block hash, rows of another block at the same number are from a reorg
+// NOTE rows loaded before block hashes were stored have an empty block hash
+func (m *LogCountByBlockNumberModel) SelectLargestCountByBlockNumber(blockNumber uint64, blockHash string) (uint64, error) {
 	db := m.db
 
 	// Set table
@@ -109,6 +121,9 @@ func (m *LogCountByBlockNumberModel) SelectLargestCountByBlockNumber(blockNumber
 	// Block Number
 	db = db.Where("block_number = ?", blockNumber)
 
+	// Block Hash
+	db = db.Where("block_hash IN ?", []string{blockHash, ""})
+
 	// Get max id
 	count := uint64(0)
 	row := db.Select("max(count)").Row()
@@ -118,22 +133,39 @@ func (m *LogCountByBlockNumberModel) SelectLargestCountByBlockNumber(blockNumber
 }
 
 // StartLogCountByBlockNumberLoader starts loader
-func StartLogCountByBlockNumberLoader() {
+// NOTE not batched, counts are only incremented for rows not yet loaded
+func StartLogCountByBlockNumberLoader(ctx context.Context) {
+	shutdownTaskDone := global.AddShutdownTask()
+
 	go func() {
+		defer shutdownTaskDone()
 
 		for {
 			// Read logCountByBlockNumber
-			newLogCountByBlockNumber := <-GetLogCountByBlockNumberModel().LoaderChannel
+			// NOTE on shutdown the channel is drained before returning
+			var newLogCountByBlockNumber *models.LogCountByBlockNumber
+			select {
+			case newLogCountByBlockNumber = <-GetLogCountByBlockNumberModel().LoaderChannel:
+			case <-ctx.Done():
+				select {
+				case newLogCountByBlockNumber = <-GetLogCountByBlockNumberModel().LoaderChannel:
+				default:
+					zap.S().Info("Loader=LogCountByBlockNumber - Drained, stopped")
+					return
+				}
+			}
 
 			// Insert
 			_, err := GetLogCountByBlockNumberModel().SelectOne(
 				newLogCountByBlockNumber.TransactionHash,
 				newLogCountByBlockNumber.LogIndex,
+				newLogCountByBlockNumber.BlockHash,
 			)
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				// Last count
 				lastCount, err := GetLogCountByBlockNumberModel().SelectLargestCountByBlockNumber(
 					newLogCountByBlockNumber.BlockNumber,
+					newLogCountByBlockNumber.BlockHash,
 				)
 				if err != nil {
 					zap.S().Fatal(err.Error())
@@ -160,6 +192,8 @@ func StartLogCountByBlockNumberLoader() {
 				// Postgress error
 				zap.S().Fatal(err.Error())
 			}
+
+			ackLoaded(newLogCountByBlockNumber)
 		}
 	}()
 }
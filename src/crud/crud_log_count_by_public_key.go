@@ -1,7 +1,7 @@
 package crud
 
 import (
-	"errors"
+	"context"
 	"reflect"
 	"sync"
 
@@ -9,8 +9,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
-	"github.com/geometry-labs/icon-addresses/redis"
 )
 
 // LogCountByPublicKeyModel - type for address table model
@@ -43,7 +43,7 @@ func GetLogCountByPublicKeyModel() *LogCountByPublicKeyModel {
 			zap.S().Fatal("LogCountByPublicKeyModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartLogCountByPublicKeyLoader()
+		StartLogCountByPublicKeyLoader(global.ShutdownContext())
 	})
 
 	return logCountByPublicKeyModel
@@ -52,7 +52,7 @@ func GetLogCountByPublicKeyModel() *LogCountByPublicKeyModel {
 // Migrate - migrate logCountByPublicKeys table
 func (m *LogCountByPublicKeyModel) Migrate() error {
 	// Only using LogCountByPublicKeyRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -93,6 +93,25 @@ func (m *LogCountByPublicKeyModel) SelectCount(publicKey string) (uint64, error)
 	return count, db.Error
 }
 
+// SelectManyByPublicKeys - select many log counts by public key
+// Public keys without a row are left out
+func (m *LogCountByPublicKeyModel) SelectManyByPublicKeys(
+	publicKeys []string,
+) (*[]models.LogCountByPublicKey, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.LogCountByPublicKey{})
+
+	// Public Keys
+	db = db.Where("public_key IN ?", publicKeys)
+
+	logCountByPublicKeys := &[]models.LogCountByPublicKey{}
+	db = db.Find(logCountByPublicKeys)
+
+	return logCountByPublicKeys, db.Error
+}
+
 func (m *LogCountByPublicKeyModel) UpsertOne(
 	logCountByPublicKey *models.LogCountByPublicKey,
 ) error {
@@ -114,104 +133,127 @@ func (m *LogCountByPublicKeyModel) UpsertOne(
 }
 
 // StartLogCountByPublicKeyLoader starts loader
-func StartLogCountByPublicKeyLoader() {
-	go func() {
-		postgresLoaderChan := GetLogCountByPublicKeyModel().LoaderChannel
+// NOTE counts are only incremented for logs not yet in the index table
+func StartLogCountByPublicKeyLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"log_count_by_public_key",
+		GetLogCountByPublicKeyModel().db,
+		[]string{"transaction_hash", "log_index", "public_key"}, // NOTE set to primary keys for the index table
+		true,
+	)
+	loader.conflictKeys = []string{"public_key"} // NOTE set to primary keys for table
 
-		for {
-			// Read log
-			newLogCountByPublicKey := <-postgresLoaderChan
+	loader.enrichBatch = func(rows []interface{}) []interface{} {
+		newLogCountByPublicKeys := make([]*models.LogCountByPublicKey, len(rows))
+		for i, row := range rows {
+			newLogCountByPublicKeys[i] = row.(*models.LogCountByPublicKey)
+		}
 
-			//////////////////////////
-			// Get count from redis //
-			//////////////////////////
-			countKey := "icon_addresses_log_count_by_address_" + newLogCountByPublicKey.PublicKey
+		logCountByPublicKeys, err := countNewLogsByPublicKey(newLogCountByPublicKeys)
+		if err != nil {
+			// Postgres or redis error
+			zap.S().Fatal("Loader=LogCountByPublicKey - Error: ", err.Error())
+		}
 
-			count, err := redis.GetRedisClient().GetCount(countKey)
-			if err != nil {
-				zap.S().Fatal(
-					"Loader=LogCountByPublicKey",
-					" Hash=", newLogCountByPublicKey.TransactionHash,
-					" Log Index=", newLogCountByPublicKey.LogIndex,
-					" Public Key=", newLogCountByPublicKey.PublicKey,
-					" - Error: ", err.Error())
-			}
+		rows = make([]interface{}, len(logCountByPublicKeys))
+		for i, logCountByPublicKey := range logCountByPublicKeys {
+			rows[i] = logCountByPublicKey
+		}
+		return rows
+	}
 
-			// No count set yet
-			// Get from database
-			if count == -1 {
-				curLogCountByPublicKey, err := GetLogCountByPublicKeyModel().SelectOne(newLogCountByPublicKey.PublicKey)
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					count = 0
-				} else if err != nil {
-					zap.S().Fatal(
-						"Loader=LogCountByPublicKey",
-						" Hash=", newLogCountByPublicKey.TransactionHash,
-						" Log Index=", newLogCountByPublicKey.LogIndex,
-						" Public Key=", newLogCountByPublicKey.PublicKey,
-						" - Error: ", err.Error())
-				} else {
-					count = int64(curLogCountByPublicKey.Count)
-				}
-
-				// Set count
-				err = redis.GetRedisClient().SetCount(countKey, int64(count))
-				if err != nil {
-					// Redis error
-					zap.S().Fatal(
-						"Loader=LogCountByPublicKey",
-						" Hash=", newLogCountByPublicKey.TransactionHash,
-						" Log Index=", newLogCountByPublicKey.LogIndex,
-						" Public Key=", newLogCountByPublicKey.PublicKey,
-						" - Error: ", err.Error())
-				}
-			}
+	loader.start(ctx, GetLogCountByPublicKeyModel().LoaderChannel)
+}
+
+// countNewLogsByPublicKey - insert logs into the index table and increment the counts of their public keys
+// Returns a count row for each public key with new logs, set to the last new log
+func countNewLogsByPublicKey(newLogCountByPublicKeys []*models.LogCountByPublicKey) ([]*models.LogCountByPublicKey, error) {
+
+	/////////////////////////
+	// Load to index table //
+	/////////////////////////
+	logCountByPublicKeyIndexes := make([]*models.LogCountByPublicKeyIndex, len(newLogCountByPublicKeys))
+	for i, newLogCountByPublicKey := range newLogCountByPublicKeys {
+		logCountByPublicKeyIndexes[i] = &models.LogCountByPublicKeyIndex{
+			TransactionHash: newLogCountByPublicKey.TransactionHash,
+			LogIndex:        newLogCountByPublicKey.LogIndex,
+			PublicKey:       newLogCountByPublicKey.PublicKey,
+		}
+	}
 
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
+	insertedLogCountByPublicKeyIndexes, err := GetLogCountByPublicKeyIndexModel().InsertMany(logCountByPublicKeyIndexes)
+	if err != nil {
+		return nil, err
+	}
 
-			// Add log to indexed
-			newLogCountByPublicKeyIndex := &models.LogCountByPublicKeyIndex{
-				TransactionHash: newLogCountByPublicKey.TransactionHash,
-				LogIndex:        newLogCountByPublicKey.LogIndex,
-				PublicKey:       newLogCountByPublicKey.PublicKey,
-			}
-			err = GetLogCountByPublicKeyIndexModel().Insert(newLogCountByPublicKeyIndex)
-			if err != nil {
-				// Record already exists, continue
-				continue
-			}
+	type indexKey struct {
+		transactionHash string
+		logIndex        uint64
+		publicKey       string
+	}
+	inserted := map[indexKey]bool{}
+	for _, insertedLogCountByPublicKeyIndex := range *insertedLogCountByPublicKeyIndexes {
+		inserted[indexKey{
+			insertedLogCountByPublicKeyIndex.TransactionHash,
+			insertedLogCountByPublicKeyIndex.LogIndex,
+			insertedLogCountByPublicKeyIndex.PublicKey,
+		}] = true
+	}
+
+	increments := map[string]int64{}
+	lastLogCountByPublicKeys := map[string]*models.LogCountByPublicKey{}
+	publicKeys := []string{}
+	for _, newLogCountByPublicKey := range newLogCountByPublicKeys {
+		if inserted[indexKey{
+			newLogCountByPublicKey.TransactionHash,
+			newLogCountByPublicKey.LogIndex,
+			newLogCountByPublicKey.PublicKey,
+		}] == false {
+			// Already counted
+			continue
+		}
 
-			// Increment records
-			count, err = redis.GetRedisClient().IncCount(countKey)
+		if _, ok := increments[newLogCountByPublicKey.PublicKey]; ok == false {
+			publicKeys = append(publicKeys, newLogCountByPublicKey.PublicKey)
+		}
+		increments[newLogCountByPublicKey.PublicKey]++
+		lastLogCountByPublicKeys[newLogCountByPublicKey.PublicKey] = newLogCountByPublicKey
+	}
+
+	////////////////////////////
+	// Increment redis counts //
+	////////////////////////////
+	counts, err := incrementRedisCounts(
+		"icon_addresses_log_count_by_address_",
+		increments,
+		func(publicKeys []string) (map[string]uint64, error) {
+			logCountByPublicKeys, err := GetLogCountByPublicKeyModel().SelectManyByPublicKeys(publicKeys)
 			if err != nil {
-				// Redis error
-				zap.S().Fatal(
-					"Loader=LogCountByPublicKey",
-					" Hash=", newLogCountByPublicKey.TransactionHash,
-					" Log Index=", newLogCountByPublicKey.LogIndex,
-					" Public Key=", newLogCountByPublicKey.PublicKey,
-					" - Error: ", err.Error())
+				return nil, err
 			}
-			newLogCountByPublicKey.Count = uint64(count)
-
-			err = GetLogCountByPublicKeyModel().UpsertOne(newLogCountByPublicKey)
-			zap.S().Debug(
-				"Loader=LogCountByPublicKey",
-				" Hash=", newLogCountByPublicKey.TransactionHash,
-				" Log Index=", newLogCountByPublicKey.LogIndex,
-				" Public Key=", newLogCountByPublicKey.PublicKey,
-				" - Upsert")
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal(
-					"Loader=LogCountByPublicKey",
-					" Hash=", newLogCountByPublicKey.TransactionHash,
-					" Log Index=", newLogCountByPublicKey.LogIndex,
-					" Public Key=", newLogCountByPublicKey.PublicKey,
-					" - Error: ", err.Error())
+
+			storedCounts := map[string]uint64{}
+			for _, logCountByPublicKey := range *logCountByPublicKeys {
+				storedCounts[logCountByPublicKey.PublicKey] = logCountByPublicKey.Count
 			}
-		}
-	}()
+			return storedCounts, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	logCountByPublicKeys := []*models.LogCountByPublicKey{}
+	for _, publicKey := range publicKeys {
+		lastLogCountByPublicKey := lastLogCountByPublicKeys[publicKey]
+
+		logCountByPublicKeys = append(logCountByPublicKeys, &models.LogCountByPublicKey{
+			PublicKey:       publicKey,
+			TransactionHash: lastLogCountByPublicKey.TransactionHash,
+			LogIndex:        lastLogCountByPublicKey.LogIndex,
+			Count:           counts[publicKey],
+		})
+	}
+
+	return logCountByPublicKeys, nil
 }
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"strconv"
 	"sync"
 
 	"go.uber.org/zap"
@@ -46,7 +47,7 @@ func GetLogCountByPublicKeyIndexModel() *LogCountByPublicKeyIndexModel {
 // Migrate - migrate logCountByPublicKeyIndexs table
 func (m *LogCountByPublicKeyIndexModel) Migrate() error {
 	// Only using LogCountByPublicKeyIndexRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -61,3 +62,29 @@ func (m *LogCountByPublicKeyIndexModel) Insert(logCountByPublicKeyIndex *models.
 
 	return db.Error
 }
+
+// InsertMany - insert indexes not yet in the table
+// Returns the inserted rows, rows already in the table are left out
+func (m *LogCountByPublicKeyIndexModel) InsertMany(logCountByPublicKeyIndexes []*models.LogCountByPublicKeyIndex) (*[]models.LogCountByPublicKeyIndex, error) {
+	transactionHashes := make([]string, len(logCountByPublicKeyIndexes))
+	logIndexes := make([]string, len(logCountByPublicKeyIndexes))
+	publicKeys := make([]string, len(logCountByPublicKeyIndexes))
+	for i, row := range logCountByPublicKeyIndexes {
+		transactionHashes[i] = row.TransactionHash
+		logIndexes[i] = strconv.FormatUint(row.LogIndex, 10)
+		publicKeys[i] = row.PublicKey
+	}
+
+	insertedLogCountByPublicKeyIndexes := &[]models.LogCountByPublicKeyIndex{}
+	db := m.db.Raw(
+		`INSERT INTO log_count_by_public_key_indices (transaction_hash, log_index, public_key)
+		SELECT * FROM unnest(?::text[], ?::bigint[], ?::text[])
+		ON CONFLICT DO NOTHING
+		RETURNING transaction_hash, log_index, public_key`,
+		postgresArrayLiteral(transactionHashes),
+		postgresArrayLiteral(logIndexes),
+		postgresArrayLiteral(publicKeys),
+	).Scan(insertedLogCountByPublicKeyIndexes)
+
+	return insertedLogCountByPublicKeyIndexes, db.Error
+}
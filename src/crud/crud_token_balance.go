@@ -0,0 +1,501 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geometry-labs/icon-addresses/global"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// TokenBalanceModel - type for token_balance table model
+type TokenBalanceModel struct {
+	db            *gorm.DB
+	model         *models.TokenBalance
+	modelORM      *models.TokenBalanceORM
+	LoaderChannel chan *models.TokenBalance
+}
+
+var tokenBalanceModel *TokenBalanceModel
+var tokenBalanceModelOnce sync.Once
+
+// GetTokenBalanceModel - create and/or return the token_balances table model
+func GetTokenBalanceModel() *TokenBalanceModel {
+	tokenBalanceModelOnce.Do(func() {
+		dbConn := getPostgresConn()
+		if dbConn == nil {
+			zap.S().Fatal("Cannot connect to postgres database")
+		}
+
+		tokenBalanceModel = &TokenBalanceModel{
+			db:            dbConn,
+			model:         &models.TokenBalance{},
+			LoaderChannel: make(chan *models.TokenBalance, 1),
+		}
+
+		err := tokenBalanceModel.Migrate()
+		if err != nil {
+			zap.S().Fatal("TokenBalanceModel: Unable migrate postgres table: ", err.Error())
+		}
+
+		StartTokenBalanceLoader(global.ShutdownContext())
+	})
+
+	return tokenBalanceModel
+}
+
+// Migrate - migrate token_balances table
+func (m *TokenBalanceModel) Migrate() error {
+	// Only using TokenBalanceORM (ORM version of the proto generated struct) to create the TABLE
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
+	return err
+}
+
+// SelectManyFromPositions - select the ledger entries of public key and token pairs from log positions
+// Returns the latest entry strictly before each position and every entry at or after it, in ledger order
+// NOTE one position per public key and token pair
+func (m *TokenBalanceModel) SelectManyFromPositions(
+	positions []*models.TokenBalance,
+) (*[]models.TokenBalance, *[]models.TokenBalance, error) {
+	return selectTokenBalancesFromPositions(m.db, positions)
+}
+
+// selectTokenBalancesFromPositions - SelectManyFromPositions in a transaction
+func selectTokenBalancesFromPositions(
+	db *gorm.DB,
+	positions []*models.TokenBalance,
+) (*[]models.TokenBalance, *[]models.TokenBalance, error) {
+	publicKeys := make([]string, len(positions))
+	tokenContractAddresses := make([]string, len(positions))
+	blockNumbers := make([]string, len(positions))
+	transactionIndexes := make([]string, len(positions))
+	logIndexes := make([]string, len(positions))
+	for i, position := range positions {
+		publicKeys[i] = position.PublicKey
+		tokenContractAddresses[i] = position.TokenContractAddress
+		blockNumbers[i] = strconv.FormatUint(position.BlockNumber, 10)
+		transactionIndexes[i] = strconv.FormatUint(uint64(position.TransactionIndex), 10)
+		logIndexes[i] = strconv.FormatInt(int64(position.LogIndex), 10)
+	}
+	positionsSQL := `unnest(?::text[], ?::text[], ?::bigint[], ?::bigint[], ?::bigint[])
+		AS p(public_key, token_contract_address, block_number, transaction_index, log_index)`
+	positionsValues := []interface{}{
+		postgresArrayLiteral(publicKeys),
+		postgresArrayLiteral(tokenContractAddresses),
+		postgresArrayLiteral(blockNumbers),
+		postgresArrayLiteral(transactionIndexes),
+		postgresArrayLiteral(logIndexes),
+	}
+
+	// Latest entry before each position
+	previousTokenBalances := &[]models.TokenBalance{}
+	err := db.Raw(
+		`SELECT b.* FROM `+positionsSQL+`
+		JOIN LATERAL (
+			SELECT * FROM token_balances
+			WHERE token_balances.public_key = p.public_key
+			AND token_balances.token_contract_address = p.token_contract_address
+			AND (token_balances.block_number, token_balances.transaction_index, token_balances.log_index)
+				< (p.block_number, p.transaction_index, p.log_index)
+			ORDER BY token_balances.block_number DESC, token_balances.transaction_index DESC, token_balances.log_index DESC
+			LIMIT 1
+		) AS b ON true`,
+		positionsValues...,
+	).Scan(previousTokenBalances).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Entries at or after each position
+	laterTokenBalances := &[]models.TokenBalance{}
+	err = db.Raw(
+		`SELECT token_balances.* FROM token_balances
+		JOIN `+positionsSQL+`
+		ON token_balances.public_key = p.public_key
+		AND token_balances.token_contract_address = p.token_contract_address
+		AND (token_balances.block_number, token_balances.transaction_index, token_balances.log_index)
+			>= (p.block_number, p.transaction_index, p.log_index)
+		ORDER BY token_balances.block_number ASC, token_balances.transaction_index ASC, token_balances.log_index ASC`,
+		positionsValues...,
+	).Scan(laterTokenBalances).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return previousTokenBalances, laterTokenBalances, nil
+}
+
+// SelectOne - select token balance entry by primary key
+func (m *TokenBalanceModel) SelectOne(
+	publicKey string,
+	blockNumber uint64,
+	transactionIndex uint32,
+	logIndex int32,
+) (*models.TokenBalance, error) {
+	db := m.db
+
+	// publicKey
+	db = db.Where("public_key = ?", publicKey)
+
+	// Block number
+	db = db.Where("block_number = ?", blockNumber)
+
+	// Transaction index
+	db = db.Where("transaction_index = ?", transactionIndex)
+
+	// Log index
+	db = db.Where("log_index = ?", logIndex)
+
+	tokenBalance := &models.TokenBalance{}
+	db = db.First(tokenBalance)
+
+	return tokenBalance, db.Error
+}
+
+// SelectManyAPIByPublicKey - select latest balance of every token held by a public key
+// Tokens seen in address_tokens without ledger entries have a 0x0 balance
+func (m *TokenBalanceModel) SelectManyAPIByPublicKey(
+	publicKey string,
+) (*[]models.AddressTokenAPIList, error) {
+	db := m.db
+
+	// Set table
+	db = db.Table("address_tokens")
+
+	// Select fields
+	db = db.Select(
+		"address_tokens.token_contract_address," +
+			"COALESCE(contract_processeds.name, '') AS token_name," +
+			"address_tokens.balance," +
+			"address_tokens.balance_decimal," +
+			"address_tokens.last_updated_block_number",
+	)
+
+	// Token name
+	db = db.Joins("LEFT JOIN contract_processeds ON contract_processeds.address = address_tokens.token_contract_address")
+
+	// publicKey
+	db = db.Where("address_tokens.public_key = ?", publicKey)
+
+	// Order
+	db = db.Order("address_tokens.token_contract_address")
+
+	addressTokens := &[]models.AddressTokenAPIList{}
+	db = db.Find(addressTokens)
+
+	return addressTokens, db.Error
+}
+
+// SelectManyHoldersAPI - select holders of a token with their latest balance
+// Holders are addresses with a positive latest balance, sorted by token balance
+func (m *TokenBalanceModel) SelectManyHoldersAPI(
+	limit int,
+	skip int,
+	tokenContractAddress string,
+) (*[]models.TokenHolderAPIList, error) {
+	db := m.db
+
+	// Set table
+	db = db.Table("address_tokens")
+
+	// Select fields
+	db = db.Select("public_key, balance, balance_decimal, last_updated_block_number")
+
+	// Token contract address
+	db = db.Where("token_contract_address = ?", tokenContractAddress)
+
+	// Current holders
+	db = db.Where("balance_decimal > 0")
+
+	// Order by balance
+	db = db.Order("balance_decimal DESC, public_key ASC")
+
+	// Limit
+	db = db.Limit(limit)
+
+	// Skip
+	if skip != 0 {
+		db = db.Offset(skip)
+	}
+
+	tokenHolders := &[]models.TokenHolderAPIList{}
+	db = db.Find(tokenHolders)
+
+	return tokenHolders, db.Error
+}
+
+func (m *TokenBalanceModel) UpsertOne(
+	tokenBalance *models.TokenBalance,
+) error {
+	db := m.db
+
+	// map[string]interface{}
+	updateOnConflictValues := extractAllFieldsFromModel(
+		reflect.ValueOf(*tokenBalance),
+		reflect.TypeOf(*tokenBalance),
+	)
+
+	// Upsert
+	db = db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "block_number"},
+			{Name: "transaction_index"},
+			{Name: "log_index"},
+			{Name: "public_key"},
+		}, // NOTE set to primary keys for table
+		DoUpdates: clause.Assignments(updateOnConflictValues),
+	}).Create(tokenBalance)
+
+	return db.Error
+}
+
+// StartTokenBalanceLoader starts loader
+// NOTE entries are loaded with value_change set, value is computed from the previous entry
+// NOTE logs are not guaranteed to arrive in order, later entries are recomputed in the same write
+func StartTokenBalanceLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"token_balance",
+		GetTokenBalanceModel().db,
+		[]string{"block_number", "transaction_index", "log_index", "public_key"}, // NOTE set to primary keys for table
+		false,
+	)
+
+	loader.enrichBatch = func(rows []interface{}) []interface{} {
+		newTokenBalances := make([]*models.TokenBalance, len(rows))
+		for i, row := range rows {
+			newTokenBalances[i] = row.(*models.TokenBalance)
+		}
+
+		// Earliest new entry of each public key and token
+		positions := []*models.TokenBalance{}
+		positionIndexes := map[[2]string]int{}
+		for _, newTokenBalance := range newTokenBalances {
+			key := [2]string{newTokenBalance.PublicKey, newTokenBalance.TokenContractAddress}
+
+			i, ok := positionIndexes[key]
+			if ok == false {
+				positionIndexes[key] = len(positions)
+				positions = append(positions, newTokenBalance)
+			} else if compareTokenBalancePositions(newTokenBalance, positions[i]) < 0 {
+				positions[i] = newTokenBalance
+			}
+		}
+
+		previousTokenBalances, laterTokenBalances, err := GetTokenBalanceModel().SelectManyFromPositions(positions)
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal("Loader=TokenBalance - Error: ", err.Error())
+		}
+
+		tokenBalances := computeTokenBalances(newTokenBalances, previousTokenBalances, laterTokenBalances)
+
+		// Latest balances of holders
+		// NOTE written before the ledger, replayed messages rewrite both
+		err = GetAddressTokenModel().UpsertManyBalances(getLatestAddressTokenBalances(tokenBalances))
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal("Loader=TokenBalance - Error: ", err.Error())
+		}
+
+		rows = make([]interface{}, len(tokenBalances))
+		for i, tokenBalance := range tokenBalances {
+			rows[i] = tokenBalance
+		}
+		return rows
+	}
+
+	loader.start(ctx, GetTokenBalanceModel().LoaderChannel)
+}
+
+// computeTokenBalances - apply the value changes of new entries in ledger order
+// Returns the new entries and the stored entries after them with recomputed values
+// NOTE new entries that are already stored are replays, they are not applied twice
+// NOTE new entries of another block at a stored position are from a reorg, they replace the stored entry
+func computeTokenBalances(
+	newTokenBalances []*models.TokenBalance,
+	previousTokenBalances *[]models.TokenBalance,
+	laterTokenBalances *[]models.TokenBalance,
+) []*models.TokenBalance {
+
+	// Ledger by public key and token
+	ledgers := map[[2]string][]*models.TokenBalance{}
+	ledgerKeys := [][2]string{}
+	storedIndexes := map[string]int{}
+	for i := range *laterTokenBalances {
+		tokenBalance := &(*laterTokenBalances)[i]
+		key := [2]string{tokenBalance.PublicKey, tokenBalance.TokenContractAddress}
+
+		if _, ok := ledgers[key]; ok == false {
+			ledgerKeys = append(ledgerKeys, key)
+		}
+		storedIndexes[tokenBalancePositionString(tokenBalance)] = len(ledgers[key])
+		ledgers[key] = append(ledgers[key], tokenBalance)
+	}
+	for _, newTokenBalance := range newTokenBalances {
+		key := [2]string{newTokenBalance.PublicKey, newTokenBalance.TokenContractAddress}
+
+		i, isStored := storedIndexes[tokenBalancePositionString(newTokenBalance)]
+		if isStored == true && ledgers[key][i].BlockHash != newTokenBalance.BlockHash {
+			// Reorg
+			ledgers[key][i] = newTokenBalance
+			continue
+		}
+		if isStored == true {
+			zap.S().Debug(
+				"Loader=TokenBalance,",
+				"BlockNumber=", newTokenBalance.BlockNumber,
+				"TransactionIndex=", newTokenBalance.TransactionIndex,
+				"LogIndex=", newTokenBalance.LogIndex,
+				"PublicKey=", newTokenBalance.PublicKey,
+				" - Already loaded",
+			)
+			continue
+		}
+
+		if _, ok := ledgers[key]; ok == false {
+			ledgerKeys = append(ledgerKeys, key)
+		}
+		ledgers[key] = append(ledgers[key], newTokenBalance)
+	}
+
+	// Previous values
+	curValues := map[[2]string]string{}
+	for i := range *previousTokenBalances {
+		tokenBalance := &(*previousTokenBalances)[i]
+
+		curValues[[2]string{tokenBalance.PublicKey, tokenBalance.TokenContractAddress}] = tokenBalance.Value
+	}
+
+	tokenBalances := []*models.TokenBalance{}
+	for _, key := range ledgerKeys {
+		ledger := ledgers[key]
+		sort.SliceStable(ledger, func(i, j int) bool {
+			return compareTokenBalancePositions(ledger[i], ledger[j]) < 0
+		})
+
+		curValue, ok := curValues[key]
+		if ok == false {
+			curValue = "0x0"
+		}
+		for _, tokenBalance := range ledger {
+			err := applyTokenBalanceValueChange(tokenBalance, curValue)
+			if err != nil {
+				zap.S().Warn(
+					"Loader=TokenBalance,",
+					"ValueChange=", tokenBalance.ValueChange,
+					" - Error: ", err.Error(),
+				)
+				continue
+			}
+
+			tokenBalances = append(tokenBalances, tokenBalance)
+			curValue = tokenBalance.Value
+		}
+	}
+
+	return tokenBalances
+}
+
+// getLatestAddressTokenBalances - latest balance of each public key and token in ledger entries
+func getLatestAddressTokenBalances(tokenBalances []*models.TokenBalance) []*models.AddressToken {
+	addressTokens := []*models.AddressToken{}
+	addressTokenIndexes := map[[2]string]int{}
+	latestTokenBalances := []*models.TokenBalance{}
+
+	for _, tokenBalance := range tokenBalances {
+		key := [2]string{tokenBalance.PublicKey, tokenBalance.TokenContractAddress}
+
+		i, ok := addressTokenIndexes[key]
+		if ok == false {
+			addressTokenIndexes[key] = len(latestTokenBalances)
+			latestTokenBalances = append(latestTokenBalances, tokenBalance)
+		} else if compareTokenBalancePositions(tokenBalance, latestTokenBalances[i]) > 0 {
+			latestTokenBalances[i] = tokenBalance
+		}
+	}
+
+	for _, tokenBalance := range latestTokenBalances {
+		addressTokens = append(addressTokens, &models.AddressToken{
+			PublicKey:              tokenBalance.PublicKey,
+			TokenContractAddress:   tokenBalance.TokenContractAddress,
+			Balance:                tokenBalance.Value,
+			BalanceDecimal:         tokenBalance.ValueDecimal,
+			LastUpdatedBlockNumber: tokenBalance.BlockNumber,
+		})
+	}
+
+	return addressTokens
+}
+
+// compareTokenBalancePositions - order of two entries in a ledger, -1, 0 or 1
+func compareTokenBalancePositions(a *models.TokenBalance, b *models.TokenBalance) int {
+	switch {
+	case a.BlockNumber != b.BlockNumber:
+		if a.BlockNumber < b.BlockNumber {
+			return -1
+		}
+		return 1
+	case a.TransactionIndex != b.TransactionIndex:
+		if a.TransactionIndex < b.TransactionIndex {
+			return -1
+		}
+		return 1
+	case a.LogIndex != b.LogIndex:
+		if a.LogIndex < b.LogIndex {
+			return -1
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// tokenBalancePositionString - primary key of an entry
+func tokenBalancePositionString(tokenBalance *models.TokenBalance) string {
+	return fmt.Sprintf(
+		"%d|%d|%d|%s",
+		tokenBalance.BlockNumber,
+		tokenBalance.TransactionIndex,
+		tokenBalance.LogIndex,
+		tokenBalance.PublicKey,
+	)
+}
+
+// applyTokenBalanceValueChange - set value and value_decimal from the previous value and the value change
+func applyTokenBalanceValueChange(tokenBalance *models.TokenBalance, curValue string) error {
+
+	// Hex -> big.Int
+	// NOTE value_change is signed, -0x... for senders
+	curValueBigInt, ok := new(big.Int).SetString(curValue, 0)
+	if ok == false {
+		return errors.New("Invalid token balance value: " + curValue)
+	}
+	valueChangeBigInt, ok := new(big.Int).SetString(tokenBalance.ValueChange, 0)
+	if ok == false {
+		return errors.New("Invalid token balance value change: " + tokenBalance.ValueChange)
+	}
+
+	newValueBigInt := new(big.Int).Add(curValueBigInt, valueChangeBigInt)
+	tokenBalance.Value = fmt.Sprintf("%#x", newValueBigInt)
+
+	// Value -> ValueDecimal
+	baseBigInt := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(tokenBalance.TokenDecimals)), nil)
+	newValueBigFloat := new(big.Float).SetInt(newValueBigInt)
+
+	// newValue / 10^decimals
+	newValueBigFloat = newValueBigFloat.Quo(newValueBigFloat, new(big.Float).SetInt(baseBigInt))
+
+	tokenBalance.ValueDecimal, _ = newValueBigFloat.Float64()
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package crud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestComputeTokenBalances(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey := "hx0000000000000000000000000000000000000001"
+	tokenA := "cx000000000000000000000000000000000000000a"
+	tokenB := "cx000000000000000000000000000000000000000b"
+
+	previousTokenBalances := &[]models.TokenBalance{
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 1, Value: "0x10", ValueChange: "0x10"},
+	}
+	laterTokenBalances := &[]models.TokenBalance{
+		// Stored after the new entries, recomputed
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 5, Value: "0xf", ValueChange: "-0x1"},
+		// Replayed
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 4, Value: "0x10", ValueChange: "0x0"},
+		// Replaced by a reorg
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 7, BlockHash: "0xa", Value: "0x10", ValueChange: "0x1"},
+	}
+	newTokenBalances := []*models.TokenBalance{
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 3, TransactionIndex: 1, ValueChange: "0x2"},
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 3, TransactionIndex: 0, ValueChange: "0x1"},
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 4, ValueChange: "0x0"},
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 7, BlockHash: "0xb", ValueChange: "0x3"},
+		{PublicKey: publicKey, TokenContractAddress: tokenB, BlockNumber: 2, ValueChange: "0x5", TokenDecimals: 1},
+		{PublicKey: publicKey, TokenContractAddress: tokenB, BlockNumber: 6, ValueChange: "bad"},
+	}
+
+	tokenBalances := computeTokenBalances(newTokenBalances, previousTokenBalances, laterTokenBalances)
+
+	values := map[string]string{}
+	for _, tokenBalance := range tokenBalances {
+		values[tokenBalancePositionString(tokenBalance)+tokenBalance.TokenContractAddress] = tokenBalance.Value
+	}
+	assert.Equal(map[string]string{
+		"3|0|0|" + publicKey + tokenA: "0x11",
+		"3|1|0|" + publicKey + tokenA: "0x13",
+		"4|0|0|" + publicKey + tokenA: "0x13",
+		"5|0|0|" + publicKey + tokenA: "0x12",
+		"7|0|0|" + publicKey + tokenA: "0x15",
+		"2|0|0|" + publicKey + tokenB: "0x5",
+	}, values)
+
+	// Decimals
+	for _, tokenBalance := range tokenBalances {
+		if tokenBalance.TokenContractAddress == tokenB {
+			assert.Equal(0.5, tokenBalance.ValueDecimal)
+		}
+	}
+}
+
+func TestGetLatestAddressTokenBalances(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey := "hx0000000000000000000000000000000000000001"
+	tokenA := "cx000000000000000000000000000000000000000a"
+	tokenB := "cx000000000000000000000000000000000000000b"
+
+	tokenBalances := []*models.TokenBalance{
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 3, TransactionIndex: 1, Value: "0x3", ValueDecimal: 3},
+		{PublicKey: publicKey, TokenContractAddress: tokenA, BlockNumber: 3, TransactionIndex: 0, Value: "0x1", ValueDecimal: 1},
+		{PublicKey: publicKey, TokenContractAddress: tokenB, BlockNumber: 2, Value: "0x5", ValueDecimal: 5},
+		{PublicKey: publicKey, TokenContractAddress: tokenB, BlockNumber: 6, Value: "0x0", ValueDecimal: 0},
+	}
+
+	addressTokens := getLatestAddressTokenBalances(tokenBalances)
+	assert.Equal(2, len(addressTokens))
+
+	assert.Equal(tokenA, addressTokens[0].TokenContractAddress)
+	assert.Equal("0x3", addressTokens[0].Balance)
+	assert.Equal(float64(3), addressTokens[0].BalanceDecimal)
+	assert.Equal(uint64(3), addressTokens[0].LastUpdatedBlockNumber)
+
+	// Former holder, kept with a zero balance and filtered from the holders
+	assert.Equal(tokenB, addressTokens[1].TokenContractAddress)
+	assert.Equal("0x0", addressTokens[1].Balance)
+	assert.Equal(float64(0), addressTokens[1].BalanceDecimal)
+	assert.Equal(uint64(6), addressTokens[1].LastUpdatedBlockNumber)
+}
@@ -1,6 +1,7 @@
 package crud
 
 import (
+	"context"
 	"reflect"
 	"sync"
 
@@ -8,6 +9,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
 )
 
@@ -41,7 +43,7 @@ func GetTransactionModel() *TransactionModel {
 			zap.S().Fatal("TransactionModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartTransactionLoader()
+		StartTransactionLoader(global.ShutdownContext())
 	})
 
 	return transactionModel
@@ -50,13 +52,15 @@ func GetTransactionModel() *TransactionModel {
 // Migrate - migrate transactions table
 func (m *TransactionModel) Migrate() error {
 	// Only using TransactionRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
-// SelectManyByBlockNumber - select many by block number
+// SelectManyByBlockNumber - select many by block number and block hash
+// NOTE rows loaded before block hashes were stored have an empty block hash
 func (m *TransactionModel) SelectManyByBlockNumber(
 	blockNumber uint64,
+	blockHash string,
 ) (*[]models.Transaction, error) {
 	db := m.db
 
@@ -69,6 +73,95 @@ func (m *TransactionModel) SelectManyByBlockNumber(
 	// Block Number
 	db = db.Where("block_number = ?", blockNumber)
 
+	// Block Hash
+	db = db.Where("block_hash IN ?", []string{blockHash, ""})
+
+	transactions := &[]models.Transaction{}
+	db = db.Find(transactions)
+
+	return transactions, db.Error
+}
+
+// SelectManyByBlockNumberRange - select transactions between two block numbers, inclusive
+func (m *TransactionModel) SelectManyByBlockNumberRange(
+	startBlockNumber uint64,
+	endBlockNumber uint64,
+) (*[]models.Transaction, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Transaction{})
+
+	// Order by block number, transaction index, log index
+	db = db.Order("block_number ASC, transaction_index ASC, log_index ASC")
+
+	// Block number
+	db = db.Where("block_number >= ?", startBlockNumber)
+	db = db.Where("block_number <= ?", endBlockNumber)
+
+	transactions := &[]models.Transaction{}
+	db = db.Find(transactions)
+
+	return transactions, db.Error
+}
+
+// SelectManyByPublicKeyAPI - select many by public key for the api
+// direction: "in", "out", or "both"
+// transactionType: "base" (log_index = -1), "internal" (log_index != -1), or "" for all
+// endBlockNumber: 0 for no upper bound
+func (m *TransactionModel) SelectManyByPublicKeyAPI(
+	limit int,
+	skip int,
+	publicKey string,
+	direction string,
+	transactionType string,
+	startBlockNumber uint64,
+	endBlockNumber uint64,
+) (*[]models.Transaction, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.Transaction{})
+
+	// Order by latest
+	db = db.Order("block_number DESC, transaction_index DESC, log_index DESC")
+
+	// Public key and direction
+	switch direction {
+	case "in":
+		db = db.Where("to_address = ?", publicKey)
+	case "out":
+		db = db.Where("from_address = ?", publicKey)
+	default:
+		db = db.Where("(from_address = ? OR to_address = ?)", publicKey, publicKey)
+	}
+
+	// Transaction type
+	switch transactionType {
+	case "base":
+		db = db.Where("log_index = ?", -1)
+	case "internal":
+		db = db.Where("log_index != ?", -1)
+	}
+
+	// Start block number
+	if startBlockNumber != 0 {
+		db = db.Where("block_number >= ?", startBlockNumber)
+	}
+
+	// End block number
+	if endBlockNumber != 0 {
+		db = db.Where("block_number <= ?", endBlockNumber)
+	}
+
+	// Limit
+	db = db.Limit(limit)
+
+	// Skip
+	if skip != 0 {
+		db = db.Offset(skip)
+	}
+
 	transactions := &[]models.Transaction{}
 	db = db.Find(transactions)
 
@@ -116,23 +209,13 @@ func (m *TransactionModel) UpsertOne(
 }
 
 // StartTransactionLoader starts loader
-func StartTransactionLoader() {
-	go func() {
-		postgresLoaderChan := GetTransactionModel().LoaderChannel
-
-		for {
-			// Read transaction
-			newTransaction := <-postgresLoaderChan
-
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
-			err := GetTransactionModel().UpsertOne(newTransaction)
-			zap.S().Debug("Loader=Transaction, Hash=", newTransaction.Hash, " LogIndex=", newTransaction.LogIndex, " - Upserted")
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal("Loader=Transaction, Hash=", newTransaction.Hash, " LogIndex=", newTransaction.LogIndex, " - Error: ", err.Error())
-			}
-		}
-	}()
+func StartTransactionLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"transaction",
+		GetTransactionModel().db,
+		[]string{"hash", "log_index"}, // NOTE set to primary keys for table
+		true,
+	)
+
+	loader.start(ctx, GetTransactionModel().LoaderChannel)
 }
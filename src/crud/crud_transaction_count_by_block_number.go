@@ -1,12 +1,14 @@
 package crud
 
 import (
+	"context"
 	"errors"
 	"sync"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
 )
 
@@ -40,7 +42,7 @@ func GetTransactionCountByBlockNumberModel() *TransactionCountByBlockNumberModel
 			zap.S().Fatal("TransactionCountByBlockNumberModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartTransactionCountByBlockNumberLoader()
+		StartTransactionCountByBlockNumberLoader(global.ShutdownContext())
 	})
 
 	return transactionCountByBlockNumberModel
@@ -49,7 +51,7 @@ func GetTransactionCountByBlockNumberModel() *TransactionCountByBlockNumberModel
 // Migrate - migrate transactionCountByBlockNumbers table
 func (m *TransactionCountByBlockNumberModel) Migrate() error {
 	// Only using TransactionCountByBlockNumberRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -66,7 +68,7 @@ func (m *TransactionCountByBlockNumberModel) Insert(transactionCountByBlockNumbe
 }
 
 // Select - select from transactionCountByBlockNumbers table
-func (m *TransactionCountByBlockNumberModel) SelectOne(transactionHash string) (models.TransactionCountByBlockNumber, error) {
+func (m *TransactionCountByBlockNumberModel) SelectOne(transactionHash string, blockHash string) (models.TransactionCountByBlockNumber, error) {
 	db := m.db
 
 	// Set table
@@ -75,13 +77,19 @@ func (m *TransactionCountByBlockNumberModel) SelectOne(transactionHash string) (
 	// Transaction Hash
 	db = db.Where("transaction_hash = ?", transactionHash)
 
+	// Block Hash
+	db = db.Where("block_hash = ?", blockHash)
+
 	transactionCountByBlockNumber := models.TransactionCountByBlockNumber{}
 	db = db.First(&transactionCountByBlockNumber)
 
 	return transactionCountByBlockNumber, db.Error
 }
 
-func (m *TransactionCountByBlockNumberModel) SelectLargestCountByBlockNumber(blockNumber uint64) (uint64, error) {
+// SelectLargestCountByBlockNumber - count of the rows loaded for a block
+// NOTE counted by block hash, rows of another block at the same number are from a reorg
+// NOTE rows loaded before block hashes were stored have an empty block hash
+func (m *TransactionCountByBlockNumberModel) SelectLargestCountByBlockNumber(blockNumber uint64, blockHash string) (uint64, error) {
 	db := m.db
 
 	// Set table
@@ -90,6 +98,9 @@ func (m *TransactionCountByBlockNumberModel) SelectLargestCountByBlockNumber(blo
 	// Block Number
 	db = db.Where("block_number = ?", blockNumber)
 
+	// Block Hash
+	db = db.Where("block_hash IN ?", []string{blockHash, ""})
+
 	// Get max id
 	count := uint64(0)
 	row := db.Select("max(count)").Row()
@@ -99,21 +110,38 @@ func (m *TransactionCountByBlockNumberModel) SelectLargestCountByBlockNumber(blo
 }
 
 // StartTransactionCountByBlockNumberLoader starts loader
-func StartTransactionCountByBlockNumberLoader() {
+// NOTE not batched, counts are only incremented for rows not yet loaded
+func StartTransactionCountByBlockNumberLoader(ctx context.Context) {
+	shutdownTaskDone := global.AddShutdownTask()
+
 	go func() {
+		defer shutdownTaskDone()
 
 		for {
 			// Read transactionCountByBlockNumber
-			newTransactionCountByBlockNumber := <-GetTransactionCountByBlockNumberModel().LoaderChannel
+			// NOTE on shutdown the channel is drained before returning
+			var newTransactionCountByBlockNumber *models.TransactionCountByBlockNumber
+			select {
+			case newTransactionCountByBlockNumber = <-GetTransactionCountByBlockNumberModel().LoaderChannel:
+			case <-ctx.Done():
+				select {
+				case newTransactionCountByBlockNumber = <-GetTransactionCountByBlockNumberModel().LoaderChannel:
+				default:
+					zap.S().Info("Loader=TransactionCountByBlockNumber - Drained, stopped")
+					return
+				}
+			}
 
 			// Insert
 			_, err := GetTransactionCountByBlockNumberModel().SelectOne(
 				newTransactionCountByBlockNumber.TransactionHash,
+				newTransactionCountByBlockNumber.BlockHash,
 			)
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				// Last count
 				lastCount, err := GetTransactionCountByBlockNumberModel().SelectLargestCountByBlockNumber(
 					newTransactionCountByBlockNumber.BlockNumber,
+					newTransactionCountByBlockNumber.BlockHash,
 				)
 				if err != nil {
 					zap.S().Fatal(err.Error())
@@ -131,6 +159,8 @@ func StartTransactionCountByBlockNumberLoader() {
 				// Error
 				zap.S().Fatal(err.Error())
 			}
+
+			ackLoaded(newTransactionCountByBlockNumber)
 		}
 	}()
 }
@@ -1,7 +1,7 @@
 package crud
 
 import (
-	"errors"
+	"context"
 	"reflect"
 	"sync"
 
@@ -9,8 +9,8 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
-	"github.com/geometry-labs/icon-addresses/redis"
 )
 
 // TransactionCountByPublicKeyModel - type for address table model
@@ -43,7 +43,7 @@ func GetTransactionCountByPublicKeyModel() *TransactionCountByPublicKeyModel {
 			zap.S().Fatal("TransactionCountByPublicKeyModel: Unable migrate postgres table: ", err.Error())
 		}
 
-		StartTransactionCountByPublicKeyLoader()
+		StartTransactionCountByPublicKeyLoader(global.ShutdownContext())
 	})
 
 	return transactionCountByPublicKeyModel
@@ -52,7 +52,7 @@ func GetTransactionCountByPublicKeyModel() *TransactionCountByPublicKeyModel {
 // Migrate - migrate transactionCountByPublicKeys table
 func (m *TransactionCountByPublicKeyModel) Migrate() error {
 	// Only using TransactionCountByPublicKeyRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -118,6 +118,25 @@ func (m *TransactionCountByPublicKeyModel) SelectCount(publicKey string) (uint64
 	return count, db.Error
 }
 
+// SelectManyByPublicKeys - select many transaction counts by public key
+// Public keys without a row are left out
+func (m *TransactionCountByPublicKeyModel) SelectManyByPublicKeys(
+	publicKeys []string,
+) (*[]models.TransactionCountByPublicKey, error) {
+	db := m.db
+
+	// Set table
+	db = db.Model(&models.TransactionCountByPublicKey{})
+
+	// Public Keys
+	db = db.Where("public_key IN ?", publicKeys)
+
+	transactionCountByPublicKeys := &[]models.TransactionCountByPublicKey{}
+	db = db.Find(transactionCountByPublicKeys)
+
+	return transactionCountByPublicKeys, db.Error
+}
+
 func (m *TransactionCountByPublicKeyModel) UpsertOne(
 	transactionCountByPublicKey *models.TransactionCountByPublicKey,
 ) error {
@@ -139,77 +158,116 @@ func (m *TransactionCountByPublicKeyModel) UpsertOne(
 }
 
 // StartTransactionCountByPublicKeyLoader starts loader
-func StartTransactionCountByPublicKeyLoader() {
-	go func() {
-		postgresLoaderChan := GetTransactionCountByPublicKeyModel().LoaderChannel
+// NOTE counts are only incremented for transactions not yet in the index table
+func StartTransactionCountByPublicKeyLoader(ctx context.Context) {
+	loader := newBatchLoader(
+		"transaction_count_by_public_key",
+		GetTransactionCountByPublicKeyModel().db,
+		[]string{"transaction_hash", "public_key"}, // NOTE set to primary keys for the index table
+		true,
+	)
+	loader.conflictKeys = []string{"public_key"} // NOTE set to primary keys for table
 
-		for {
-			// Read transaction
-			newTransactionCountByPublicKey := <-postgresLoaderChan
+	loader.enrichBatch = func(rows []interface{}) []interface{} {
+		newTransactionCountByPublicKeys := make([]*models.TransactionCountByPublicKey, len(rows))
+		for i, row := range rows {
+			newTransactionCountByPublicKeys[i] = row.(*models.TransactionCountByPublicKey)
+		}
 
-			//////////////////////////
-			// Get count from redis //
-			//////////////////////////
-			countKey := "icon_addresses_transaction_count_by_address_" + newTransactionCountByPublicKey.PublicKey
+		transactionCountByPublicKeys, err := countNewTransactionsByPublicKey(newTransactionCountByPublicKeys)
+		if err != nil {
+			// Postgres or redis error
+			zap.S().Fatal("Loader=TransactionCountByPublicKey - Error: ", err.Error())
+		}
 
-			count, err := redis.GetRedisClient().GetCount(countKey)
-			if err != nil {
-				zap.S().Fatal("Loader=Transaction, Hash=", newTransactionCountByPublicKey.TransactionHash, " PublicKey=", newTransactionCountByPublicKey.PublicKey, " - Error: ", err.Error())
-			}
+		rows = make([]interface{}, len(transactionCountByPublicKeys))
+		for i, transactionCountByPublicKey := range transactionCountByPublicKeys {
+			rows[i] = transactionCountByPublicKey
+		}
+		return rows
+	}
 
-			// No count set yet
-			// Get from database
-			if count == -1 {
-				curTransactionCountByPublicKey, err := GetTransactionCountByPublicKeyModel().SelectOne(newTransactionCountByPublicKey.PublicKey)
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					count = 0
-				} else if err != nil {
-					zap.S().Fatal(
-						"Loader=Transaction,",
-						"Hash=", newTransactionCountByPublicKey.TransactionHash,
-						" PublicKey=", newTransactionCountByPublicKey.PublicKey,
-						" - Error: ", err.Error())
-				} else {
-					count = int64(curTransactionCountByPublicKey.Count)
-				}
-
-				// Set count
-				err = redis.GetRedisClient().SetCount(countKey, int64(count))
-				if err != nil {
-					// Redis error
-					zap.S().Fatal("Loader=Transaction, Hash=", newTransactionCountByPublicKey.TransactionHash, " PublicKey=", newTransactionCountByPublicKey.PublicKey, " - Error: ", err.Error())
-				}
-			}
+	loader.start(ctx, GetTransactionCountByPublicKeyModel().LoaderChannel)
+}
 
-			//////////////////////
-			// Load to postgres //
-			//////////////////////
+// countNewTransactionsByPublicKey - insert transactions into the index table and increment the counts of their public keys
+// Returns a count row for each public key with new transactions, set to the last new transaction
+func countNewTransactionsByPublicKey(newTransactionCountByPublicKeys []*models.TransactionCountByPublicKey) ([]*models.TransactionCountByPublicKey, error) {
+
+	/////////////////////////
+	// Load to index table //
+	/////////////////////////
+	transactionCountByPublicKeyIndexes := make([]*models.TransactionCountByPublicKeyIndex, len(newTransactionCountByPublicKeys))
+	for i, newTransactionCountByPublicKey := range newTransactionCountByPublicKeys {
+		transactionCountByPublicKeyIndexes[i] = &models.TransactionCountByPublicKeyIndex{
+			TransactionHash: newTransactionCountByPublicKey.TransactionHash,
+			PublicKey:       newTransactionCountByPublicKey.PublicKey,
+		}
+	}
 
-			// Add transaction to indexed
-			newTransactionCountByPublicKeyIndex := &models.TransactionCountByPublicKeyIndex{
-				TransactionHash: newTransactionCountByPublicKey.TransactionHash,
-				PublicKey:       newTransactionCountByPublicKey.PublicKey,
-			}
-			err = GetTransactionCountByPublicKeyIndexModel().Insert(newTransactionCountByPublicKeyIndex)
-			if err != nil {
-				// Record already exists, continue
-				continue
-			}
+	insertedTransactionCountByPublicKeyIndexes, err := GetTransactionCountByPublicKeyIndexModel().InsertMany(transactionCountByPublicKeyIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	inserted := map[[2]string]bool{}
+	for _, insertedTransactionCountByPublicKeyIndex := range *insertedTransactionCountByPublicKeyIndexes {
+		inserted[[2]string{
+			insertedTransactionCountByPublicKeyIndex.TransactionHash,
+			insertedTransactionCountByPublicKeyIndex.PublicKey,
+		}] = true
+	}
+
+	increments := map[string]int64{}
+	lastTransactionHashes := map[string]string{}
+	publicKeys := []string{}
+	for _, newTransactionCountByPublicKey := range newTransactionCountByPublicKeys {
+		if inserted[[2]string{
+			newTransactionCountByPublicKey.TransactionHash,
+			newTransactionCountByPublicKey.PublicKey,
+		}] == false {
+			// Already counted
+			continue
+		}
 
-			// Increment records
-			count, err = redis.GetRedisClient().IncCount(countKey)
+		if _, ok := increments[newTransactionCountByPublicKey.PublicKey]; ok == false {
+			publicKeys = append(publicKeys, newTransactionCountByPublicKey.PublicKey)
+		}
+		increments[newTransactionCountByPublicKey.PublicKey]++
+		lastTransactionHashes[newTransactionCountByPublicKey.PublicKey] = newTransactionCountByPublicKey.TransactionHash
+	}
+
+	////////////////////////////
+	// Increment redis counts //
+	////////////////////////////
+	counts, err := incrementRedisCounts(
+		"icon_addresses_transaction_count_by_address_",
+		increments,
+		func(publicKeys []string) (map[string]uint64, error) {
+			transactionCountByPublicKeys, err := GetTransactionCountByPublicKeyModel().SelectManyByPublicKeys(publicKeys)
 			if err != nil {
-				// Redis error
-				zap.S().Fatal("Loader=Transaction, Hash=", newTransactionCountByPublicKey.TransactionHash, " PublicKey=", newTransactionCountByPublicKey.PublicKey, " - Error: ", err.Error())
+				return nil, err
 			}
-			newTransactionCountByPublicKey.Count = uint64(count)
 
-			err = GetTransactionCountByPublicKeyModel().UpsertOne(newTransactionCountByPublicKey)
-			zap.S().Debug("Loader=Transaction, Hash=", newTransactionCountByPublicKey.TransactionHash, " PublicKey=", newTransactionCountByPublicKey.PublicKey, " - Upserted")
-			if err != nil {
-				// Postgres error
-				zap.S().Fatal("Loader=Transaction, Hash=", newTransactionCountByPublicKey.TransactionHash, " PublicKey=", newTransactionCountByPublicKey.PublicKey, " - Error: ", err.Error())
+			storedCounts := map[string]uint64{}
+			for _, transactionCountByPublicKey := range *transactionCountByPublicKeys {
+				storedCounts[transactionCountByPublicKey.PublicKey] = transactionCountByPublicKey.Count
 			}
-		}
-	}()
+			return storedCounts, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionCountByPublicKeys := []*models.TransactionCountByPublicKey{}
+	for _, publicKey := range publicKeys {
+		transactionCountByPublicKeys = append(transactionCountByPublicKeys, &models.TransactionCountByPublicKey{
+			PublicKey:       publicKey,
+			TransactionHash: lastTransactionHashes[publicKey],
+			Count:           counts[publicKey],
+		})
+	}
+
+	return transactionCountByPublicKeys, nil
 }
@@ -46,7 +46,7 @@ func GetTransactionCountByPublicKeyIndexModel() *TransactionCountByPublicKeyInde
 // Migrate - migrate transactionCountByPublicKeyIndexs table
 func (m *TransactionCountByPublicKeyIndexModel) Migrate() error {
 	// Only using TransactionCountByPublicKeyIndexRawORM (ORM version of the proto generated struct) to create the TABLE
-	err := m.db.AutoMigrate(m.modelORM) // Migration and Index creation
+	err := autoMigrate(m.db, m.modelORM) // Migration and Index creation
 	return err
 }
 
@@ -79,3 +79,26 @@ func (m *TransactionCountByPublicKeyIndexModel) Insert(transactionCountByPublicK
 
 	return db.Error
 }
+
+// InsertMany - insert indexes not yet in the table
+// Returns the inserted rows, rows already in the table are left out
+func (m *TransactionCountByPublicKeyIndexModel) InsertMany(transactionCountByPublicKeyIndexes []*models.TransactionCountByPublicKeyIndex) (*[]models.TransactionCountByPublicKeyIndex, error) {
+	transactionHashes := make([]string, len(transactionCountByPublicKeyIndexes))
+	publicKeys := make([]string, len(transactionCountByPublicKeyIndexes))
+	for i, row := range transactionCountByPublicKeyIndexes {
+		transactionHashes[i] = row.TransactionHash
+		publicKeys[i] = row.PublicKey
+	}
+
+	insertedTransactionCountByPublicKeyIndexes := &[]models.TransactionCountByPublicKeyIndex{}
+	db := m.db.Raw(
+		`INSERT INTO transaction_count_by_public_key_indices (transaction_hash, public_key)
+		SELECT * FROM unnest(?::text[], ?::text[])
+		ON CONFLICT DO NOTHING
+		RETURNING transaction_hash, public_key`,
+		postgresArrayLiteral(transactionHashes),
+		postgresArrayLiteral(publicKeys),
+	).Scan(insertedTransactionCountByPublicKeyIndexes)
+
+	return insertedTransactionCountByPublicKeyIndexes, db.Error
+}
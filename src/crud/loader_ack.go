@@ -0,0 +1,49 @@
+package crud
+
+import "sync"
+
+// Loader acknowledgements
+// Item pointer -> callback run once the loader has persisted the item
+// NOTE used to commit kafka offsets only after the message's effects are in postgres
+var loaderAcks sync.Map
+
+// Items with a registered callback not yet persisted
+// NOTE rollbacks wait for none to be left with the transformers paused, see RollbackBlocks
+var loaderAcksPending = 0
+var loaderAcksPendingMutex sync.Mutex
+var loaderAcksPendingCond = sync.NewCond(&loaderAcksPendingMutex)
+
+// SetLoaderAck - register a callback for an item before sending it to a loader channel
+// NOTE items are new for every message, an item is registered once
+func SetLoaderAck(item interface{}, ack func()) {
+	loaderAcksPendingMutex.Lock()
+	loaderAcksPending++
+	loaderAcksPendingMutex.Unlock()
+
+	loaderAcks.Store(item, ack)
+}
+
+// ackLoaded - run the registered callback for an item, if any
+// NOTE must be called on every path where a loader is done with an item
+func ackLoaded(item interface{}) {
+	ack, ok := loaderAcks.LoadAndDelete(item)
+	if ok {
+		ack.(func())()
+
+		loaderAcksPendingMutex.Lock()
+		loaderAcksPending--
+		loaderAcksPendingCond.Broadcast()
+		loaderAcksPendingMutex.Unlock()
+	}
+}
+
+// waitLoaderAcks - wait until the loaders persisted every item sent with a callback
+// NOTE items sent while waiting are waited for too
+func waitLoaderAcks() {
+	loaderAcksPendingMutex.Lock()
+	defer loaderAcksPendingMutex.Unlock()
+
+	for loaderAcksPending > 0 {
+		loaderAcksPendingCond.Wait()
+	}
+}
@@ -0,0 +1,375 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/global"
+)
+
+// batchLoader - accumulates rows from a loader channel and bulk upserts them
+// NOTE only for loaders where rows do not depend on previously loaded rows
+type batchLoader struct {
+	name string
+	db   *gorm.DB
+
+	// Primary key columns
+	primaryKeys []string
+
+	// true: on conflict update filled fields only, like extractFilledFieldsFromModel
+	// false: on conflict update all fields, like extractAllFieldsFromModel
+	updateFilledFieldsOnly bool
+
+	batchSize     int
+	flushInterval time.Duration
+
+	// Optional, run on the de-duplicated rows before enrich, returns the rows to write
+	// NOTE for rows that depend on other rows, rows added are written but not acked
+	enrichBatch func(rows []interface{}) []interface{}
+
+	// Optional, run on each de-duplicated row before the write
+	enrich func(row interface{})
+
+	// Optional, run on each de-duplicated row after the update columns are set
+	// NOTE for values that are only inserted, never updated
+	insertDefaults func(row interface{})
+
+	// Optional, run on each de-duplicated row after the write
+	afterFlush func(row interface{})
+
+	// Optional, run on rows with the same primary keys in a batch after the filled fields are merged
+	// NOTE row is the later row, it replaces previousRow
+	merge func(row interface{}, previousRow interface{})
+
+	// Optional, on conflict update expressions by column, in place of the inserted value
+	updateExpressions map[string]clause.Expr
+
+	// Optional, on conflict columns when they differ from the primary keys rows are de-duplicated by
+	// NOTE for rows replaced in enrichBatch, e.g. counts of de-duplicated index rows
+	conflictKeys []string
+
+	// Optional, columns updated with a filled column even when not filled
+	// NOTE for zero values that are valid, e.g. block 0
+	coupledColumns map[string][]string
+}
+
+func newBatchLoader(name string, db *gorm.DB, primaryKeys []string, updateFilledFieldsOnly bool) *batchLoader {
+	batchSize, flushInterval := getBatchLoaderConfig(name)
+
+	return &batchLoader{
+		name:                   name,
+		db:                     db,
+		primaryKeys:            primaryKeys,
+		updateFilledFieldsOnly: updateFilledFieldsOnly,
+		batchSize:              batchSize,
+		flushInterval:          flushInterval,
+	}
+}
+
+// getBatchLoaderConfig - batch size and flush interval for a loader
+// LOADER_BATCH_OVERRIDES="<name>:<batch size>:<flush interval milli>,..."
+func getBatchLoaderConfig(name string) (int, time.Duration) {
+	batchSize := config.Config.LoaderBatchSize
+	flushIntervalMilli := config.Config.LoaderFlushIntervalMilli
+
+	if config.Config.LoaderBatchOverrides != "" {
+		for _, override := range strings.Split(config.Config.LoaderBatchOverrides, ",") {
+			overrideSplit := strings.Split(strings.TrimSpace(override), ":")
+			if len(overrideSplit) != 3 || overrideSplit[0] != name {
+				continue
+			}
+
+			overrideBatchSize, err := strconv.Atoi(overrideSplit[1])
+			if err != nil || overrideBatchSize < 1 {
+				zap.S().Fatal("Loader=", name, " - Invalid batch size override: ", override)
+			}
+			overrideFlushIntervalMilli, err := strconv.Atoi(overrideSplit[2])
+			if err != nil || overrideFlushIntervalMilli < 1 {
+				zap.S().Fatal("Loader=", name, " - Invalid flush interval override: ", override)
+			}
+
+			batchSize = overrideBatchSize
+			flushIntervalMilli = overrideFlushIntervalMilli
+		}
+	}
+
+	return batchSize, time.Duration(flushIntervalMilli) * time.Millisecond
+}
+
+// start - read rows from a loader channel and flush when full or on interval
+// NOTE loaderChannel is a typed channel, chan *models.X
+// NOTE on shutdown the channel is drained and the remaining batch is flushed before returning
+func (b *batchLoader) start(ctx context.Context, loaderChannel interface{}) {
+	shutdownTaskDone := global.AddShutdownTask()
+
+	go func() {
+		defer shutdownTaskDone()
+
+		batch := []interface{}{}
+
+		flushTicker := time.NewTicker(b.flushInterval)
+		defer flushTicker.Stop()
+
+		selectCases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(loaderChannel)},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(flushTicker.C)},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		}
+
+		for {
+			chosen, value, _ := reflect.Select(selectCases)
+
+			if chosen == 2 {
+				// Shutdown
+				b.drain(loaderChannel, batch)
+				return
+			}
+
+			if chosen == 0 {
+				// New row
+				batch = append(batch, value.Interface())
+				if len(batch) < b.batchSize {
+					continue
+				}
+			}
+
+			if len(batch) == 0 {
+				continue
+			}
+
+			b.flush(batch)
+			batch = []interface{}{}
+		}
+	}()
+}
+
+// drain - flush the batch and the rows left in a loader channel
+func (b *batchLoader) drain(loaderChannel interface{}, batch []interface{}) {
+	selectCases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(loaderChannel)},
+		{Dir: reflect.SelectDefault},
+	}
+
+	for {
+		chosen, value, ok := reflect.Select(selectCases)
+		if chosen == 1 || ok == false {
+			// Channel is empty
+			break
+		}
+
+		batch = append(batch, value.Interface())
+		if len(batch) >= b.batchSize {
+			b.flush(batch)
+			batch = []interface{}{}
+		}
+	}
+
+	if len(batch) > 0 {
+		b.flush(batch)
+	}
+
+	zap.S().Info("Loader=", b.name, " - Drained, stopped")
+}
+
+// flush - de-duplicate and bulk upsert rows, then ack every row
+func (b *batchLoader) flush(batch []interface{}) {
+
+	/////////////////
+	// De-duplicate //
+	/////////////////
+	rows := []interface{}{}
+	rowIndexes := map[string]int{}
+	for _, row := range batch {
+		key := b.primaryKeyString(row)
+
+		i, ok := rowIndexes[key]
+		if ok == false {
+			rowIndexes[key] = len(rows)
+			rows = append(rows, row)
+			continue
+		}
+
+		// Later row wins
+		if b.updateFilledFieldsOnly == true {
+			mergeFilledFields(row, rows[i])
+		}
+		if b.merge != nil {
+			b.merge(row, rows[i])
+		}
+		rows[i] = row
+	}
+
+	/////////////////
+	// Enrichments //
+	/////////////////
+	if b.enrichBatch != nil {
+		rows = b.enrichBatch(rows)
+	}
+	if b.enrich != nil {
+		for _, row := range rows {
+			b.enrich(row)
+		}
+	}
+
+	//////////////////////
+	// Load to postgres //
+	//////////////////////
+
+	// Group rows by the columns to update
+	groupColumns := map[string][]string{}
+	groupRows := map[string][]interface{}{}
+	for _, row := range rows {
+		columns := b.updateColumns(row)
+		groupKey := strings.Join(columns, ",")
+
+		groupColumns[groupKey] = columns
+		groupRows[groupKey] = append(groupRows[groupKey], row)
+
+		if b.insertDefaults != nil {
+			b.insertDefaults(row)
+		}
+	}
+
+	conflictKeys := b.primaryKeys
+	if b.conflictKeys != nil {
+		conflictKeys = b.conflictKeys
+	}
+
+	conflictColumns := []clause.Column{}
+	for _, conflictKey := range conflictKeys {
+		conflictColumns = append(conflictColumns, clause.Column{Name: conflictKey})
+	}
+
+	for groupKey, groupRow := range groupRows {
+		// []interface{} -> *[]*models.X
+		rowsValue := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(groupRow[0])), 0, len(groupRow))
+		for _, row := range groupRow {
+			rowsValue = reflect.Append(rowsValue, reflect.ValueOf(row))
+		}
+		rowsPtr := reflect.New(rowsValue.Type())
+		rowsPtr.Elem().Set(rowsValue)
+
+		db := b.db.Clauses(clause.OnConflict{
+			Columns:   conflictColumns,
+			DoUpdates: b.updateAssignments(groupColumns[groupKey]),
+		}).Create(rowsPtr.Interface())
+		if db.Error != nil {
+			// Postgres error
+			zap.S().Fatal(
+				"Loader=", b.name,
+				",Rows=", len(groupRow),
+				" - Error: ", db.Error.Error(),
+			)
+		}
+	}
+
+	zap.S().Debug(
+		"Loader=", b.name,
+		",Rows=", len(batch),
+		",UniqueRows=", len(rows),
+		" - Upserted",
+	)
+
+	if b.afterFlush != nil {
+		for _, row := range rows {
+			b.afterFlush(row)
+		}
+	}
+
+	for _, row := range batch {
+		ackLoaded(row)
+	}
+}
+
+// primaryKeyString - unique key of a row within a batch
+func (b *batchLoader) primaryKeyString(row interface{}) string {
+	fields := extractAllFieldsFromModel(
+		reflect.ValueOf(row).Elem(),
+		reflect.TypeOf(row).Elem(),
+	)
+
+	key := ""
+	for _, primaryKey := range b.primaryKeys {
+		key += fmt.Sprintf("%v|", fields[primaryKey])
+	}
+
+	return key
+}
+
+// updateColumns - sorted columns to update on conflict
+func (b *batchLoader) updateColumns(row interface{}) []string {
+	var fields map[string]interface{}
+	if b.updateFilledFieldsOnly == true {
+		fields = extractFilledFieldsFromModel(
+			reflect.ValueOf(row).Elem(),
+			reflect.TypeOf(row).Elem(),
+		)
+	} else {
+		fields = extractAllFieldsFromModel(
+			reflect.ValueOf(row).Elem(),
+			reflect.TypeOf(row).Elem(),
+		)
+	}
+
+	for column := range fields {
+		for _, coupledColumn := range b.coupledColumns[column] {
+			fields[coupledColumn] = true
+		}
+	}
+
+	columns := []string{}
+	for column := range fields {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	return columns
+}
+
+// updateAssignments - on conflict assignments of columns, the inserted value unless there is an update expression
+func (b *batchLoader) updateAssignments(columns []string) clause.Set {
+	assignments := clause.AssignmentColumns(columns)
+
+	for i := range assignments {
+		expression, ok := b.updateExpressions[assignments[i].Column.Name]
+		if ok == true {
+			assignments[i].Value = expression
+		}
+	}
+
+	return assignments
+}
+
+// mergeFilledFields - copy fields filled in src but not in dst
+// NOTE dst and src are pointers to the same model type
+func mergeFilledFields(dst interface{}, src interface{}) {
+	dstValue := reflect.ValueOf(dst).Elem()
+	srcValue := reflect.ValueOf(src).Elem()
+	modelType := dstValue.Type()
+
+	dstFields := extractFilledFieldsFromModel(dstValue, modelType)
+	srcFields := extractFilledFieldsFromModel(srcValue, modelType)
+
+	for i := 0; i < modelType.NumField(); i++ {
+		column := modelType.Field(i).Tag.Get("json")
+		if column == "" {
+			continue
+		}
+
+		_, isDstFilled := dstFields[column]
+		_, isSrcFilled := srcFields[column]
+		if isSrcFilled == true && isDstFilled == false {
+			dstValue.Field(i).Set(srcValue.Field(i))
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package crud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestGetBatchLoaderConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.LoaderBatchSize = 500
+	config.Config.LoaderFlushIntervalMilli = 1000
+	config.Config.LoaderBatchOverrides = "address:50:200, block:10:100"
+	defer func() { config.Config.LoaderBatchOverrides = "" }()
+
+	batchSize, flushInterval := getBatchLoaderConfig("address")
+	assert.Equal(50, batchSize)
+	assert.Equal(200*time.Millisecond, flushInterval)
+
+	batchSize, flushInterval = getBatchLoaderConfig("block")
+	assert.Equal(10, batchSize)
+	assert.Equal(100*time.Millisecond, flushInterval)
+
+	batchSize, flushInterval = getBatchLoaderConfig("transaction")
+	assert.Equal(500, batchSize)
+	assert.Equal(time.Second, flushInterval)
+}
+
+func TestBatchLoaderPrimaryKeyString(t *testing.T) {
+	assert := assert.New(t)
+
+	loader := &batchLoader{primaryKeys: []string{"hash", "log_index"}}
+
+	keyA := loader.primaryKeyString(&models.Transaction{Hash: "0xa", LogIndex: -1})
+	keyB := loader.primaryKeyString(&models.Transaction{Hash: "0xa", LogIndex: -1, Value: "0x1"})
+	keyC := loader.primaryKeyString(&models.Transaction{Hash: "0xa", LogIndex: 0})
+
+	assert.Equal(keyA, keyB)
+	assert.NotEqual(keyA, keyC)
+}
+
+func TestMergeFilledFields(t *testing.T) {
+	assert := assert.New(t)
+
+	earlier := &models.Address{
+		PublicKey:        "hx0000000000000000000000000000000000000000",
+		TransactionCount: 10,
+		Name:             "earlier",
+	}
+	later := &models.Address{
+		PublicKey: "hx0000000000000000000000000000000000000000",
+		LogCount:  5,
+		Name:      "later",
+	}
+
+	mergeFilledFields(later, earlier)
+
+	// Filled in later row wins
+	assert.Equal("later", later.Name)
+	assert.Equal(uint64(5), later.LogCount)
+
+	// Filled in earlier row only is kept
+	assert.Equal(uint64(10), later.TransactionCount)
+}
+
+func TestBatchLoaderUpdateColumns(t *testing.T) {
+	assert := assert.New(t)
+
+	loader := &batchLoader{
+		updateFilledFieldsOnly: true,
+		updateExpressions:      addressActivityUpdateExpressions,
+		coupledColumns:         addressCoupledColumns,
+	}
+
+	// Genesis block number is not filled
+	columns := loader.updateColumns(&models.Address{
+		PublicKey:          "hx0000000000000000000000000000000000000000",
+		FirstSeenTimestamp: 1516819217223222,
+	})
+	assert.Contains(columns, "first_seen_block_number")
+	assert.NotContains(columns, "last_active_block_number")
+
+	for _, assignment := range loader.updateAssignments(columns) {
+		expression, ok := addressActivityUpdateExpressions[assignment.Column.Name]
+		if ok == true {
+			assert.Equal(expression, assignment.Value)
+		} else {
+			assert.Equal(clause.Column{Table: "excluded", Name: assignment.Column.Name}, assignment.Value)
+		}
+	}
+}
+
+func TestMergeAddressActivity(t *testing.T) {
+	assert := assert.New(t)
+
+	earlier := &models.Address{
+		FirstSeenBlockNumber:     10,
+		FirstSeenTimestamp:       1000,
+		FirstSeenTransactionHash: "0xa",
+		LastActiveBlockNumber:    10,
+		LastActiveTimestamp:      1000,
+	}
+	later := &models.Address{
+		FirstSeenBlockNumber:     20,
+		FirstSeenTimestamp:       2000,
+		FirstSeenTransactionHash: "0xb",
+		LastActiveBlockNumber:    20,
+		LastActiveTimestamp:      2000,
+	}
+	mergeAddressActivity(later, earlier)
+
+	// Earliest first seen, latest last active
+	assert.Equal(uint64(10), later.FirstSeenBlockNumber)
+	assert.Equal(uint64(1000), later.FirstSeenTimestamp)
+	assert.Equal("0xa", later.FirstSeenTransactionHash)
+	assert.Equal(uint64(20), later.LastActiveBlockNumber)
+	assert.Equal(uint64(2000), later.LastActiveTimestamp)
+
+	// Not seen
+	unseen := &models.Address{}
+	mergeAddressActivity(earlier, unseen)
+	assert.Equal(uint64(1000), earlier.FirstSeenTimestamp)
+	assert.Equal(uint64(1000), earlier.LastActiveTimestamp)
+}
+
+func TestMergeStoredAddress(t *testing.T) {
+	assert := assert.New(t)
+
+	storedAddress := &models.Address{
+		PublicKey:             "hx0000000000000000000000000000000000000001",
+		BalanceLoop:           "100",
+		TransactionCount:      5,
+		FirstSeenBlockNumber:  10,
+		FirstSeenTimestamp:    1000,
+		LastActiveBlockNumber: 10,
+		LastActiveTimestamp:   1000,
+	}
+	newAddress := &models.Address{
+		PublicKey:             "hx0000000000000000000000000000000000000001",
+		TransactionCount:      6,
+		FirstSeenBlockNumber:  20,
+		FirstSeenTimestamp:    2000,
+		LastActiveBlockNumber: 20,
+		LastActiveTimestamp:   2000,
+	}
+
+	// Updated
+	mergedAddress := mergeStoredAddress(newAddress, storedAddress)
+	assert.Equal("100", mergedAddress.BalanceLoop)
+	assert.Equal(uint64(6), mergedAddress.TransactionCount)
+	assert.Equal(uint64(10), mergedAddress.FirstSeenBlockNumber)
+	assert.Equal(uint64(20), mergedAddress.LastActiveBlockNumber)
+
+	// Written row is not changed
+	assert.Equal("", newAddress.BalanceLoop)
+	assert.Equal(uint64(20), newAddress.FirstSeenBlockNumber)
+
+	// Inserted
+	mergedAddress = mergeStoredAddress(newAddress, nil)
+	assert.Equal("0", mergedAddress.BalanceLoop)
+	assert.Equal(uint64(20), mergedAddress.FirstSeenBlockNumber)
+}
+
+func TestBatchLoaderDrainOnShutdown(t *testing.T) {
+	assert := assert.New(t)
+
+	// Statements are built but not run
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	assert.Equal(nil, err)
+
+	loader := &batchLoader{
+		name:          "transaction",
+		db:            db,
+		primaryKeys:   []string{"hash", "log_index"},
+		batchSize:     10,
+		flushInterval: time.Hour,
+	}
+
+	flushedRows := make(chan interface{}, 10)
+	loader.afterFlush = func(row interface{}) {
+		flushedRows <- row
+	}
+
+	loaderChannel := make(chan *models.Transaction, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	loader.start(ctx, loaderChannel)
+
+	// Below the batch size, not flushed until shutdown
+	loaderChannel <- &models.Transaction{Hash: "0xa", LogIndex: -1}
+	loaderChannel <- &models.Transaction{Hash: "0xb", LogIndex: -1}
+
+	select {
+	case <-flushedRows:
+		assert.Fail("flushed before shutdown")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+
+	for _, hash := range []string{"0xa", "0xb"} {
+		select {
+		case row := <-flushedRows:
+			assert.Equal(hash, row.(*models.Transaction).Hash)
+		case <-time.After(time.Second):
+			assert.Fail("not flushed on shutdown", hash)
+		}
+	}
+}
+
+func TestBatchLoaderUpdateColumnsDrainedBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	loader := &batchLoader{
+		updateFilledFieldsOnly: true,
+		coupledColumns:         addressCoupledColumns,
+	}
+
+	// Zero balance is written with the balance loop
+	columns := loader.updateColumns(&models.Address{
+		PublicKey:   "hx0000000000000000000000000000000000000000",
+		Balance:     0,
+		BalanceLoop: "0",
+	})
+	assert.Contains(columns, "balance_loop")
+	assert.Contains(columns, "balance")
+}
@@ -0,0 +1,57 @@
+package crud
+
+import (
+	"github.com/geometry-labs/icon-addresses/redis"
+)
+
+// incrementRedisCounts - increment redis counters by key
+// Counters that are not set are set from postgres first
+// Returns the incremented counts by key
+// NOTE selectCounts returns the stored counts by key, keys without a row are left out
+func incrementRedisCounts(
+	countKeyPrefix string,
+	increments map[string]int64,
+	selectCounts func(keys []string) (map[string]uint64, error),
+) (map[string]uint64, error) {
+
+	// Counters not set yet
+	unsetKeys := []string{}
+	for key := range increments {
+		count, err := redis.GetRedisClient().GetCount(countKeyPrefix + key)
+		if err != nil {
+			return nil, err
+		}
+		if count == -1 {
+			unsetKeys = append(unsetKeys, key)
+		}
+	}
+
+	// Get from database
+	if len(unsetKeys) > 0 {
+		storedCounts, err := selectCounts(unsetKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range unsetKeys {
+			// NOTE 0 if no row
+			err = redis.GetRedisClient().SetCount(countKeyPrefix+key, int64(storedCounts[key]))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Increment
+	counts := map[string]uint64{}
+	for key, increment := range increments {
+		count, err := redis.GetRedisClient().IncCountBy(countKeyPrefix+key, increment)
+		if err != nil {
+			return nil, err
+		}
+
+		counts[key] = uint64(count)
+	}
+
+	return counts, nil
+}
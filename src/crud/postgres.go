@@ -88,3 +88,18 @@ func createSession(dsn string) (*gorm.DB, error) {
 
 	return db, err
 }
+
+// GetPostgresConn - shared postgres connection
+func GetPostgresConn() *gorm.DB {
+	return getPostgresConn()
+}
+
+// autoMigrate - create or update a table from its ORM model
+// NOTE only with DB_AUTO_MIGRATE=true, the schema is managed by the migrations package
+func autoMigrate(db *gorm.DB, modelORM interface{}) error {
+	if config.Config.DbAutoMigrate == false {
+		return nil
+	}
+
+	return db.AutoMigrate(modelORM)
+}
@@ -0,0 +1,495 @@
+package crud
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/redis"
+)
+
+// Transformers hold the read lock while sending the rows of a message to the loaders
+// Rollbacks hold the write lock, see RollbackBlocks
+var rollbackLock sync.RWMutex
+
+// Hashes of blocks replaced by a reorg, loaded from orphaned_blocks
+var orphanedBlockHashes sync.Map
+var orphanedBlockHashesOnce sync.Once
+
+// RLockRollbacks - hold off rollbacks while the rows of a message are sent to the loaders
+func RLockRollbacks() {
+	rollbackLock.RLock()
+}
+
+// RUnlockRollbacks - release RLockRollbacks
+func RUnlockRollbacks() {
+	rollbackLock.RUnlock()
+}
+
+// IsOrphanedBlockHash - check if a block was replaced by a reorg
+// NOTE messages of orphaned blocks are skipped, their rows were rolled back
+func IsOrphanedBlockHash(blockHash string) bool {
+	if blockHash == "" {
+		return false
+	}
+
+	orphanedBlockHashesOnce.Do(func() {
+		hashes := []string{}
+		err := getPostgresConn().Raw("SELECT hash FROM orphaned_blocks").Scan(&hashes).Error
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+
+		for _, hash := range hashes {
+			orphanedBlockHashes.Store(hash, true)
+		}
+	})
+
+	_, ok := orphanedBlockHashes.Load(blockHash)
+	return ok
+}
+
+// pauseTransformers - hold off transformers and wait for the rows sent to the loaders to be persisted
+// Returns the callback resuming the transformers
+func pauseTransformers() func() {
+	rollbackLock.Lock()
+
+	waitLoaderAcks()
+
+	return rollbackLock.Unlock
+}
+
+// Rows of orphaned blocks, by block hash
+// NOTE rows loaded before block hashes were stored are rolled back by block number
+const orphanedRowsCondition = "(block_hash IN ? OR (block_hash = '' AND block_number >= ?))"
+
+// RollbackBlocks - delete the rows of blocks replaced by a reorg and the data built from the fork block number
+// Rows are deleted by block hash, rows of the new blocks loaded before the rollback are kept
+// NOTE transformers are paused and the rows sent to the loaders are persisted first, rows still in a loader channel are rolled back too
+// NOTE the balance builder must not run during a rollback, see builders.RewindBalanceBuilders
+func RollbackBlocks(blockNumber uint32, orphanedBlocks []*models.Block) error {
+
+	resumeTransformers := pauseTransformers()
+	defer resumeTransformers()
+
+	orphanedHashes := []string{}
+	for _, orphanedBlock := range orphanedBlocks {
+		orphanedHashes = append(orphanedHashes, orphanedBlock.Hash)
+	}
+
+	// Count index rows removed by public key
+	transactionCountDecrements := map[string]int64{}
+	logCountDecrements := map[string]int64{}
+
+	// Addresses to re-enrich
+	publicKeys := map[string]bool{}
+
+	// Addresses with activity to recompute
+	activityPublicKeys := []string{}
+
+	err := getPostgresConn().Transaction(func(tx *gorm.DB) error {
+
+		////////////////////////
+		// Count index tables //
+		////////////////////////
+		rows, err := tx.Raw(
+			`DELETE FROM transaction_count_by_public_key_indices
+			WHERE transaction_hash IN (SELECT hash FROM transactions WHERE `+orphanedRowsCondition+`)
+			RETURNING public_key`,
+			orphanedHashes,
+			blockNumber,
+		).Rows()
+		if err != nil {
+			return err
+		}
+		err = scanRollbackPublicKeys(rows, transactionCountDecrements, publicKeys)
+		if err != nil {
+			return err
+		}
+
+		// NOTE transactions in both an orphaned block and a new block keep their index rows
+		rows, err = tx.Raw(
+			`DELETE FROM log_count_by_public_key_indices
+			WHERE transaction_hash IN (SELECT transaction_hash FROM log_count_by_block_numbers WHERE `+orphanedRowsCondition+`)
+			AND transaction_hash NOT IN (SELECT transaction_hash FROM log_count_by_block_numbers WHERE NOT `+orphanedRowsCondition+`)
+			RETURNING public_key`,
+			orphanedHashes,
+			blockNumber,
+			orphanedHashes,
+			blockNumber,
+		).Rows()
+		if err != nil {
+			return err
+		}
+		err = scanRollbackPublicKeys(rows, logCountDecrements, publicKeys)
+		if err != nil {
+			return err
+		}
+
+		for publicKey, decrement := range transactionCountDecrements {
+			err = tx.Exec(
+				"UPDATE transaction_count_by_public_keys SET count = GREATEST(count - ?, 0) WHERE public_key = ?",
+				decrement,
+				publicKey,
+			).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for publicKey, decrement := range logCountDecrements {
+			err = tx.Exec(
+				"UPDATE log_count_by_public_keys SET count = GREATEST(count - ?, 0) WHERE public_key = ?",
+				decrement,
+				publicKey,
+			).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		/////////////////////////
+		// Block number tables //
+		/////////////////////////
+		for _, table := range []string{
+			"log_count_by_block_numbers",
+			"transaction_count_by_block_numbers",
+		} {
+			err = tx.Exec("DELETE FROM "+table+" WHERE "+orphanedRowsCondition, orphanedHashes, blockNumber).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		// Built from the block number by the balance builder
+		err = tx.Exec("DELETE FROM balances WHERE block_number >= ?", blockNumber).Error
+		if err != nil {
+			return err
+		}
+
+		rows, err = tx.Raw(
+			"DELETE FROM transactions WHERE "+orphanedRowsCondition+" RETURNING from_address, to_address",
+			orphanedHashes,
+			blockNumber,
+		).Rows()
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var fromAddress, toAddress string
+			err = rows.Scan(&fromAddress, &toAddress)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			publicKeys[fromAddress] = true
+			publicKeys[toAddress] = true
+		}
+		rows.Close()
+		if rows.Err() != nil {
+			return rows.Err()
+		}
+
+		// I-Score claims are ledger inputs of the balance builder
+		iScoreClaimPublicKeys := []string{}
+		err = tx.Raw(
+			"DELETE FROM i_score_claims WHERE "+orphanedRowsCondition+" RETURNING public_key",
+			orphanedHashes,
+			blockNumber,
+		).Scan(&iScoreClaimPublicKeys).Error
+		if err != nil {
+			return err
+		}
+		for _, publicKey := range iScoreClaimPublicKeys {
+			publicKeys[publicKey] = true
+		}
+
+		////////////////////////
+		// Address activities //
+		////////////////////////
+		// NOTE the loader only moves activity earlier or later, reset it to recompute
+		err = tx.Raw(
+			`UPDATE addresses SET
+				first_seen_block_number = CASE WHEN first_seen_block_number >= ? THEN 0 ELSE first_seen_block_number END,
+				first_seen_timestamp = CASE WHEN first_seen_block_number >= ? THEN 0 ELSE first_seen_timestamp END,
+				first_seen_transaction_hash = CASE WHEN first_seen_block_number >= ? THEN '' ELSE first_seen_transaction_hash END,
+				last_active_block_number = 0,
+				last_active_timestamp = 0
+			WHERE last_active_timestamp > 0 AND last_active_block_number >= ?
+			RETURNING public_key`,
+			blockNumber,
+			blockNumber,
+			blockNumber,
+			blockNumber,
+		).Scan(&activityPublicKeys).Error
+		if err != nil {
+			return err
+		}
+
+		////////////////////
+		// Address tokens //
+		////////////////////
+		rows, err = tx.Raw(
+			"DELETE FROM token_balances WHERE "+orphanedRowsCondition+" RETURNING public_key, token_contract_address",
+			orphanedHashes,
+			blockNumber,
+		).Rows()
+		if err != nil {
+			return err
+		}
+		addressTokens := map[[2]string]bool{}
+		for rows.Next() {
+			var publicKey, tokenContractAddress string
+			err = rows.Scan(&publicKey, &tokenContractAddress)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+
+			addressTokens[[2]string{publicKey, tokenContractAddress}] = true
+			publicKeys[publicKey] = true
+		}
+		rows.Close()
+		if rows.Err() != nil {
+			return rows.Err()
+		}
+
+		// Entries of new blocks were computed on top of the orphaned entries
+		err = recomputeTokenBalances(tx, blockNumber, addressTokens)
+		if err != nil {
+			return err
+		}
+
+		// Tokens first held in orphaned blocks are removed, others are set to their latest balance
+		for addressToken := range addressTokens {
+			err = tx.Exec(
+				`DELETE FROM address_tokens
+				WHERE public_key = ? AND token_contract_address = ?
+				AND NOT EXISTS (
+					SELECT 1 FROM token_balances
+					WHERE token_balances.public_key = address_tokens.public_key
+					AND token_balances.token_contract_address = address_tokens.token_contract_address
+				)`,
+				addressToken[0],
+				addressToken[1],
+			).Error
+			if err != nil {
+				return err
+			}
+
+			// Latest balance left in the ledger
+			err = tx.Exec(
+				`UPDATE address_tokens
+				SET (balance, balance_decimal, last_updated_block_number) = (
+					SELECT value, value_decimal, block_number FROM token_balances
+					WHERE token_balances.public_key = address_tokens.public_key
+					AND token_balances.token_contract_address = address_tokens.token_contract_address
+					ORDER BY block_number DESC, transaction_index DESC, log_index DESC
+					LIMIT 1
+				)
+				WHERE public_key = ? AND token_contract_address = ?`,
+				addressToken[0],
+				addressToken[1],
+			).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		/////////////////////////
+		// Builder checkpoints //
+		/////////////////////////
+		_, err = GetBuilderCheckpointModel().rewind(tx, uint64(blockNumber))
+		if err != nil {
+			return err
+		}
+
+		////////////
+		// Blocks //
+		////////////
+		err = tx.Exec(
+			"DELETE FROM blocks WHERE hash IN ? OR (hash = '' AND number >= ?)",
+			orphanedHashes,
+			blockNumber,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		for _, orphanedBlock := range orphanedBlocks {
+			err = tx.Exec(
+				"INSERT INTO orphaned_blocks (hash, number, orphaned_timestamp) VALUES (?, ?, ?) ON CONFLICT DO NOTHING",
+				orphanedBlock.Hash,
+				orphanedBlock.Number,
+				time.Now().UnixNano()/1000,
+			).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Messages of orphaned blocks are skipped from now on
+	for _, orphanedHash := range orphanedHashes {
+		orphanedBlockHashes.Store(orphanedHash, true)
+	}
+
+	////////////////////
+	// Redis counters //
+	////////////////////
+	err = rollbackRedisCounts("icon_addresses_transaction_count_by_address_", "transaction_count_by_public_keys", transactionCountDecrements)
+	if err != nil {
+		return err
+	}
+	err = rollbackRedisCounts("icon_addresses_log_count_by_address_", "log_count_by_public_keys", logCountDecrements)
+	if err != nil {
+		return err
+	}
+
+	///////////////////////
+	// Force enrichments //
+	///////////////////////
+	reloadPublicKeys := []string{}
+	for publicKey := range publicKeys {
+		if publicKey == "" {
+			continue
+		}
+
+		reloadPublicKeys = append(reloadPublicKeys, publicKey)
+	}
+	err = reloadAddresses(reloadPublicKeys)
+	if err != nil {
+		return err
+	}
+
+	// Activity before the block number
+	if len(activityPublicKeys) > 0 {
+		activities, err := GetAddressModel().SelectActivitiesByPublicKeys(activityPublicKeys)
+		if err != nil {
+			return err
+		}
+		for i := range *activities {
+			GetAddressModel().LoaderChannel <- &(*activities)[i]
+		}
+	}
+
+	zap.S().Info(
+		"Rollback=Block,",
+		"BlockNumber=", blockNumber,
+		",OrphanedBlocks=", len(orphanedBlocks),
+		",Addresses=", len(publicKeys),
+		" - Rolled back",
+	)
+
+	return nil
+}
+
+// scanRollbackPublicKeys - count deleted rows by public key
+func scanRollbackPublicKeys(rows *sql.Rows, decrements map[string]int64, publicKeys map[string]bool) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var publicKey string
+		err := rows.Scan(&publicKey)
+		if err != nil {
+			return err
+		}
+
+		decrements[publicKey]++
+		publicKeys[publicKey] = true
+	}
+
+	return rows.Err()
+}
+
+// rollbackRedisCounts - decrement redis counters that are already set
+// NOTE counters that are not set are read from postgres by the loaders
+func rollbackRedisCounts(countKeyPrefix string, table string, decrements map[string]int64) error {
+	for publicKey, decrement := range decrements {
+		countKey := countKeyPrefix + publicKey
+
+		count, err := redis.GetRedisClient().GetCount(countKey)
+		if err != nil {
+			return err
+		}
+		if count == -1 {
+			// Not set
+			continue
+		}
+
+		count, err = redis.GetRedisClient().DecCountBy(countKey, decrement)
+		if err != nil {
+			return err
+		}
+		if count < 0 {
+			count = 0
+			err = redis.GetRedisClient().SetCount(countKey, count)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Keep postgres in line with redis
+		err = getPostgresConn().Exec(
+			"UPDATE "+table+" SET count = ? WHERE public_key = ?",
+			count,
+			publicKey,
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recomputeTokenBalances - recompute the ledger values of public key and token pairs from a block number
+func recomputeTokenBalances(tx *gorm.DB, blockNumber uint32, addressTokens map[[2]string]bool) error {
+	if len(addressTokens) == 0 {
+		return nil
+	}
+
+	// Before the first entry of the block
+	positions := []*models.TokenBalance{}
+	for addressToken := range addressTokens {
+		positions = append(positions, &models.TokenBalance{
+			PublicKey:            addressToken[0],
+			TokenContractAddress: addressToken[1],
+			BlockNumber:          uint64(blockNumber),
+			TransactionIndex:     0,
+			LogIndex:             -1,
+		})
+	}
+
+	previousTokenBalances, laterTokenBalances, err := selectTokenBalancesFromPositions(tx, positions)
+	if err != nil {
+		return err
+	}
+
+	tokenBalances := computeTokenBalances(nil, previousTokenBalances, laterTokenBalances)
+	if len(tokenBalances) == 0 {
+		return nil
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "block_number"},
+			{Name: "transaction_index"},
+			{Name: "log_index"},
+			{Name: "public_key"},
+		}, // NOTE set to primary keys for table
+		DoUpdates: clause.AssignmentColumns([]string{"value", "value_decimal"}),
+	}).Create(&tokenBalances).Error
+}
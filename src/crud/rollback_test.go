@@ -0,0 +1,116 @@
+package crud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestPauseTransformers(t *testing.T) {
+	assert := assert.New(t)
+
+	// Row of a message sent to a loader, not yet persisted
+	transaction := &models.Transaction{Hash: "0x1", BlockHash: "0xa"}
+	acked := false
+	SetLoaderAck(transaction, func() { acked = true })
+
+	// Transformer sending the rows of another message
+	RLockRollbacks()
+
+	paused := make(chan func(), 1)
+	go func() {
+		paused <- pauseTransformers()
+	}()
+
+	select {
+	case <-paused:
+		assert.Fail("paused while a transformer is sending rows")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	RUnlockRollbacks()
+
+	select {
+	case <-paused:
+		assert.Fail("paused before the loaders persisted the rows")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Loader flush
+	ackLoaded(transaction)
+	assert.Equal(true, acked)
+
+	var resumeTransformers func()
+	select {
+	case resumeTransformers = <-paused:
+	case <-time.After(time.Second):
+		assert.FailNow("not paused after the loaders persisted the rows")
+	}
+
+	// Transformers are held off until the rollback is done
+	resumed := make(chan bool, 1)
+	go func() {
+		RLockRollbacks()
+		resumed <- true
+		RUnlockRollbacks()
+	}()
+
+	select {
+	case <-resumed:
+		assert.Fail("transformer resumed during the rollback")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	resumeTransformers()
+
+	select {
+	case <-resumed:
+	case <-time.After(time.Second):
+		assert.Fail("transformer not resumed after the rollback")
+	}
+}
+
+func TestIsOrphanedBlockHash(t *testing.T) {
+	assert := assert.New(t)
+
+	// Not loaded from postgres
+	orphanedBlockHashesOnce.Do(func() {})
+	orphanedBlockHashes.Store("0xa", true)
+
+	assert.Equal(true, IsOrphanedBlockHash("0xa"))
+	assert.Equal(false, IsOrphanedBlockHash("0xb"))
+
+	// Rows loaded before block hashes were stored
+	assert.Equal(false, IsOrphanedBlockHash(""))
+}
+
+func TestOrphanedRowsCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	// Statements are built but not run
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Discard,
+	})
+	assert.Equal(nil, err)
+
+	// Rows of the new blocks at the same block numbers are kept
+	statement := db.Exec(
+		"DELETE FROM transactions WHERE "+orphanedRowsCondition,
+		[]string{"0xa", "0xb"},
+		uint32(10),
+	).Statement
+	assert.Equal(
+		"DELETE FROM transactions WHERE (block_hash IN ($1,$2) OR (block_hash = '' AND block_number >= $3))",
+		statement.SQL.String(),
+	)
+	assert.Equal([]interface{}{"0xa", "0xb", uint32(10)}, statement.Vars)
+}
@@ -1,6 +1,9 @@
 package crud
 
-import "reflect"
+import (
+	"reflect"
+	"strings"
+)
 
 func extractAllFieldsFromModel(modelValueOf reflect.Value, modelTypeOf reflect.Type) map[string]interface{} {
 
@@ -111,3 +114,16 @@ func extractFilledFieldsFromModel(modelValueOf reflect.Value, modelTypeOf reflec
 
 	return fields
 }
+
+// postgresArrayLiteral - text array literal for a ?::<type>[] parameter
+// NOTE gorm expands slice parameters to a list, not an array
+func postgresArrayLiteral(values []string) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+	elements := make([]string, len(values))
+	for i, value := range values {
+		elements[i] = `"` + escaper.Replace(value) + `"`
+	}
+
+	return "{" + strings.Join(elements, ",") + "}"
+}
@@ -0,0 +1,15 @@
+package crud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresArrayLiteral(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("{}", postgresArrayLiteral([]string{}))
+	assert.Equal(`{"1","1000000000000000000"}`, postgresArrayLiteral([]string{"1", "1000000000000000000"}))
+	assert.Equal(`{"a\"b","c\\d"}`, postgresArrayLiteral([]string{`a"b`, `c\d`}))
+}
@@ -1,19 +1,60 @@
 package global
 
 import (
+	"context"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
 )
 
 // Version - service version
 const Version = "v0.1.0"
 
+var shutdownContext, shutdownCancel = context.WithCancel(context.Background())
+var shutdownTasks sync.WaitGroup
+
+// ShutdownContext - cancelled when a shutdown signal is received
+func ShutdownContext() context.Context {
+	return shutdownContext
+}
+
+// AddShutdownTask - register a task to drain before exiting
+// Returns the callback to run once the task is drained
+func AddShutdownTask() func() {
+	shutdownTasks.Add(1)
+
+	return shutdownTasks.Done
+}
+
 // WaitShutdownSig - wait for system shutdown signal
+// Cancels the shutdown context and waits for shutdown tasks to drain
 func WaitShutdownSig() {
 	// Listen for close sig
 	// Register for interupt (Ctrl+C) and SIGTERM (docker)
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	<-sigChan
+
+	zap.S().Info("Shutdown: Signal received, draining...")
+	shutdownCancel()
+
+	// Drain
+	drainedChan := make(chan struct{})
+	go func() {
+		shutdownTasks.Wait()
+		close(drainedChan)
+	}()
+
+	select {
+	case <-drainedChan:
+		zap.S().Info("Shutdown: Drained")
+	case <-time.After(time.Duration(config.Config.ShutdownDrainTimeout) * time.Second):
+		zap.S().Warn("Shutdown: Drain timed out after ", config.Config.ShutdownDrainTimeout, " seconds")
+	}
 }
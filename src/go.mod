@@ -11,6 +11,7 @@ require (
 	github.com/frankban/quicktest v1.13.0 // indirect
 	github.com/go-redis/redis/v8 v8.11.3
 	github.com/gofiber/fiber/v2 v2.14.0
+	github.com/gofiber/websocket/v2 v2.0.7
 	github.com/golang/protobuf v1.5.2
 	github.com/infobloxopen/atlas-app-toolkit v1.0.0
 	github.com/infobloxopen/protoc-gen-gorm v0.21.0
@@ -25,6 +26,7 @@ require (
 	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c // indirect
 	google.golang.org/genproto v0.0.0-20210726200206-e7812ac95cc0
 	google.golang.org/protobuf v1.27.1
+	gopkg.in/yaml.v2 v2.4.0
 	gorm.io/driver/postgres v1.1.0
 	gorm.io/gorm v1.21.12
 )
@@ -12,13 +12,14 @@ import (
 
 	"github.com/geometry-labs/icon-addresses/config"
 	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/global"
 	"github.com/geometry-labs/icon-addresses/models"
 )
 
 type kafkaTopicConsumer struct {
 	brokerURL     string
 	topicNames    []string
-	TopicChannels map[string]chan *sarama.ConsumerMessage
+	TopicChannels map[string]chan *TopicMessage
 }
 
 var KafkaTopicConsumer *kafkaTopicConsumer
@@ -36,9 +37,9 @@ func StartWorkerConsumers() {
 	}
 
 	// Init topic channels
-	topicChannels := make(map[string]chan *sarama.ConsumerMessage)
+	topicChannels := make(map[string]chan *TopicMessage)
 	for _, topicName := range topicNames {
-		topicChannels[topicName] = make(chan *sarama.ConsumerMessage)
+		topicChannels[topicName] = make(chan *TopicMessage)
 	}
 
 	// Init consumer
@@ -61,6 +62,7 @@ func StartWorkerConsumers() {
 			" ConsumerPartitionStartOffset=", config.Config.ConsumerPartitionStartOffset,
 			" - Starting Consumers")
 		go KafkaTopicConsumer.consumePartition(
+			global.ShutdownContext(),
 			config.Config.ConsumerPartitionTopic,
 			config.Config.ConsumerPartition,
 			config.Config.ConsumerPartitionStartOffset,
@@ -75,7 +77,7 @@ func StartWorkerConsumers() {
 			" consumerTopics=", topicNames,
 			" consumerGroup=", config.Config.ConsumerGroup+"-"+config.Config.ConsumerJobID,
 			" - Starting Consumers")
-		go KafkaTopicConsumer.consumeGroup(config.Config.ConsumerGroup + "-" + config.Config.ConsumerJobID)
+		go KafkaTopicConsumer.consumeGroup(global.ShutdownContext(), config.Config.ConsumerGroup+"-"+config.Config.ConsumerJobID)
 		return
 	}
 
@@ -86,14 +88,18 @@ func StartWorkerConsumers() {
 		" consumerTopics=", topicNames,
 		" consumerGroup=", config.Config.ConsumerGroup+"-head",
 		" - Starting Consumers")
-	go KafkaTopicConsumer.consumeGroup(config.Config.ConsumerGroup + "-head")
+	go KafkaTopicConsumer.consumeGroup(global.ShutdownContext(), config.Config.ConsumerGroup+"-head")
 	return
 }
 
 ////////////////////
 // Group Consumer //
 ////////////////////
-func (k *kafkaTopicConsumer) consumeGroup(group string) {
+func (k *kafkaTopicConsumer) consumeGroup(ctx context.Context, group string) {
+	// Drain before exiting
+	shutdownTaskDone := global.AddShutdownTask()
+	defer shutdownTaskDone()
+
 	version, err := sarama.ParseKafkaVersion("2.1.1")
 	if err != nil {
 		zap.S().Panic("CONSUME GROUP ERROR: parsing Kafka version: ", err.Error())
@@ -163,40 +169,38 @@ func (k *kafkaTopicConsumer) consumeGroup(group string) {
 	}
 
 	// From example: /sarama/blob/master/examples/consumergroup/main.go
-	ctx, cancel := context.WithCancel(context.Background())
-	go func() {
-		claimConsumer := &ClaimConsumer{
-			topicNames: k.topicNames,
-			topicChans: k.TopicChannels,
-			group:      group,
-			kafkaJobs:  *kafkaJobs,
-		}
+	claimConsumer := &ClaimConsumer{
+		topicNames: k.topicNames,
+		topicChans: k.TopicChannels,
+		group:      group,
+		kafkaJobs:  *kafkaJobs,
+	}
 
-		for {
-			// `Consume` should be called inside an infinite loop, when a
-			// server-side rebalance happens, the consumer session will need to be
-			// recreated to get the new claims
-			err := consumerGroup.Consume(ctx, k.topicNames, claimConsumer)
-			if err != nil {
-				zap.S().Warn("CONSUME GROUP ERROR: from consumer: ", err.Error())
-			}
-			// check if context was cancelled, signaling that the consumer should stop
-			if ctx.Err() != nil {
-				zap.S().Warn("CONSUME GROUP WARN: from context: ", ctx.Err().Error())
-				return
-			}
+	for {
+		// `Consume` should be called inside an infinite loop, when a
+		// server-side rebalance happens, the consumer session will need to be
+		// recreated to get the new claims
+		err := consumerGroup.Consume(ctx, k.topicNames, claimConsumer)
+		if err != nil {
+			zap.S().Warn("CONSUME GROUP ERROR: from consumer: ", err.Error())
 		}
-	}()
+		// check if context was cancelled, signaling that the consumer should stop
+		if ctx.Err() != nil {
+			zap.S().Warn("CONSUME GROUP WARN: from context: ", ctx.Err().Error())
+			break
+		}
+	}
 
-	// Waiting, so that consumerGroup remains alive
-	ch := make(chan int, 1)
-	<-ch
-	cancel()
+	// Commit marked offsets
+	err = consumerGroup.Close()
+	if err != nil {
+		zap.S().Warn("CONSUME GROUP ERROR: closing consumer group: ", err.Error())
+	}
 }
 
 type ClaimConsumer struct {
 	topicNames []string
-	topicChans map[string]chan *sarama.ConsumerMessage
+	topicChans map[string]chan *TopicMessage
 	group      string
 	kafkaJobs  []models.KafkaJob
 }
@@ -217,29 +221,70 @@ func (c *ClaimConsumer) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sar
 		}
 	}
 
+	// Messages sent to transformers, in offset order
+	// NOTE offsets are marked in order once messages are done
+	inFlightMsgs := []*TopicMessage{}
+	markDoneMessages := func() {
+		for len(inFlightMsgs) > 0 {
+			select {
+			case <-inFlightMsgs[0].Done():
+				sess.MarkMessage(inFlightMsgs[0].ConsumerMessage, "")
+				inFlightMsgs = inFlightMsgs[1:]
+				continue
+			default:
+			}
+			break
+		}
+	}
+
+	// Wait for in flight messages before giving up the claim
+	drainMessages := func() {
+		drainTimeout := time.After(time.Duration(config.Config.ShutdownDrainTimeout) * time.Second)
+		for _, inFlightMsg := range inFlightMsgs {
+			select {
+			case <-inFlightMsg.Done():
+				sess.MarkMessage(inFlightMsg.ConsumerMessage, "")
+			case <-drainTimeout:
+				zap.S().Warn("GROUP=", c.group, ",TOPIC=", topicName, ",PARTITION=", partition, " - Drain timed out, offsets not marked for unfinished messages")
+				return
+			}
+		}
+		sess.Commit()
+	}
+
 	for {
-		var topicMsg *sarama.ConsumerMessage
+		markDoneMessages()
+
+		var topicMsg *TopicMessage
 		select {
 		case msg := <-claim.Messages():
 			if msg == nil {
 				zap.S().Warn("GROUP=", c.group, ",TOPIC=", topicName, " - Kafka message is nil, exiting ConsumeClaim loop...")
+				drainMessages()
 				return nil
 			}
 
-			topicMsg = msg
+			topicMsg = newTopicMessage(msg)
 		case <-time.After(5 * time.Second):
 			zap.S().Info("GROUP=", c.group, ",TOPIC=", topicName, " - No new kafka messages, waited 5 secs...")
 			continue
 		case <-sess.Context().Done():
 			zap.S().Warn("GROUP=", c.group, ",TOPIC=", topicName, " - Session is done, exiting ConsumeClaim loop...")
+			drainMessages()
 			return nil
 		}
 
 		zap.S().Info("GROUP=", c.group, ",TOPIC=", topicName, ",PARTITION=", partition, ",OFFSET=", topicMsg.Offset, " - New message")
-		sess.MarkMessage(topicMsg, "")
 
 		// Broadcast
-		c.topicChans[topicName] <- topicMsg
+		select {
+		case c.topicChans[topicName] <- topicMsg:
+			inFlightMsgs = append(inFlightMsgs, topicMsg)
+		case <-sess.Context().Done():
+			zap.S().Warn("GROUP=", c.group, ",TOPIC=", topicName, " - Session is done, exiting ConsumeClaim loop...")
+			drainMessages()
+			return nil
+		}
 
 		// Check if kafka job is done
 		// NOTE only applicable if ConsumerKafkaJobID is given
@@ -253,16 +298,16 @@ func (c *ClaimConsumer) ConsumeClaim(sess sarama.ConsumerGroupSession, claim sar
 				",PARTITION=", partition,
 				" - Kafka Job done...exiting",
 			)
+			drainMessages()
 			os.Exit(0)
 		}
 	}
-	return nil
 }
 
 ////////////////////////
 // Partition Consumer //
 ////////////////////////
-func (k *kafkaTopicConsumer) consumePartition(topic string, partition int, startOffset int) {
+func (k *kafkaTopicConsumer) consumePartition(ctx context.Context, topic string, partition int, startOffset int) {
 	version, err := sarama.ParseKafkaVersion("2.1.1")
 	if err != nil {
 		zap.S().Panic("CONSUME GROUP ERROR: parsing Kafka version: ", err.Error())
@@ -313,6 +358,9 @@ func (k *kafkaTopicConsumer) consumePartition(topic string, partition int, start
 	for {
 		var topic_msg *sarama.ConsumerMessage
 		select {
+		case <-ctx.Done():
+			zap.S().Warn("Consumer ", topic, ": Shutting down...")
+			return
 		case msg := <-pc.Messages():
 			topic_msg = msg
 		case consumerErr := <-pc.Errors():
@@ -326,7 +374,12 @@ func (k *kafkaTopicConsumer) consumePartition(topic string, partition int, start
 		zap.S().Debug("Consumer ", topic, ": Consumed message key=", string(topic_msg.Key))
 
 		// Broadcast
-		k.TopicChannels[topic] <- topic_msg
+		// NOTE no offsets to commit
+		select {
+		case k.TopicChannels[topic] <- newTopicMessage(topic_msg):
+		case <-ctx.Done():
+			return
+		}
 
 		zap.S().Debug("Consumer ", topic, ": Broadcasted message key=", string(topic_msg.Key))
 	}
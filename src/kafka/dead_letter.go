@@ -0,0 +1,124 @@
+package kafka
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/metrics"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// Dead letter headers
+const (
+	DeadLetterHeaderTopic     = "original-topic"
+	DeadLetterHeaderPartition = "original-partition"
+	DeadLetterHeaderOffset    = "original-offset"
+	DeadLetterHeaderError     = "error"
+)
+
+// IsPoisonMessageError - error is caused by the message itself and will not resolve on retry
+// NOTE registry outages, postgres errors, and any other error are retryable
+func IsPoisonMessageError(processErr error) bool {
+	var wireFormatErr *WireFormatError
+	var schemaMismatchErr *SchemaMismatchError
+	var payloadErr *PayloadError
+
+	return errors.As(processErr, &wireFormatErr) ||
+		errors.As(processErr, &schemaMismatchErr) ||
+		errors.As(processErr, &payloadErr) ||
+		errors.Is(processErr, ErrSchemaNotFound)
+}
+
+// HandleProcessError - dead letter poison messages, stop the consumer on retryable errors
+// NOTE the message is not processed on retryable errors, the offset is not committed and the message is consumed again on restart
+func HandleProcessError(msg *TopicMessage, processErr error) {
+	if IsPoisonMessageError(processErr) == false {
+		zap.S().Fatal(
+			"TOPIC=", msg.Topic,
+			",PARTITION=", msg.Partition,
+			",OFFSET=", msg.Offset,
+			" - Unable to process message, stopping without committing, error: ", processErr.Error(),
+		)
+		return
+	}
+
+	SendToDeadLetter(msg, processErr)
+	msg.Processed()
+}
+
+// SendToDeadLetter - route a message that cannot be processed to the dead letter topic
+// NOTE the message is also stored in the failed_messages table for replays
+func SendToDeadLetter(msg *TopicMessage, processErr error) {
+
+	zap.S().Warn(
+		"TOPIC=", msg.Topic,
+		",PARTITION=", msg.Partition,
+		",OFFSET=", msg.Offset,
+		" - Sending message to dead letter topic, error: ", processErr.Error(),
+	)
+
+	/////////////
+	// Metrics //
+	/////////////
+	metrics.DeadLetterMessagesCounter.WithLabelValues(msg.Topic).Inc()
+
+	//////////////////////
+	// Load to postgres //
+	//////////////////////
+	failedMessage := &models.FailedMessage{
+		Topic:             msg.Topic,
+		Partition:         msg.Partition,
+		Offset:            msg.Offset,
+		Key:               msg.Key,
+		Value:             msg.Value,
+		Error:             processErr.Error(),
+		CreatedTimestamp:  uint64(time.Now().UnixNano() / 1000),
+		ReplayedTimestamp: 0,
+	}
+	crud.SetLoaderAck(failedMessage, msg.Track())
+	crud.GetFailedMessageModel().LoaderChannel <- failedMessage
+
+	///////////////////
+	// Load to kafka //
+	///////////////////
+
+	// Keep original headers
+	headers := []sarama.RecordHeader{}
+	for _, header := range msg.Headers {
+		if header != nil {
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(DeadLetterHeaderTopic), Value: []byte(msg.Topic)},
+		sarama.RecordHeader{Key: []byte(DeadLetterHeaderPartition), Value: []byte(strconv.FormatInt(int64(msg.Partition), 10))},
+		sarama.RecordHeader{Key: []byte(DeadLetterHeaderOffset), Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		sarama.RecordHeader{Key: []byte(DeadLetterHeaderError), Value: []byte(processErr.Error())},
+	)
+
+	producerMessage := &sarama.ProducerMessage{
+		Topic:   config.Config.ProducerTopicDeadLetter,
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	}
+	if len(msg.Key) > 0 {
+		producerMessage.Key = sarama.ByteEncoder(msg.Key)
+	}
+
+	_, _, err := GetKafkaProducer().SendMessage(producerMessage)
+	if err != nil {
+		// Message is still in failed_messages
+		zap.S().Warn(
+			"TOPIC=", msg.Topic,
+			",PARTITION=", msg.Partition,
+			",OFFSET=", msg.Offset,
+			" - Unable to send message to dead letter topic, error: ", err.Error(),
+		)
+	}
+}
@@ -0,0 +1,59 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestIsPoisonMessageError(t *testing.T) {
+	assert := assert.New(t)
+
+	// Poison
+	assert.True(IsPoisonMessageError(&WireFormatError{Reason: "unknown magic byte 0x1"}))
+	assert.True(IsPoisonMessageError(&SchemaMismatchError{SchemaID: 1, SchemaType: "JSON", Expected: "PROTOBUF"}))
+	assert.True(IsPoisonMessageError(&PayloadError{Err: errors.New("unexpected EOF")}))
+	assert.True(IsPoisonMessageError(fmt.Errorf("Unable to validate schema ID 9: %w", ErrSchemaNotFound)))
+
+	// Retryable
+	assert.False(IsPoisonMessageError(&SchemaRegistryError{SchemaID: 1, Err: errors.New("connection refused")}))
+	assert.False(IsPoisonMessageError(errors.New("connection refused")))
+}
+
+func TestHandleProcessErrorRegistryOutage(t *testing.T) {
+	assert := assert.New(t)
+
+	// Fatal panics instead of exiting
+	defer zap.ReplaceGlobals(zap.NewNop().WithOptions(zap.OnFatal(zapcore.WriteThenPanic)))()
+
+	// Registry is down for every lookup
+	decoder := &ProtobufDecoder{Registry: &flakySchemaRegistryClient{registry: newTestSchemaRegistryClient(t), failures: 100}}
+
+	msg := newTopicMessage(&sarama.ConsumerMessage{
+		Topic:  "blocks",
+		Offset: 1,
+		Value:  []byte{0x0, 0x0, 0x0, 0x0, 0x1, 0x0, 0x8, 0x64},
+	})
+
+	err := decoder.Decode(msg.Value, &models.BlockRaw{})
+	assert.NotEqual(nil, err)
+
+	// Stops before the dead letter topic
+	func() {
+		defer func() {
+			assert.Contains(fmt.Sprint(recover()), "stopping without committing")
+		}()
+
+		HandleProcessError(msg, err)
+	}()
+
+	// Not processed, offset is never marked
+	assert.False(isTopicMessageDone(msg))
+}
@@ -0,0 +1,260 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-addresses/config"
+)
+
+// Decoder - decode kafka message values into models
+type Decoder interface {
+	Decode(value []byte, message proto.Message) error
+}
+
+// SchemaMismatchError - schema ID in header does not match the decoder
+type SchemaMismatchError struct {
+	SchemaID   uint32
+	SchemaType string
+	Expected   string
+}
+
+func (e *SchemaMismatchError) Error() string {
+	return fmt.Sprintf(
+		"Schema mismatch: schema ID %d is %s, expected %s",
+		e.SchemaID,
+		e.SchemaType,
+		e.Expected,
+	)
+}
+
+// SchemaRegistryError - schema registry could not be reached
+// NOTE transient, the message is retried rather than dead lettered
+type SchemaRegistryError struct {
+	SchemaID uint32
+	Err      error
+}
+
+func (e *SchemaRegistryError) Error() string {
+	return fmt.Sprintf("Unable to validate schema ID %d: %s", e.SchemaID, e.Err.Error())
+}
+
+func (e *SchemaRegistryError) Unwrap() error {
+	return e.Err
+}
+
+// PayloadError - message payload cannot be decoded
+type PayloadError struct {
+	Err error
+}
+
+func (e *PayloadError) Error() string {
+	return "Invalid payload: " + e.Err.Error()
+}
+
+func (e *PayloadError) Unwrap() error {
+	return e.Err
+}
+
+// validateSchemaID - check schema ID is registered with the expected type
+// NOTE a nil registry skips validation
+// NOTE registry errors other than ErrSchemaNotFound are retried with backoff, SCHEMA_REGISTRY_RETRIES times
+func validateSchemaID(registry SchemaRegistryClient, schemaID uint32, schemaType string) error {
+	if registry == nil {
+		return nil
+	}
+
+	backoff := time.Duration(config.Config.SchemaRegistryRetryBackoffMilliseconds) * time.Millisecond
+
+	var schema *Schema
+	for attempt := 0; ; attempt++ {
+		var err error
+		schema, err = registry.GetSchemaByID(schemaID)
+		if err == nil {
+			break
+		} else if errors.Is(err, ErrSchemaNotFound) {
+			return fmt.Errorf("Unable to validate schema ID %d: %w", schemaID, err)
+		} else if attempt >= config.Config.SchemaRegistryRetries {
+			return &SchemaRegistryError{
+				SchemaID: schemaID,
+				Err:      err,
+			}
+		}
+
+		zap.S().Warn("Unable to reach schema registry for schema ID ", schemaID, ", retrying in ", backoff, " - Error: ", err.Error())
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	if schema.SchemaType != schemaType {
+		return &SchemaMismatchError{
+			SchemaID:   schemaID,
+			SchemaType: schema.SchemaType,
+			Expected:   schemaType,
+		}
+	}
+
+	return nil
+}
+
+//////////////
+// Protobuf //
+//////////////
+
+// ProtobufDecoder - Confluent wire format protobuf
+type ProtobufDecoder struct {
+	Registry SchemaRegistryClient
+}
+
+func (d *ProtobufDecoder) Decode(value []byte, message proto.Message) error {
+	header, payload, err := ParseWireFormat(value, true)
+	if err != nil {
+		return err
+	}
+
+	err = validateSchemaID(d.Registry, header.SchemaID, "PROTOBUF")
+	if err != nil {
+		return err
+	}
+
+	err = proto.Unmarshal(payload, message)
+	if err != nil {
+		return &PayloadError{Err: err}
+	}
+
+	return nil
+}
+
+//////////
+// JSON //
+//////////
+
+// JSONDecoder - Confluent wire format JSON, or JSON without a header
+type JSONDecoder struct {
+	Registry SchemaRegistryClient
+}
+
+func (d *JSONDecoder) Decode(value []byte, message proto.Message) error {
+	payload := value
+
+	// JSON cannot start with the magic byte
+	if len(value) > 0 && value[0] == wireFormatMagicByte {
+		header, headerPayload, err := ParseWireFormat(value, false)
+		if err != nil {
+			return err
+		}
+
+		err = validateSchemaID(d.Registry, header.SchemaID, "JSON")
+		if err != nil {
+			return err
+		}
+
+		payload = headerPayload
+	}
+
+	err := protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(payload, message)
+	if err != nil {
+		return &PayloadError{Err: err}
+	}
+
+	return nil
+}
+
+///////////////////
+// Topic Formats //
+///////////////////
+
+var schemaRegistryClient SchemaRegistryClient
+var schemaRegistryClientOnce sync.Once
+
+// GetSchemaRegistryClient - create and/or return the configured registry client
+// Returns nil if validation is disabled
+func GetSchemaRegistryClient() SchemaRegistryClient {
+	schemaRegistryClientOnce.Do(func() {
+		if config.Config.SchemaRegistryValidate == false {
+			return
+		}
+
+		if config.Config.SchemaRegistryFile != "" {
+			var err error
+			schemaRegistryClient, err = NewFileSchemaRegistryClient(config.Config.SchemaRegistryFile)
+			if err != nil {
+				zap.S().Fatal("Unable to read schema registry file: ", err.Error())
+			}
+			return
+		}
+
+		schemaRegistryClient = NewHTTPSchemaRegistryClient(config.Config.SchemaRegistryURL)
+	})
+
+	return schemaRegistryClient
+}
+
+var topicDecoders map[string]Decoder
+var topicDecodersOnce sync.Once
+
+// GetDecoder - return decoder for a topic
+// Formats are set with CONSUMER_TOPIC_FORMATS, default protobuf
+func GetDecoder(topic string) Decoder {
+	topicDecodersOnce.Do(func() {
+		topicDecoders = map[string]Decoder{}
+
+		topicFormats, err := parseTopicFormats(config.Config.ConsumerTopicFormats)
+		if err != nil {
+			zap.S().Fatal("Unable to parse topic formats: ", err.Error())
+		}
+
+		for topicName, format := range topicFormats {
+			topicDecoders[topicName] = newDecoder(format)
+		}
+	})
+
+	decoder, ok := topicDecoders[topic]
+	if ok == false {
+		return newDecoder("protobuf")
+	}
+
+	return decoder
+}
+
+func newDecoder(format string) Decoder {
+	switch format {
+	case "json":
+		return &JSONDecoder{Registry: GetSchemaRegistryClient()}
+	default:
+		return &ProtobufDecoder{Registry: GetSchemaRegistryClient()}
+	}
+}
+
+// parseTopicFormats - parse "topic:format,topic:format"
+func parseTopicFormats(topicFormatsString string) (map[string]string, error) {
+	topicFormats := map[string]string{}
+
+	if topicFormatsString == "" {
+		return topicFormats, nil
+	}
+
+	for _, topicFormat := range strings.Split(topicFormatsString, ",") {
+		topicFormatSplit := strings.Split(strings.TrimSpace(topicFormat), ":")
+		if len(topicFormatSplit) != 2 {
+			return nil, fmt.Errorf("invalid topic format %q, expected topic:format", topicFormat)
+		}
+
+		topicName := topicFormatSplit[0]
+		format := topicFormatSplit[1]
+		if format != "protobuf" && format != "json" {
+			return nil, fmt.Errorf("invalid format %q for topic %s, expected protobuf or json", format, topicName)
+		}
+
+		topicFormats[topicName] = format
+	}
+
+	return topicFormats, nil
+}
@@ -0,0 +1,195 @@
+package kafka
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func newTestSchemaRegistryClient(t *testing.T) SchemaRegistryClient {
+	registry, err := NewFileSchemaRegistryClient("testdata/schema_registry.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return registry
+}
+
+func TestParseWireFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	// Message index shorthand
+	header, payload, err := ParseWireFormat([]byte{0x0, 0x0, 0x0, 0x1, 0x2, 0x0, 0xa}, true)
+	assert.Equal(nil, err)
+	assert.Equal(uint32(258), header.SchemaID)
+	assert.Equal([]int64{0}, header.MessageIndexes)
+	assert.Equal([]byte{0xa}, payload)
+
+	// Message indexes [1, 2] as zigzag varints
+	header, payload, err = ParseWireFormat([]byte{0x0, 0x0, 0x0, 0x0, 0x1, 0x4, 0x2, 0x4, 0xa}, true)
+	assert.Equal(nil, err)
+	assert.Equal([]int64{1, 2}, header.MessageIndexes)
+	assert.Equal([]byte{0xa}, payload)
+
+	// No message indexes
+	header, payload, err = ParseWireFormat([]byte{0x0, 0x0, 0x0, 0x0, 0x2, '{', '}'}, false)
+	assert.Equal(nil, err)
+	assert.Equal(uint32(2), header.SchemaID)
+	assert.Equal([]byte("{}"), payload)
+
+	// Malformed
+	malformedValues := [][]byte{
+		{},
+		{0x0, 0x0, 0x0},
+		{0x1, 0x0, 0x0, 0x0, 0x1, 0x0},
+		{0x0, 0x0, 0x0, 0x0, 0x1},
+		{0x0, 0x0, 0x0, 0x0, 0x1, 0x8, 0x2},
+	}
+	for _, value := range malformedValues {
+		_, _, err = ParseWireFormat(value, true)
+
+		var wireFormatErr *WireFormatError
+		assert.True(errors.As(err, &wireFormatErr), "value=%v", value)
+	}
+}
+
+func TestProtobufDecoder(t *testing.T) {
+	assert := assert.New(t)
+
+	decoder := &ProtobufDecoder{Registry: newTestSchemaRegistryClient(t)}
+
+	payload, err := proto.Marshal(&models.BlockRaw{Number: 100})
+	assert.Equal(nil, err)
+
+	// Valid
+	block := &models.BlockRaw{}
+	err = decoder.Decode(append([]byte{0x0, 0x0, 0x0, 0x0, 0x1, 0x0}, payload...), block)
+	assert.Equal(nil, err)
+	assert.Equal(uint32(100), block.Number)
+
+	// Unknown schema ID
+	err = decoder.Decode(append([]byte{0x0, 0x0, 0x0, 0x0, 0x9, 0x0}, payload...), block)
+	assert.True(errors.Is(err, ErrSchemaNotFound))
+
+	// Wrong schema type
+	err = decoder.Decode(append([]byte{0x0, 0x0, 0x0, 0x0, 0x2, 0x0}, payload...), block)
+	var schemaMismatchErr *SchemaMismatchError
+	assert.True(errors.As(err, &schemaMismatchErr))
+
+	// Malformed header
+	err = decoder.Decode([]byte{0x0, 0x0}, block)
+	var wireFormatErr *WireFormatError
+	assert.True(errors.As(err, &wireFormatErr))
+
+	// Malformed payload
+	err = decoder.Decode([]byte{0x0, 0x0, 0x0, 0x0, 0x1, 0x0, 0xff}, block)
+	var payloadErr *PayloadError
+	assert.True(errors.As(err, &payloadErr))
+}
+
+// Registry that is unreachable for the first lookups
+type flakySchemaRegistryClient struct {
+	registry SchemaRegistryClient
+	failures int
+	calls    int
+}
+
+func (r *flakySchemaRegistryClient) GetSchemaByID(id uint32) (*Schema, error) {
+	r.calls++
+	if r.calls <= r.failures {
+		return nil, errors.New("connection refused")
+	}
+
+	return r.registry.GetSchemaByID(id)
+}
+
+func TestDecoderSchemaRegistryErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.SchemaRegistryRetries = 2
+	config.Config.SchemaRegistryRetryBackoffMilliseconds = 1
+	defer func() {
+		config.Config.SchemaRegistryRetries = 0
+		config.Config.SchemaRegistryRetryBackoffMilliseconds = 0
+	}()
+
+	payload, err := proto.Marshal(&models.BlockRaw{Number: 100})
+	assert.Equal(nil, err)
+	value := append([]byte{0x0, 0x0, 0x0, 0x0, 0x1, 0x0}, payload...)
+
+	// Recovers within the retries
+	registry := &flakySchemaRegistryClient{registry: newTestSchemaRegistryClient(t), failures: 2}
+	decoder := &ProtobufDecoder{Registry: registry}
+
+	block := &models.BlockRaw{}
+	err = decoder.Decode(value, block)
+	assert.Equal(nil, err)
+	assert.Equal(uint32(100), block.Number)
+	assert.Equal(3, registry.calls)
+
+	// Unreachable, retryable
+	registry = &flakySchemaRegistryClient{registry: newTestSchemaRegistryClient(t), failures: 3}
+	decoder = &ProtobufDecoder{Registry: registry}
+
+	err = decoder.Decode(value, block)
+	var schemaRegistryErr *SchemaRegistryError
+	assert.True(errors.As(err, &schemaRegistryErr))
+	assert.Equal(uint32(1), schemaRegistryErr.SchemaID)
+	assert.Equal(3, registry.calls)
+
+	// Not found is not retried
+	registry = &flakySchemaRegistryClient{registry: newTestSchemaRegistryClient(t)}
+	decoder = &ProtobufDecoder{Registry: registry}
+
+	err = decoder.Decode(append([]byte{0x0, 0x0, 0x0, 0x0, 0x9, 0x0}, payload...), block)
+	assert.True(errors.Is(err, ErrSchemaNotFound))
+	assert.False(errors.As(err, &schemaRegistryErr))
+	assert.Equal(1, registry.calls)
+}
+
+func TestJSONDecoder(t *testing.T) {
+	assert := assert.New(t)
+
+	decoder := &JSONDecoder{Registry: newTestSchemaRegistryClient(t)}
+
+	// With header
+	block := &models.BlockRaw{}
+	err := decoder.Decode(append([]byte{0x0, 0x0, 0x0, 0x0, 0x2}, []byte(`{"number": 100}`)...), block)
+	assert.Equal(nil, err)
+	assert.Equal(uint32(100), block.Number)
+
+	// Without header
+	block = &models.BlockRaw{}
+	err = decoder.Decode([]byte(`{"number": 200, "unknown_field": 1}`), block)
+	assert.Equal(nil, err)
+	assert.Equal(uint32(200), block.Number)
+
+	// Wrong schema type
+	err = decoder.Decode(append([]byte{0x0, 0x0, 0x0, 0x0, 0x1}, []byte(`{"number": 100}`)...), block)
+	var schemaMismatchErr *SchemaMismatchError
+	assert.True(errors.As(err, &schemaMismatchErr))
+
+	// Malformed payload
+	err = decoder.Decode([]byte(`{"number": `), block)
+	var payloadErr *PayloadError
+	assert.True(errors.As(err, &payloadErr))
+}
+
+func TestParseTopicFormats(t *testing.T) {
+	assert := assert.New(t)
+
+	topicFormats, err := parseTopicFormats("blocks:protobuf, logs:json")
+	assert.Equal(nil, err)
+	assert.Equal(map[string]string{"blocks": "protobuf", "logs": "json"}, topicFormats)
+
+	_, err = parseTopicFormats("blocks:avro")
+	assert.NotEqual(nil, err)
+
+	_, err = parseTopicFormats("blocks")
+	assert.NotEqual(nil, err)
+}
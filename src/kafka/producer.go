@@ -0,0 +1,51 @@
+package kafka
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+)
+
+var kafkaProducer sarama.SyncProducer
+var kafkaProducerOnce sync.Once
+
+// GetKafkaProducer - create and/or return the kafka producer
+func GetKafkaProducer() sarama.SyncProducer {
+	kafkaProducerOnce.Do(func() {
+		version, err := sarama.ParseKafkaVersion("2.1.1")
+		if err != nil {
+			zap.S().Panic("PRODUCER ERROR: parsing Kafka version: ", err.Error())
+		}
+
+		/////////////////////
+		// Producer Config //
+		/////////////////////
+
+		saramaConfig := sarama.NewConfig()
+
+		// Version
+		// NOTE headers require >= 0.11
+		saramaConfig.Version = version
+
+		// Required for sync producer
+		saramaConfig.Producer.Return.Successes = true
+		saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+
+		for {
+			kafkaProducer, err = sarama.NewSyncProducer([]string{config.Config.KafkaBrokerURL}, saramaConfig)
+			if err != nil {
+				zap.S().Warn("Creating producer err: ", err.Error())
+				zap.S().Info("Retrying in 3 seconds...")
+				time.Sleep(3 * time.Second)
+				continue
+			}
+			break
+		}
+	})
+
+	return kafkaProducer
+}
@@ -0,0 +1,154 @@
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Schema - schema registered under an ID
+type Schema struct {
+	// PROTOBUF, JSON, or AVRO
+	SchemaType string `json:"schemaType"`
+	Schema     string `json:"schema"`
+}
+
+// SchemaRegistryClient - lookup schemas by ID
+type SchemaRegistryClient interface {
+	GetSchemaByID(id uint32) (*Schema, error)
+}
+
+// ErrSchemaNotFound - schema ID is not in the registry
+var ErrSchemaNotFound = errors.New("Schema not found")
+
+//////////////////////////
+// HTTP Schema Registry //
+//////////////////////////
+
+type httpSchemaRegistryClient struct {
+	url        string
+	httpClient *http.Client
+
+	// Schema ID -> Schema
+	// NOTE schemas are immutable once registered
+	cache      map[uint32]*Schema
+	cacheMutex sync.RWMutex
+}
+
+// NewHTTPSchemaRegistryClient - client for a Confluent Schema Registry
+func NewHTTPSchemaRegistryClient(url string) SchemaRegistryClient {
+	if strings.HasPrefix(url, "http://") == false && strings.HasPrefix(url, "https://") == false {
+		url = "http://" + url
+	}
+
+	return &httpSchemaRegistryClient{
+		url:        strings.TrimSuffix(url, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      map[uint32]*Schema{},
+	}
+}
+
+func (r *httpSchemaRegistryClient) GetSchemaByID(id uint32) (*Schema, error) {
+
+	// Check cache
+	r.cacheMutex.RLock()
+	schema, ok := r.cache[id]
+	r.cacheMutex.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	// Execute request
+	res, err := r.httpClient.Get(r.url + "/schemas/ids/" + strconv.FormatUint(uint64(id), 10))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Read body
+	bodyString, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code
+	if res.StatusCode == 404 {
+		return nil, ErrSchemaNotFound
+	} else if res.StatusCode != 200 {
+		return nil, errors.New(
+			"StatusCode=" + strconv.Itoa(res.StatusCode) +
+				",SchemaID=" + strconv.FormatUint(uint64(id), 10) +
+				",Response=" + string(bodyString),
+		)
+	}
+
+	// Parse body
+	schema = &Schema{}
+	err = json.Unmarshal(bodyString, schema)
+	if err != nil {
+		return nil, err
+	}
+	if schema.SchemaType == "" {
+		// Registry omits the type for avro
+		schema.SchemaType = "AVRO"
+	}
+
+	r.cacheMutex.Lock()
+	r.cache[id] = schema
+	r.cacheMutex.Unlock()
+
+	return schema, nil
+}
+
+//////////////////////////
+// File Schema Registry //
+//////////////////////////
+
+type fileSchemaRegistryClient struct {
+	// Schema ID -> Schema
+	schemas map[uint32]*Schema
+}
+
+// NewFileSchemaRegistryClient - local stand-in for a schema registry
+// File is a JSON object of schema ID to schema:
+// {"1": {"schemaType": "PROTOBUF", "schema": "..."}}
+func NewFileSchemaRegistryClient(path string) (SchemaRegistryClient, error) {
+	fileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSchemas := map[string]*Schema{}
+	err = json.Unmarshal(fileBytes, &fileSchemas)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := map[uint32]*Schema{}
+	for idString, schema := range fileSchemas {
+		id, err := strconv.ParseUint(idString, 10, 32)
+		if err != nil {
+			return nil, errors.New("Invalid schema ID in schema registry file: " + idString)
+		}
+
+		schemas[uint32(id)] = schema
+	}
+
+	return &fileSchemaRegistryClient{
+		schemas: schemas,
+	}, nil
+}
+
+func (r *fileSchemaRegistryClient) GetSchemaByID(id uint32) (*Schema, error) {
+	schema, ok := r.schemas[id]
+	if ok == false {
+		return nil, ErrSchemaNotFound
+	}
+
+	return schema, nil
+}
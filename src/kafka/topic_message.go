@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"sync"
+
+	"github.com/Shopify/sarama"
+)
+
+// TopicMessage - consumed message and the loads it is waiting on
+// NOTE offsets are only marked once the message is done
+type TopicMessage struct {
+	*sarama.ConsumerMessage
+
+	pending       sync.WaitGroup
+	processedOnce sync.Once
+	done          chan struct{}
+}
+
+func newTopicMessage(msg *sarama.ConsumerMessage) *TopicMessage {
+	return &TopicMessage{
+		ConsumerMessage: msg,
+		done:            make(chan struct{}),
+	}
+}
+
+// Track - register a load for the message
+// Returns the callback to run once the load is persisted
+// NOTE must be called before Processed
+func (m *TopicMessage) Track() func() {
+	m.pending.Add(1)
+
+	var ackOnce sync.Once
+	return func() {
+		ackOnce.Do(m.pending.Done)
+	}
+}
+
+// Processed - all loads have been sent
+// The message is done once every tracked load is acked
+func (m *TopicMessage) Processed() {
+	m.processedOnce.Do(func() {
+		go func() {
+			m.pending.Wait()
+			close(m.done)
+		}()
+	})
+}
+
+// Done - closed when the message is done
+func (m *TopicMessage) Done() <-chan struct{} {
+	return m.done
+}
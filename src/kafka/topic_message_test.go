@@ -0,0 +1,50 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+)
+
+func isTopicMessageDone(msg *TopicMessage) bool {
+	select {
+	case <-msg.Done():
+		return true
+	case <-time.After(100 * time.Millisecond):
+		return false
+	}
+}
+
+func TestTopicMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	msg := newTopicMessage(&sarama.ConsumerMessage{Topic: "logs", Offset: 1})
+
+	ackFrom := msg.Track()
+	ackTo := msg.Track()
+
+	// Not processed yet
+	ackFrom()
+	ackTo()
+	assert.False(isTopicMessageDone(msg))
+
+	msg = newTopicMessage(&sarama.ConsumerMessage{Topic: "logs", Offset: 2})
+	ackFrom = msg.Track()
+	ackTo = msg.Track()
+	msg.Processed()
+
+	// Waiting on loads
+	ackFrom()
+	ackFrom() // NOTE acks are idempotent
+	assert.False(isTopicMessageDone(msg))
+
+	ackTo()
+	assert.True(isTopicMessageDone(msg))
+
+	// No loads
+	msg = newTopicMessage(&sarama.ConsumerMessage{Topic: "logs", Offset: 3})
+	msg.Processed()
+	assert.True(isTopicMessageDone(msg))
+}
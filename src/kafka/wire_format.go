@@ -0,0 +1,89 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Confluent wire format
+// https://docs.confluent.io/platform/current/schema-registry/serdes-develop/index.html#wire-format
+// 0     - magic byte, always 0
+// 1-4   - schema ID, big endian
+// 5-... - protobuf only, message indexes as zigzag varints (count then indexes)
+//         a single 0 byte is shorthand for [0]
+// ...   - payload
+const wireFormatMagicByte byte = 0x0
+
+// WireFormatHeader - parsed Confluent wire format header
+type WireFormatHeader struct {
+	SchemaID       uint32
+	MessageIndexes []int64
+}
+
+// WireFormatError - malformed Confluent wire format header
+type WireFormatError struct {
+	Reason string
+}
+
+func (e *WireFormatError) Error() string {
+	return "Invalid wire format: " + e.Reason
+}
+
+// ParseWireFormat - parse header and return the payload
+// Set hasMessageIndexes for protobuf payloads
+func ParseWireFormat(value []byte, hasMessageIndexes bool) (*WireFormatHeader, []byte, error) {
+
+	// Magic byte + schema ID
+	if len(value) < 5 {
+		return nil, nil, &WireFormatError{
+			Reason: fmt.Sprintf("message is %d bytes, header requires at least 5", len(value)),
+		}
+	}
+	if value[0] != wireFormatMagicByte {
+		return nil, nil, &WireFormatError{
+			Reason: fmt.Sprintf("unknown magic byte 0x%x", value[0]),
+		}
+	}
+
+	header := &WireFormatHeader{
+		SchemaID:       binary.BigEndian.Uint32(value[1:5]),
+		MessageIndexes: []int64{},
+	}
+	offset := 5
+
+	if hasMessageIndexes == false {
+		return header, value[offset:], nil
+	}
+
+	// Message indexes
+	indexCount, n := binary.Varint(value[offset:])
+	if n <= 0 {
+		return nil, nil, &WireFormatError{Reason: "unable to read message index count"}
+	}
+	offset += n
+
+	if indexCount == 0 {
+		// Shorthand for first message in schema
+		header.MessageIndexes = []int64{0}
+		return header, value[offset:], nil
+	}
+	if indexCount < 0 || indexCount > int64(len(value)-offset) {
+		return nil, nil, &WireFormatError{
+			Reason: fmt.Sprintf("invalid message index count %d", indexCount),
+		}
+	}
+
+	for i := int64(0); i < indexCount; i++ {
+		index, n := binary.Varint(value[offset:])
+		if n <= 0 {
+			return nil, nil, &WireFormatError{
+				Reason: fmt.Sprintf("unable to read message index %d", i),
+			}
+		}
+		offset += n
+
+		header.MessageIndexes = append(header.MessageIndexes, index)
+	}
+
+	return header, value[offset:], nil
+}
@@ -17,12 +17,12 @@ func Init() {
 		cfg := newLoggerConfig()
 
 		logger := newLogger(cfg)
-		defer logger.Sync()
+		zap.ReplaceGlobals(logger)
 
-		undo := zap.ReplaceGlobals(logger)
-		defer undo()
-
-		global.WaitShutdownSig()
+		// Flush on shutdown
+		// NOTE keep the logger in place while draining
+		<-global.ShutdownContext().Done()
+		logger.Sync()
 	}()
 }
 
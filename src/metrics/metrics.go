@@ -38,6 +38,41 @@ var (
 		Help:        "Number of addresses the balance routine has computed in current run",
 		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
 	})
+	DeadLetterMessagesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:        "dead_letter_messages_total",
+		Help:        "Number of kafka messages sent to the dead letter topic",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"topic"})
+	BlockReorgsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name:        "block_reorgs_total",
+		Help:        "Number of chain reorganizations rolled back",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	BalanceRefreshAddressesComputedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name:        "balance_refresh_addresses_computed_total",
+		Help:        "Number of dirty addresses the balance refresh routine has computed",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	DirtyAddressesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:        "dirty_addresses",
+		Help:        "Number of addresses waiting for a balance refresh",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	BalanceDiscrepanciesGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:        "balance_discrepancies",
+		Help:        "Number of addresses whose balance ledger does not match the node",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
+	BalanceBuilderBlockNumberGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:        "balance_builder_block_number",
+		Help:        "Last block number completed by each balance builder shard",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	}, []string{"shard"})
+	AddressDailyStatBuilderBlockNumberGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name:        "address_daily_stat_builder_block_number",
+		Help:        "Last block number completed by the address daily stat builder",
+		ConstLabels: prometheus.Labels{"network_name": config.Config.NetworkName},
+	})
 )
 
 func Start() {
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/logging"
+	"github.com/geometry-labs/icon-addresses/migrations"
+)
+
+// Migrate - apply, revert or list schema migrations
+// Usage: migrate up | migrate down [-steps 1] | migrate status
+func main() {
+	steps := flag.Int("steps", 1, "number of migrations to revert with down")
+	flag.Parse()
+
+	config.ReadEnvironment()
+
+	logging.Init()
+	log.Printf("Main: Starting logging with level %s", config.Config.LogLevel)
+
+	sqlDB, err := crud.GetPostgresConn().DB()
+	if err != nil {
+		zap.S().Fatal("Unable to get postgres connection: ", err.Error())
+	}
+
+	migrator, err := migrations.NewMigrator(sqlDB)
+	if err != nil {
+		zap.S().Fatal("Unable to load migrations: ", err.Error())
+	}
+
+	switch flag.Arg(0) {
+	case "up":
+		count, err := migrator.Up()
+		if err != nil {
+			zap.S().Fatal("Unable to apply migrations: ", err.Error())
+		}
+
+		zap.S().Info("Migrate: Applied ", count, " migrations")
+	case "down":
+		count, err := migrator.Down(*steps)
+		if err != nil {
+			zap.S().Fatal("Unable to revert migrations: ", err.Error())
+		}
+
+		zap.S().Info("Migrate: Reverted ", count, " migrations")
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			zap.S().Fatal("Unable to get migration status: ", err.Error())
+		}
+
+		for _, status := range statuses {
+			state := "pending"
+			if status.IsApplied == true {
+				state = fmt.Sprintf("applied at %d", status.AppliedTimestamp)
+			}
+
+			fmt.Printf("%04d_%s - %s\n", status.Version, status.Name, state)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, "Usage: migrate up | migrate down [-steps 1] | migrate status")
+		os.Exit(2)
+	}
+}
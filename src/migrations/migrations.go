@@ -0,0 +1,309 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//go:embed sql/*.sql
+var migrationFiles embed.FS // <version>_<name>.<up|down>.sql
+
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// NOTE any constant shared by every process running migrations on the database
+const migrationLockID = 4318203741
+
+// Migration - versioned schema change
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus - migration and whether it is applied
+type MigrationStatus struct {
+	Migration
+	IsApplied        bool
+	AppliedTimestamp uint64
+}
+
+// GetMigrations - all migrations, ordered by version
+func GetMigrations() ([]Migration, error) {
+	return parseMigrations(migrationFiles, "sql")
+}
+
+func parseMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrationsByVersion := map[uint64]*Migration{}
+	for _, file := range files {
+		match := migrationFileRegex.FindStringSubmatch(file.Name())
+		if match == nil {
+			return nil, fmt.Errorf("Invalid migration file name: %s", file.Name())
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid migration version: %s", file.Name())
+		}
+
+		body, err := fs.ReadFile(fsys, path.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := migrationsByVersion[version]
+		if ok == false {
+			migration = &Migration{
+				Version: version,
+				Name:    match[2],
+			}
+			migrationsByVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("Duplicate migration version: %d", version)
+		}
+
+		switch match[3] {
+		case "up":
+			migration.Up = string(body)
+		case "down":
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, migration := range migrationsByVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("Migration %d_%s needs both up and down files", migration.Version, migration.Name)
+		}
+
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Migrator - applies migrations to a database
+// NOTE a postgres advisory lock keeps api and worker processes from migrating at the same time
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator - create a migrator with the embedded migrations
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := GetMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+	}, nil
+}
+
+// Up - apply all pending migrations
+// Returns the number of migrations applied
+func (m *Migrator) Up() (int, error) {
+	count := 0
+
+	err := m.withLock(func(conn *sql.Conn) error {
+		appliedTimestamps, err := m.selectApplied(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := appliedTimestamps[migration.Version]; ok == true {
+				continue
+			}
+
+			zap.S().Info("Migrate: Applying ", migration.Version, "_", migration.Name)
+
+			err = m.apply(conn, migration.Up, func(tx *sql.Tx) error {
+				_, err := tx.Exec(
+					"INSERT INTO schema_migrations (version, name, applied_timestamp) VALUES ($1, $2, $3)",
+					migration.Version,
+					migration.Name,
+					uint64(time.Now().UnixNano()/1000),
+				)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("Migration %d_%s failed: %s", migration.Version, migration.Name, err.Error())
+			}
+
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Down - revert the latest applied migrations
+// Returns the number of migrations reverted
+func (m *Migrator) Down(steps int) (int, error) {
+	count := 0
+
+	err := m.withLock(func(conn *sql.Conn) error {
+		appliedTimestamps, err := m.selectApplied(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := appliedTimestamps[migration.Version]; ok == false {
+				continue
+			}
+
+			zap.S().Info("Migrate: Reverting ", migration.Version, "_", migration.Name)
+
+			err = m.apply(conn, migration.Down, func(tx *sql.Tx) error {
+				_, err := tx.Exec(
+					"DELETE FROM schema_migrations WHERE version = $1",
+					migration.Version,
+				)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("Migration %d_%s revert failed: %s", migration.Version, migration.Name, err.Error())
+			}
+
+			count++
+		}
+
+		return nil
+	})
+
+	return count, err
+}
+
+// Status - all migrations and whether they are applied
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	statuses := []MigrationStatus{}
+
+	err := m.withLock(func(conn *sql.Conn) error {
+		appliedTimestamps, err := m.selectApplied(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			appliedTimestamp, ok := appliedTimestamps[migration.Version]
+
+			statuses = append(statuses, MigrationStatus{
+				Migration:        migration,
+				IsApplied:        ok,
+				AppliedTimestamp: appliedTimestamp,
+			})
+		}
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withLock - run on a single connection holding the migration lock
+// NOTE advisory locks are held by the session, so every statement uses the same connection
+func (m *Migrator) withLock(run func(conn *sql.Conn) error) error {
+	ctx := context.Background()
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID)
+		if err != nil {
+			zap.S().Warn("Migrate: Unable to release lock: ", err.Error())
+		}
+	}()
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint,
+		name text,
+		applied_timestamp bigint,
+		PRIMARY KEY (version)
+	)`)
+	if err != nil {
+		return err
+	}
+
+	return run(conn)
+}
+
+// selectApplied - applied timestamps by version
+func (m *Migrator) selectApplied(conn *sql.Conn) (map[uint64]uint64, error) {
+	rows, err := conn.QueryContext(context.Background(), "SELECT version, applied_timestamp FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appliedTimestamps := map[uint64]uint64{}
+	for rows.Next() {
+		var version uint64
+		var appliedTimestamp uint64
+
+		err = rows.Scan(&version, &appliedTimestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		appliedTimestamps[version] = appliedTimestamp
+	}
+
+	return appliedTimestamps, rows.Err()
+}
+
+// apply - run migration sql and record it in one transaction
+func (m *Migrator) apply(conn *sql.Conn, body string, record func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(body)
+	if err == nil {
+		err = record(tx)
+	}
+	if err != nil {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && errors.Is(rollbackErr, sql.ErrTxDone) == false {
+			zap.S().Warn("Migrate: Unable to rollback: ", rollbackErr.Error())
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMigrations(t *testing.T) {
+	assert := assert.New(t)
+
+	migrations, err := GetMigrations()
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(migrations))
+
+	for i, migration := range migrations {
+		if i > 0 {
+			assert.Less(migrations[i-1].Version, migration.Version)
+		}
+	}
+}
+
+func TestParseMigrations(t *testing.T) {
+	assert := assert.New(t)
+
+	fsys := fstest.MapFS{
+		"sql/0002_second.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+		"sql/0002_second.down.sql": {Data: []byte("DROP TABLE b;")},
+		"sql/0001_first.up.sql":    {Data: []byte("CREATE TABLE a ();")},
+		"sql/0001_first.down.sql":  {Data: []byte("DROP TABLE a;")},
+	}
+
+	migrations, err := parseMigrations(fsys, "sql")
+	assert.Equal(nil, err)
+	assert.Equal(2, len(migrations))
+	assert.Equal(uint64(1), migrations[0].Version)
+	assert.Equal("first", migrations[0].Name)
+	assert.Equal("CREATE TABLE a ();", migrations[0].Up)
+	assert.Equal("DROP TABLE a;", migrations[0].Down)
+	assert.Equal(uint64(2), migrations[1].Version)
+
+	// Missing down
+	delete(fsys, "sql/0002_second.down.sql")
+	_, err = parseMigrations(fsys, "sql")
+	assert.NotEqual(nil, err)
+
+	// Duplicate version
+	fsys["sql/0002_second.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE b;")}
+	fsys["sql/0002_other.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE c ();")}
+	_, err = parseMigrations(fsys, "sql")
+	assert.NotEqual(nil, err)
+
+	// Invalid name
+	delete(fsys, "sql/0002_other.up.sql")
+	fsys["sql/notes.txt"] = &fstest.MapFile{Data: []byte("")}
+	_, err = parseMigrations(fsys, "sql")
+	assert.NotEqual(nil, err)
+}
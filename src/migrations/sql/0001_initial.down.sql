@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS kafka_jobs;
+DROP TABLE IF EXISTS failed_messages;
+DROP TABLE IF EXISTS transaction_count_by_public_key_indices;
+DROP TABLE IF EXISTS transaction_count_by_public_keys;
+DROP TABLE IF EXISTS transaction_count_by_block_numbers;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS log_count_by_public_key_indices;
+DROP TABLE IF EXISTS log_count_by_public_keys;
+DROP TABLE IF EXISTS log_count_by_block_numbers;
+DROP TABLE IF EXISTS governance_prep_processeds;
+DROP TABLE IF EXISTS contract_processeds;
+DROP TABLE IF EXISTS contract_counts;
+DROP TABLE IF EXISTS blocks;
+DROP TABLE IF EXISTS token_balances;
+DROP TABLE IF EXISTS balances;
+DROP TABLE IF EXISTS address_tokens;
+DROP TABLE IF EXISTS address_token_count_indices;
+DROP TABLE IF EXISTS address_contract_count_indices;
+DROP TABLE IF EXISTS address_count_indices;
+DROP TABLE IF EXISTS address_counts;
+DROP TABLE IF EXISTS addresses;
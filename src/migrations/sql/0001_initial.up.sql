@@ -0,0 +1,215 @@
+-- Baseline schema, matches the tables previously created by AutoMigrate
+-- NOTE IF NOT EXISTS so existing databases can be baselined
+
+-- Addresses
+CREATE TABLE IF NOT EXISTS addresses (
+  public_key text,
+  is_contract boolean,
+  transaction_count bigint,
+  log_count bigint,
+  balance decimal,
+  type text,
+  name text,
+  status text,
+  created_timestamp bigint,
+  is_token boolean,
+  is_prep boolean,
+  PRIMARY KEY (public_key)
+);
+CREATE INDEX IF NOT EXISTS address_idx_transaction_count ON addresses (transaction_count);
+CREATE INDEX IF NOT EXISTS address_idx_log_count ON addresses (log_count);
+CREATE INDEX IF NOT EXISTS address_idx_balance ON addresses (balance);
+CREATE INDEX IF NOT EXISTS address_idx_created_timestamp ON addresses (created_timestamp);
+CREATE INDEX IF NOT EXISTS address_idx_is_contract ON addresses (is_contract);
+CREATE INDEX IF NOT EXISTS address_idx_is_token ON addresses (is_token);
+CREATE INDEX IF NOT EXISTS address_idx_is_governance_prep ON addresses (is_prep);
+
+-- Address counts
+CREATE TABLE IF NOT EXISTS address_counts (
+  type text,
+  count bigint,
+  public_key text,
+  PRIMARY KEY (type)
+);
+CREATE INDEX IF NOT EXISTS address_count_idx_public_key ON address_counts (public_key);
+
+CREATE TABLE IF NOT EXISTS address_count_indices (
+  public_key text,
+  PRIMARY KEY (public_key)
+);
+
+CREATE TABLE IF NOT EXISTS address_contract_count_indices (
+  public_key text,
+  PRIMARY KEY (public_key)
+);
+
+CREATE TABLE IF NOT EXISTS address_token_count_indices (
+  public_key text,
+  PRIMARY KEY (public_key)
+);
+
+-- Address tokens
+CREATE TABLE IF NOT EXISTS address_tokens (
+  public_key text,
+  token_contract_address text,
+  PRIMARY KEY (public_key, token_contract_address)
+);
+CREATE INDEX IF NOT EXISTS address_token_idx_token_contract_address ON address_tokens (token_contract_address);
+
+-- Balances
+CREATE TABLE IF NOT EXISTS balances (
+  block_number bigint,
+  transaction_index bigint,
+  log_index integer,
+  public_key text,
+  value text,
+  value_decimal decimal,
+  timestamp bigint,
+  PRIMARY KEY (block_number, transaction_index, log_index, public_key)
+);
+CREATE INDEX IF NOT EXISTS balance_idx_public_key ON balances (public_key);
+CREATE INDEX IF NOT EXISTS balance_idx_timestamp ON balances (timestamp);
+
+-- Token balances
+CREATE TABLE IF NOT EXISTS token_balances (
+  block_number bigint,
+  transaction_index bigint,
+  log_index integer,
+  public_key text,
+  token_contract_address text,
+  value_change text,
+  value text,
+  value_decimal decimal,
+  token_decimals bigint,
+  timestamp bigint,
+  PRIMARY KEY (block_number, transaction_index, log_index, public_key)
+);
+CREATE INDEX IF NOT EXISTS token_balance_idx_public_key ON token_balances (public_key);
+CREATE INDEX IF NOT EXISTS token_balance_idx_token_contract_address ON token_balances (token_contract_address);
+
+-- Blocks
+CREATE TABLE IF NOT EXISTS blocks (
+  number bigint,
+  transaction_count bigint,
+  log_count bigint,
+  PRIMARY KEY (number)
+);
+
+-- Contracts
+CREATE TABLE IF NOT EXISTS contract_counts (
+  id bigserial,
+  public_key text,
+  PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS contract_count_idx_public_key ON contract_counts (public_key);
+
+CREATE TABLE IF NOT EXISTS contract_processeds (
+  address text,
+  name text,
+  created_timestamp bigint,
+  status text,
+  is_token boolean,
+  PRIMARY KEY (address)
+);
+CREATE INDEX IF NOT EXISTS contract_processed_idx_created_timestamp ON contract_processeds (created_timestamp);
+CREATE INDEX IF NOT EXISTS contract_processed_idx_is_token ON contract_processeds (is_token);
+
+-- Governance preps
+CREATE TABLE IF NOT EXISTS governance_prep_processeds (
+  address text,
+  is_prep boolean,
+  PRIMARY KEY (address)
+);
+
+-- Log counts
+CREATE TABLE IF NOT EXISTS log_count_by_block_numbers (
+  transaction_hash text,
+  log_index bigint,
+  count bigint,
+  block_number bigint,
+  max_count_by_transaction bigint,
+  PRIMARY KEY (transaction_hash, log_index)
+);
+CREATE INDEX IF NOT EXISTS log_count_by_block_count_idx_count ON log_count_by_block_numbers (count);
+CREATE INDEX IF NOT EXISTS log_count_by_block_count_idx_block_count ON log_count_by_block_numbers (block_number);
+
+CREATE TABLE IF NOT EXISTS log_count_by_public_keys (
+  public_key text,
+  transaction_hash text,
+  log_index bigint,
+  count bigint,
+  PRIMARY KEY (public_key)
+);
+CREATE INDEX IF NOT EXISTS log_count_by_address_idx_count ON log_count_by_public_keys (count);
+
+CREATE TABLE IF NOT EXISTS log_count_by_public_key_indices (
+  transaction_hash text,
+  log_index bigint,
+  public_key text,
+  PRIMARY KEY (transaction_hash, log_index, public_key)
+);
+
+-- Transactions
+CREATE TABLE IF NOT EXISTS transactions (
+  hash text,
+  log_index integer,
+  from_address text,
+  to_address text,
+  value text,
+  block_number bigint,
+  transaction_fee text,
+  block_timestamp bigint,
+  transaction_index bigint,
+  PRIMARY KEY (hash, log_index)
+);
+CREATE INDEX IF NOT EXISTS transaction_idx_from_address ON transactions (from_address);
+CREATE INDEX IF NOT EXISTS transaction_idx_to_address ON transactions (to_address);
+CREATE INDEX IF NOT EXISTS transaction_idx_block_number ON transactions (block_number);
+
+-- Transaction counts
+CREATE TABLE IF NOT EXISTS transaction_count_by_block_numbers (
+  transaction_hash text,
+  count bigint,
+  block_number bigint,
+  PRIMARY KEY (transaction_hash)
+);
+CREATE INDEX IF NOT EXISTS transaction_count_by_block_number_idx_count ON transaction_count_by_block_numbers (count);
+CREATE INDEX IF NOT EXISTS transaction_count_by_block_count_idx_block_count ON transaction_count_by_block_numbers (block_number);
+
+CREATE TABLE IF NOT EXISTS transaction_count_by_public_keys (
+  public_key text,
+  transaction_hash text,
+  count bigint,
+  PRIMARY KEY (public_key)
+);
+CREATE INDEX IF NOT EXISTS transaction_count_by_address_idx_count ON transaction_count_by_public_keys (count);
+
+CREATE TABLE IF NOT EXISTS transaction_count_by_public_key_indices (
+  transaction_hash text,
+  public_key text,
+  PRIMARY KEY (transaction_hash, public_key)
+);
+
+-- Failed messages
+CREATE TABLE IF NOT EXISTS failed_messages (
+  topic text,
+  partition integer,
+  "offset" bigint,
+  key bytea,
+  value bytea,
+  error text,
+  created_timestamp bigint,
+  replayed_timestamp bigint,
+  PRIMARY KEY (topic, partition, "offset")
+);
+CREATE INDEX IF NOT EXISTS failed_message_idx_replayed_timestamp ON failed_messages (replayed_timestamp);
+
+-- Kafka jobs
+CREATE TABLE IF NOT EXISTS kafka_jobs (
+  job_id text,
+  worker_group text,
+  topic text,
+  partition bigint,
+  stop_offset bigint,
+  PRIMARY KEY (job_id, worker_group, topic, partition)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS address_idx_balance_loop;
+ALTER TABLE addresses DROP COLUMN IF EXISTS balance_loop;
+ALTER TABLE balances DROP COLUMN IF EXISTS value_loop;
+DROP FUNCTION IF EXISTS hex_to_numeric(text);
@@ -0,0 +1,32 @@
+-- Exact balances in loop alongside the float columns
+
+-- Hex string (0x.., 0x-..) -> numeric
+CREATE OR REPLACE FUNCTION hex_to_numeric(hex text) RETURNS numeric AS $$
+DECLARE
+  digits text := lower(regexp_replace(hex, '^0x', ''));
+  sign numeric := 1;
+  result numeric := 0;
+  i integer;
+BEGIN
+  IF left(digits, 1) = '-' THEN
+    sign := -1;
+    digits := substr(digits, 2);
+  END IF;
+
+  FOR i IN 1..length(digits) LOOP
+    result := result * 16 + (position(substr(digits, i, 1) IN '0123456789abcdef') - 1);
+  END LOOP;
+
+  RETURN sign * result;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Balances, exact from the hex value
+ALTER TABLE balances ADD COLUMN IF NOT EXISTS value_loop numeric(78,0) NOT NULL DEFAULT 0;
+UPDATE balances SET value_loop = hex_to_numeric(value) WHERE value IS NOT NULL AND value != '';
+
+-- Addresses
+-- NOTE backfilled from the float balance, exact once the balance routine refreshes the address
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS balance_loop numeric(78,0) NOT NULL DEFAULT 0;
+UPDATE addresses SET balance_loop = ROUND(balance * 1000000000000000000) WHERE balance IS NOT NULL;
+CREATE INDEX IF NOT EXISTS address_idx_balance_loop ON addresses (balance_loop);
@@ -0,0 +1,2 @@
+ALTER TABLE blocks DROP COLUMN IF EXISTS parent_hash;
+ALTER TABLE blocks DROP COLUMN IF EXISTS hash;
@@ -0,0 +1,3 @@
+-- Block hashes for reorg detection
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS hash text;
+ALTER TABLE blocks ADD COLUMN IF NOT EXISTS parent_hash text;
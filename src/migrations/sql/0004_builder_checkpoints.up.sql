@@ -0,0 +1,9 @@
+-- Last block completed by each balance builder shard
+CREATE TABLE IF NOT EXISTS builder_checkpoints (
+  builder text,
+  shard bigint,
+  shard_count bigint,
+  block_number bigint,
+  updated_timestamp bigint,
+  PRIMARY KEY (builder, shard, shard_count)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE builder_checkpoints DROP COLUMN IF EXISTS checksum_start_block_number;
+ALTER TABLE builder_checkpoints DROP COLUMN IF EXISTS checksum;
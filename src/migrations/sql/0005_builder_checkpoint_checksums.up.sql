@@ -0,0 +1,3 @@
+-- Checksum of the balances written by the last builder batch, verified on resume
+ALTER TABLE builder_checkpoints ADD COLUMN IF NOT EXISTS checksum text NOT NULL DEFAULT '';
+ALTER TABLE builder_checkpoints ADD COLUMN IF NOT EXISTS checksum_start_block_number bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,13 @@
+-- Addresses whose balances ledger does not match the node
+CREATE TABLE IF NOT EXISTS balance_discrepancies (
+  public_key text,
+  block_number bigint,
+  first_block_number bigint,
+  ledger_value numeric(78,0) NOT NULL DEFAULT 0,
+  node_value numeric(78,0) NOT NULL DEFAULT 0,
+  difference numeric(78,0) NOT NULL DEFAULT 0,
+  detected_timestamp bigint,
+  updated_timestamp bigint,
+  PRIMARY KEY (public_key)
+);
+CREATE INDEX IF NOT EXISTS balance_discrepancy_idx_first_block_number ON balance_discrepancies (first_block_number);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS address_unstakes;
+ALTER TABLE addresses DROP COLUMN IF EXISTS unclaimed_iscore;
+ALTER TABLE addresses DROP COLUMN IF EXISTS bonded_balance_loop;
+ALTER TABLE addresses DROP COLUMN IF EXISTS delegated_balance_loop;
+ALTER TABLE addresses DROP COLUMN IF EXISTS unstaking_balance_loop;
+ALTER TABLE addresses DROP COLUMN IF EXISTS staked_balance_loop;
+ALTER TABLE addresses DROP COLUMN IF EXISTS available_balance_loop;
@@ -0,0 +1,16 @@
+-- Staking breakdown of addresses, refreshed by the balance routine
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS available_balance_loop numeric(78,0) NOT NULL DEFAULT 0;
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS staked_balance_loop numeric(78,0) NOT NULL DEFAULT 0;
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS unstaking_balance_loop numeric(78,0) NOT NULL DEFAULT 0;
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS delegated_balance_loop numeric(78,0) NOT NULL DEFAULT 0;
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS bonded_balance_loop numeric(78,0) NOT NULL DEFAULT 0;
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS unclaimed_iscore numeric(78,0) NOT NULL DEFAULT 0;
+
+-- Pending unstakes of addresses
+CREATE TABLE IF NOT EXISTS address_unstakes (
+  public_key text,
+  unstake_index bigint,
+  value_loop numeric(78,0) NOT NULL DEFAULT 0,
+  unlock_block_number bigint,
+  PRIMARY KEY (public_key, unstake_index)
+);
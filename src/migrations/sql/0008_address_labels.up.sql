@@ -0,0 +1,16 @@
+-- Labels of addresses, many per address
+CREATE TABLE IF NOT EXISTS address_labels (
+  public_key text,
+  label text,
+  source text,
+  timestamp bigint,
+  PRIMARY KEY (public_key, label)
+);
+CREATE INDEX IF NOT EXISTS address_label_idx_label ON address_labels (label);
+
+-- Types set by the address type routine
+INSERT INTO address_labels (public_key, label, source, timestamp)
+  SELECT public_key, type, 'default', (extract(epoch FROM now()) * 1000000)::bigint
+  FROM addresses
+  WHERE type IS NOT NULL AND type != ''
+  ON CONFLICT DO NOTHING;
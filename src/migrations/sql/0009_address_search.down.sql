@@ -0,0 +1,4 @@
+-- NOTE pg_trgm is left installed
+DROP INDEX IF EXISTS contract_processed_idx_name_trgm;
+DROP INDEX IF EXISTS address_label_idx_label_pattern;
+DROP INDEX IF EXISTS address_idx_public_key_pattern;
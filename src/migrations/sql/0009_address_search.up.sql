@@ -0,0 +1,10 @@
+-- Address search
+-- NOTE pg_trgm is trusted from postgres 13, older versions need a superuser to create it
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Public key and label prefixes
+CREATE INDEX IF NOT EXISTS address_idx_public_key_pattern ON addresses (public_key text_pattern_ops);
+CREATE INDEX IF NOT EXISTS address_label_idx_label_pattern ON address_labels (label text_pattern_ops);
+
+-- Fuzzy contract names
+CREATE INDEX IF NOT EXISTS contract_processed_idx_name_trgm ON contract_processeds USING gin (lower(name) gin_trgm_ops);
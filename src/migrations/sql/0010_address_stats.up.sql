@@ -0,0 +1,11 @@
+-- Snapshot of aggregate address statistics
+CREATE TABLE IF NOT EXISTS address_stats (
+  type text,
+  key text,
+  position bigint,
+  count bigint,
+  balance_loop numeric(78,0) NOT NULL DEFAULT 0,
+  share double precision,
+  timestamp bigint,
+  PRIMARY KEY (type, key)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS address_daily_activities;
+DROP TABLE IF EXISTS address_daily_stats;
+DELETE FROM builder_checkpoints WHERE builder = 'address_daily_stats';
@@ -0,0 +1,20 @@
+-- Daily rollup of address activity
+CREATE TABLE IF NOT EXISTS address_daily_stats (
+  day text,
+  start_block_number bigint,
+  end_block_number bigint,
+  active_address_count bigint,
+  new_address_count bigint,
+  transaction_count bigint,
+  volume_loop numeric(78,0) NOT NULL DEFAULT 0,
+  updated_timestamp bigint,
+  PRIMARY KEY (day)
+);
+CREATE INDEX IF NOT EXISTS address_daily_stat_idx_end_block_number ON address_daily_stats (end_block_number);
+
+-- Addresses active in the days being built
+CREATE TABLE IF NOT EXISTS address_daily_activities (
+  day text,
+  public_key text,
+  PRIMARY KEY (day, public_key)
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS address_idx_last_active_timestamp;
+DROP INDEX IF EXISTS address_idx_first_seen_timestamp;
+ALTER TABLE addresses DROP COLUMN IF EXISTS last_active_timestamp;
+ALTER TABLE addresses DROP COLUMN IF EXISTS last_active_block_number;
+ALTER TABLE addresses DROP COLUMN IF EXISTS first_seen_transaction_hash;
+ALTER TABLE addresses DROP COLUMN IF EXISTS first_seen_timestamp;
+ALTER TABLE addresses DROP COLUMN IF EXISTS first_seen_block_number;
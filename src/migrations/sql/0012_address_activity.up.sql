@@ -0,0 +1,9 @@
+-- First seen and last active of addresses, backfilled by the address activity routine
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS first_seen_block_number bigint NOT NULL DEFAULT 0;
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS first_seen_timestamp bigint NOT NULL DEFAULT 0;
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS first_seen_transaction_hash text NOT NULL DEFAULT '';
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS last_active_block_number bigint NOT NULL DEFAULT 0;
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS last_active_timestamp bigint NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS address_idx_first_seen_timestamp ON addresses (first_seen_timestamp);
+CREATE INDEX IF NOT EXISTS address_idx_last_active_timestamp ON addresses (last_active_timestamp);
@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS address_token_idx_token_contract_address ON address_tokens (token_contract_address);
+DROP INDEX IF EXISTS address_token_idx_token_contract_address_balance_decimal;
+ALTER TABLE address_tokens DROP COLUMN IF EXISTS last_updated_block_number;
+ALTER TABLE address_tokens DROP COLUMN IF EXISTS balance_decimal;
+ALTER TABLE address_tokens DROP COLUMN IF EXISTS balance;
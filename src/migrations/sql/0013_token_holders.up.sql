@@ -0,0 +1,22 @@
+-- Latest token balance of addresses, maintained by the token balance loader
+ALTER TABLE address_tokens ADD COLUMN IF NOT EXISTS balance text NOT NULL DEFAULT '0x0';
+ALTER TABLE address_tokens ADD COLUMN IF NOT EXISTS balance_decimal double precision NOT NULL DEFAULT 0;
+ALTER TABLE address_tokens ADD COLUMN IF NOT EXISTS last_updated_block_number bigint NOT NULL DEFAULT 0;
+
+UPDATE address_tokens
+SET
+  balance = latest_token_balances.value,
+  balance_decimal = latest_token_balances.value_decimal,
+  last_updated_block_number = latest_token_balances.block_number
+FROM (
+  SELECT DISTINCT ON (public_key, token_contract_address)
+    public_key, token_contract_address, value, value_decimal, block_number
+  FROM token_balances
+  ORDER BY public_key, token_contract_address, block_number DESC, transaction_index DESC, log_index DESC
+) AS latest_token_balances
+WHERE address_tokens.public_key = latest_token_balances.public_key
+AND address_tokens.token_contract_address = latest_token_balances.token_contract_address;
+
+-- Holders of a token by balance
+DROP INDEX IF EXISTS address_token_idx_token_contract_address;
+CREATE INDEX IF NOT EXISTS address_token_idx_token_contract_address_balance_decimal ON address_tokens (token_contract_address, balance_decimal);
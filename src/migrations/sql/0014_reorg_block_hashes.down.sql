@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS orphaned_blocks;
+
+DROP INDEX IF EXISTS token_balance_idx_block_hash;
+DROP INDEX IF EXISTS log_count_by_block_number_idx_block_hash;
+DROP INDEX IF EXISTS transaction_count_by_block_number_idx_block_hash;
+DROP INDEX IF EXISTS transaction_idx_block_hash;
+
+-- NOTE rows of a transaction in several blocks are reduced to one
+DELETE FROM log_count_by_block_numbers a USING log_count_by_block_numbers b
+WHERE a.transaction_hash = b.transaction_hash AND a.log_index = b.log_index AND a.block_hash < b.block_hash;
+ALTER TABLE log_count_by_block_numbers DROP CONSTRAINT IF EXISTS log_count_by_block_numbers_pkey;
+ALTER TABLE log_count_by_block_numbers ADD PRIMARY KEY (transaction_hash, log_index);
+DELETE FROM transaction_count_by_block_numbers a USING transaction_count_by_block_numbers b
+WHERE a.transaction_hash = b.transaction_hash AND a.block_hash < b.block_hash;
+ALTER TABLE transaction_count_by_block_numbers DROP CONSTRAINT IF EXISTS transaction_count_by_block_numbers_pkey;
+ALTER TABLE transaction_count_by_block_numbers ADD PRIMARY KEY (transaction_hash);
+
+ALTER TABLE token_balances DROP COLUMN IF EXISTS block_hash;
+ALTER TABLE log_count_by_block_numbers DROP COLUMN IF EXISTS block_hash;
+ALTER TABLE transaction_count_by_block_numbers DROP COLUMN IF EXISTS block_hash;
+ALTER TABLE transactions DROP COLUMN IF EXISTS block_hash;
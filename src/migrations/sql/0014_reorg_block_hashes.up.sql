@@ -0,0 +1,24 @@
+-- Block hashes of loaded rows, reorg rollbacks delete the rows of orphaned blocks only
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS block_hash text NOT NULL DEFAULT '';
+ALTER TABLE transaction_count_by_block_numbers ADD COLUMN IF NOT EXISTS block_hash text NOT NULL DEFAULT '';
+ALTER TABLE log_count_by_block_numbers ADD COLUMN IF NOT EXISTS block_hash text NOT NULL DEFAULT '';
+ALTER TABLE token_balances ADD COLUMN IF NOT EXISTS block_hash text NOT NULL DEFAULT '';
+
+-- Rows of a transaction are counted once per block, a reorg may move a transaction to another block
+ALTER TABLE transaction_count_by_block_numbers DROP CONSTRAINT IF EXISTS transaction_count_by_block_numbers_pkey;
+ALTER TABLE transaction_count_by_block_numbers ADD PRIMARY KEY (transaction_hash, block_hash);
+ALTER TABLE log_count_by_block_numbers DROP CONSTRAINT IF EXISTS log_count_by_block_numbers_pkey;
+ALTER TABLE log_count_by_block_numbers ADD PRIMARY KEY (transaction_hash, log_index, block_hash);
+
+CREATE INDEX IF NOT EXISTS transaction_idx_block_hash ON transactions (block_hash);
+CREATE INDEX IF NOT EXISTS transaction_count_by_block_number_idx_block_hash ON transaction_count_by_block_numbers (block_hash);
+CREATE INDEX IF NOT EXISTS log_count_by_block_number_idx_block_hash ON log_count_by_block_numbers (block_hash);
+CREATE INDEX IF NOT EXISTS token_balance_idx_block_hash ON token_balances (block_hash);
+
+-- Hashes of blocks replaced by a reorg
+-- NOTE messages of orphaned blocks are skipped by the transformers, including replays after a restart
+CREATE TABLE IF NOT EXISTS orphaned_blocks (
+  hash text PRIMARY KEY,
+  number bigint NOT NULL,
+  orphaned_timestamp bigint NOT NULL
+);
@@ -0,0 +1,2 @@
+UPDATE orphaned_blocks SET orphaned_timestamp = orphaned_timestamp / 1000000 WHERE orphaned_timestamp >= 100000000000;
+UPDATE builder_checkpoints SET updated_timestamp = updated_timestamp / 1000000 WHERE updated_timestamp >= 100000000000;
@@ -0,0 +1,3 @@
+-- Builder checkpoints and orphaned blocks were written in seconds, every other timestamp is in microseconds
+UPDATE builder_checkpoints SET updated_timestamp = updated_timestamp * 1000000 WHERE updated_timestamp < 100000000000;
+UPDATE orphaned_blocks SET orphaned_timestamp = orphaned_timestamp * 1000000 WHERE orphaned_timestamp < 100000000000;
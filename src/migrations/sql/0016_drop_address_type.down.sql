@@ -0,0 +1,14 @@
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS type text;
+
+UPDATE addresses
+SET type = type_labels.label
+FROM (
+  SELECT DISTINCT ON (public_key) public_key, label
+  FROM address_labels
+  WHERE source = 'type'
+  ORDER BY public_key, label
+) AS type_labels
+WHERE addresses.public_key = type_labels.public_key;
+
+-- Types were labels of the default source in 0008
+UPDATE address_labels SET source = 'default' WHERE source = 'type';
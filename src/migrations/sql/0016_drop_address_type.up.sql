@@ -0,0 +1,9 @@
+-- Types are labels since 0008, keep them under the type source, the address labels routine replaces the default source
+INSERT INTO address_labels (public_key, label, source, timestamp)
+  SELECT public_key, type, 'type', (extract(epoch FROM now()) * 1000000)::bigint
+  FROM addresses
+  WHERE type IS NOT NULL AND type != ''
+  ON CONFLICT (public_key, label) DO UPDATE SET source = 'type'
+  WHERE address_labels.source = 'default';
+
+ALTER TABLE addresses DROP COLUMN IF EXISTS type;
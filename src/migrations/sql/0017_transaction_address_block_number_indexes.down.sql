@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS transaction_idx_from_address ON transactions (from_address);
+CREATE INDEX IF NOT EXISTS transaction_idx_to_address ON transactions (to_address);
+
+DROP INDEX IF EXISTS transaction_idx_to_address_block_number;
+DROP INDEX IF EXISTS transaction_idx_from_address_block_number;
@@ -0,0 +1,7 @@
+-- Earlier transactions of an address, for the new addresses of the address daily stats builder
+-- NOTE replaces the single column indexes, address lookups use the first column
+CREATE INDEX IF NOT EXISTS transaction_idx_from_address_block_number ON transactions (from_address, block_number);
+CREATE INDEX IF NOT EXISTS transaction_idx_to_address_block_number ON transactions (to_address, block_number);
+
+DROP INDEX IF EXISTS transaction_idx_from_address;
+DROP INDEX IF EXISTS transaction_idx_to_address;
@@ -0,0 +1 @@
+ALTER TABLE token_balances DROP COLUMN IF EXISTS transaction_hash;
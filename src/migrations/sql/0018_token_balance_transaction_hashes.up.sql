@@ -0,0 +1,3 @@
+-- Transaction of a token transfer, for the first seen transaction of the address activity routine
+-- NOTE rows loaded before are left empty
+ALTER TABLE token_balances ADD COLUMN IF NOT EXISTS transaction_hash text NOT NULL DEFAULT '';
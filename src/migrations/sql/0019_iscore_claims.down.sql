@@ -0,0 +1,6 @@
+-- Claims back as transactions without a sender
+INSERT INTO transactions (hash, log_index, from_address, to_address, value, block_number, transaction_fee, block_timestamp, transaction_index, block_hash)
+  SELECT transaction_hash, log_index, '', public_key, value, block_number, '0x0', block_timestamp, transaction_index, block_hash
+  FROM i_score_claims
+  ON CONFLICT DO NOTHING;
+DROP TABLE IF EXISTS i_score_claims;
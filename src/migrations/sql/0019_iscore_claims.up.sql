@@ -0,0 +1,23 @@
+-- ICX minted by I-Score claims, a ledger input of the balance builder next to transactions
+CREATE TABLE IF NOT EXISTS i_score_claims (
+  transaction_hash text,
+  log_index integer,
+  public_key text,
+  value text,
+  block_number bigint,
+  transaction_index bigint,
+  block_timestamp bigint,
+  block_hash text NOT NULL DEFAULT '',
+  PRIMARY KEY (transaction_hash, log_index)
+);
+CREATE INDEX IF NOT EXISTS iscore_claim_idx_public_key ON i_score_claims (public_key);
+CREATE INDEX IF NOT EXISTS iscore_claim_idx_block_number ON i_score_claims (block_number);
+CREATE INDEX IF NOT EXISTS iscore_claim_idx_block_hash ON i_score_claims (block_hash);
+
+-- Claims loaded as transactions without a sender
+INSERT INTO i_score_claims (transaction_hash, log_index, public_key, value, block_number, transaction_index, block_timestamp, block_hash)
+  SELECT hash, log_index, to_address, value, block_number, transaction_index, block_timestamp, block_hash
+  FROM transactions
+  WHERE from_address = '' AND log_index >= 0
+  ON CONFLICT DO NOTHING;
+DELETE FROM transactions WHERE from_address = '' AND log_index >= 0;
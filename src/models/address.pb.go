@@ -31,7 +31,6 @@ type Address struct {
 	TransactionCount uint64  `protobuf:"varint,3,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count"`
 	LogCount         uint64  `protobuf:"varint,4,opt,name=log_count,json=logCount,proto3" json:"log_count"`
 	Balance          float64 `protobuf:"fixed64,5,opt,name=balance,proto3" json:"balance"`
-	Type             string  `protobuf:"bytes,6,opt,name=type,proto3" json:"type"`
 	// Only relevant in contract addresses
 	Name             string `protobuf:"bytes,7,opt,name=name,proto3" json:"name"`
 	Status           string `protobuf:"bytes,8,opt,name=status,proto3" json:"status"`
@@ -39,6 +38,23 @@ type Address struct {
 	IsToken          bool   `protobuf:"varint,10,opt,name=is_token,json=isToken,proto3" json:"is_token"`
 	// Goveranance
 	IsPrep bool `protobuf:"varint,11,opt,name=is_prep,json=isPrep,proto3" json:"is_prep"`
+	// Exact balance in loop, decimal string
+	BalanceLoop string `protobuf:"bytes,12,opt,name=balance_loop,json=balanceLoop,proto3" json:"balance_loop"`
+	// Staking breakdown in loop, decimal strings
+	// NOTE balance is available_balance + staked_balance
+	AvailableBalanceLoop string `protobuf:"bytes,13,opt,name=available_balance_loop,json=availableBalanceLoop,proto3" json:"available_balance_loop"`
+	StakedBalanceLoop    string `protobuf:"bytes,14,opt,name=staked_balance_loop,json=stakedBalanceLoop,proto3" json:"staked_balance_loop"`
+	UnstakingBalanceLoop string `protobuf:"bytes,15,opt,name=unstaking_balance_loop,json=unstakingBalanceLoop,proto3" json:"unstaking_balance_loop"` // Sum of address_unstakes
+	DelegatedBalanceLoop string `protobuf:"bytes,16,opt,name=delegated_balance_loop,json=delegatedBalanceLoop,proto3" json:"delegated_balance_loop"`
+	BondedBalanceLoop    string `protobuf:"bytes,17,opt,name=bonded_balance_loop,json=bondedBalanceLoop,proto3" json:"bonded_balance_loop"`
+	UnclaimedIscore      string `protobuf:"bytes,18,opt,name=unclaimed_iscore,json=unclaimedIscore,proto3" json:"unclaimed_iscore"` // I-Score, 1000 per ICX
+	// Activity, base and internal transactions sent or received
+	// NOTE timestamps are 0 until the address is seen in a transaction
+	FirstSeenBlockNumber     uint64 `protobuf:"varint,19,opt,name=first_seen_block_number,json=firstSeenBlockNumber,proto3" json:"first_seen_block_number"`
+	FirstSeenTimestamp       uint64 `protobuf:"varint,20,opt,name=first_seen_timestamp,json=firstSeenTimestamp,proto3" json:"first_seen_timestamp"`
+	FirstSeenTransactionHash string `protobuf:"bytes,21,opt,name=first_seen_transaction_hash,json=firstSeenTransactionHash,proto3" json:"first_seen_transaction_hash"`
+	LastActiveBlockNumber    uint64 `protobuf:"varint,22,opt,name=last_active_block_number,json=lastActiveBlockNumber,proto3" json:"last_active_block_number"`
+	LastActiveTimestamp      uint64 `protobuf:"varint,23,opt,name=last_active_timestamp,json=lastActiveTimestamp,proto3" json:"last_active_timestamp"`
 }
 
 func (x *Address) Reset() {
@@ -108,13 +124,6 @@ func (x *Address) GetBalance() float64 {
 	return 0
 }
 
-func (x *Address) GetType() string {
-	if x != nil {
-		return x.Type
-	}
-	return ""
-}
-
 func (x *Address) GetName() string {
 	if x != nil {
 		return x.Name
@@ -150,6 +159,90 @@ func (x *Address) GetIsPrep() bool {
 	return false
 }
 
+func (x *Address) GetBalanceLoop() string {
+	if x != nil {
+		return x.BalanceLoop
+	}
+	return ""
+}
+
+func (x *Address) GetAvailableBalanceLoop() string {
+	if x != nil {
+		return x.AvailableBalanceLoop
+	}
+	return ""
+}
+
+func (x *Address) GetStakedBalanceLoop() string {
+	if x != nil {
+		return x.StakedBalanceLoop
+	}
+	return ""
+}
+
+func (x *Address) GetUnstakingBalanceLoop() string {
+	if x != nil {
+		return x.UnstakingBalanceLoop
+	}
+	return ""
+}
+
+func (x *Address) GetDelegatedBalanceLoop() string {
+	if x != nil {
+		return x.DelegatedBalanceLoop
+	}
+	return ""
+}
+
+func (x *Address) GetBondedBalanceLoop() string {
+	if x != nil {
+		return x.BondedBalanceLoop
+	}
+	return ""
+}
+
+func (x *Address) GetUnclaimedIscore() string {
+	if x != nil {
+		return x.UnclaimedIscore
+	}
+	return ""
+}
+
+func (x *Address) GetFirstSeenBlockNumber() uint64 {
+	if x != nil {
+		return x.FirstSeenBlockNumber
+	}
+	return 0
+}
+
+func (x *Address) GetFirstSeenTimestamp() uint64 {
+	if x != nil {
+		return x.FirstSeenTimestamp
+	}
+	return 0
+}
+
+func (x *Address) GetFirstSeenTransactionHash() string {
+	if x != nil {
+		return x.FirstSeenTransactionHash
+	}
+	return ""
+}
+
+func (x *Address) GetLastActiveBlockNumber() uint64 {
+	if x != nil {
+		return x.LastActiveBlockNumber
+	}
+	return 0
+}
+
+func (x *Address) GetLastActiveTimestamp() uint64 {
+	if x != nil {
+		return x.LastActiveTimestamp
+	}
+	return 0
+}
+
 var File_address_proto protoreflect.FileDescriptor
 
 var file_address_proto_rawDesc = []byte{
@@ -158,7 +251,7 @@ var file_address_proto_rawDesc = []byte{
 	0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x6f, 0x70, 0x65, 0x6e,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x72, 0x6d,
 	0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0xd1, 0x04, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x6f, 0x74, 0x6f, 0x22, 0xe9, 0x0b, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
 	0x27, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0xb9, 0x19, 0x04, 0x0a, 0x02, 0x28, 0x01, 0x52, 0x09, 0x70,
 	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x63,
@@ -178,25 +271,83 @@ var file_address_proto_rawDesc = []byte{
 	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x42, 0x1b, 0xba, 0xb9, 0x19,
 	0x17, 0x0a, 0x15, 0x52, 0x13, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x64, 0x78,
 	0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x52, 0x0a, 0x11, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x42, 0x25, 0xba, 0xb9,
-	0x19, 0x21, 0x0a, 0x1f, 0x52, 0x1d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x64,
-	0x78, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x10, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x37, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x42, 0x1c, 0xba, 0xb9, 0x19, 0x18, 0x0a, 0x16, 0x52,
-	0x14, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x64, 0x78, 0x5f, 0x69, 0x73, 0x5f,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x07, 0x69, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3f,
-	0x0a, 0x07, 0x69, 0x73, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x42,
-	0x26, 0xba, 0xb9, 0x19, 0x22, 0x0a, 0x20, 0x52, 0x1e, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x5f, 0x69, 0x64, 0x78, 0x5f, 0x69, 0x73, 0x5f, 0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x61, 0x6e,
-	0x63, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x70, 0x52, 0x06, 0x69, 0x73, 0x50, 0x72, 0x65, 0x70, 0x3a,
-	0x06, 0xba, 0xb9, 0x19, 0x02, 0x08, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64,
-	0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x52, 0x0a,
+	0x11, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x42, 0x25, 0xba, 0xb9, 0x19, 0x21, 0x0a, 0x1f,
+	0x52, 0x1d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x64, 0x78, 0x5f, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x10, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x37, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x08, 0x42, 0x1c, 0xba, 0xb9, 0x19, 0x18, 0x0a, 0x16, 0x52, 0x14, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x64, 0x78, 0x5f, 0x69, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x07, 0x69, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3f, 0x0a, 0x07, 0x69, 0x73,
+	0x5f, 0x70, 0x72, 0x65, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x42, 0x26, 0xba, 0xb9, 0x19,
+	0x22, 0x0a, 0x20, 0x52, 0x1e, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x69, 0x64, 0x78,
+	0x5f, 0x69, 0x73, 0x5f, 0x67, 0x6f, 0x76, 0x65, 0x72, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x70,
+	0x72, 0x65, 0x70, 0x52, 0x06, 0x69, 0x73, 0x50, 0x72, 0x65, 0x70, 0x12, 0x55, 0x0a, 0x0c, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x32, 0xba, 0xb9, 0x19, 0x2e, 0x0a, 0x2c, 0x52, 0x18, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x5f, 0x69, 0x64, 0x78, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6c,
+	0x6f, 0x6f, 0x70, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28, 0x37, 0x38, 0x2c,
+	0x30, 0x29, 0x3a, 0x01, 0x30, 0x52, 0x0b, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x6f,
+	0x6f, 0x70, 0x12, 0x4e, 0x0a, 0x16, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x18, 0xba, 0xb9, 0x19, 0x14, 0x0a, 0x12, 0x3a, 0x01, 0x30, 0x12, 0x0d, 0x6e,
+	0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28, 0x37, 0x38, 0x2c, 0x30, 0x29, 0x52, 0x14, 0x61, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x6f,
+	0x6f, 0x70, 0x12, 0x48, 0x0a, 0x13, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x5f, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x18, 0xba, 0xb9, 0x19, 0x14, 0x0a, 0x12, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63,
+	0x28, 0x37, 0x38, 0x2c, 0x30, 0x29, 0x3a, 0x01, 0x30, 0x52, 0x11, 0x73, 0x74, 0x61, 0x6b, 0x65,
+	0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x70, 0x12, 0x4e, 0x0a, 0x16,
+	0x75, 0x6e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x5f, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xba, 0xb9,
+	0x19, 0x14, 0x0a, 0x12, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28, 0x37, 0x38,
+	0x2c, 0x30, 0x29, 0x3a, 0x01, 0x30, 0x52, 0x14, 0x75, 0x6e, 0x73, 0x74, 0x61, 0x6b, 0x69, 0x6e,
+	0x67, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x70, 0x12, 0x4e, 0x0a, 0x16,
+	0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x5f, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xba, 0xb9,
+	0x19, 0x14, 0x0a, 0x12, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28, 0x37, 0x38,
+	0x2c, 0x30, 0x29, 0x3a, 0x01, 0x30, 0x52, 0x14, 0x64, 0x65, 0x6c, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x70, 0x12, 0x48, 0x0a, 0x13,
+	0x62, 0x6f, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6c,
+	0x6f, 0x6f, 0x70, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xba, 0xb9, 0x19, 0x14, 0x0a,
+	0x12, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28, 0x37, 0x38, 0x2c, 0x30, 0x29,
+	0x3a, 0x01, 0x30, 0x52, 0x11, 0x62, 0x6f, 0x6e, 0x64, 0x65, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x70, 0x12, 0x43, 0x0a, 0x10, 0x75, 0x6e, 0x63, 0x6c, 0x61, 0x69,
+	0x6d, 0x65, 0x64, 0x5f, 0x69, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x18, 0xba, 0xb9, 0x19, 0x14, 0x0a, 0x12, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69,
+	0x63, 0x28, 0x37, 0x38, 0x2c, 0x30, 0x29, 0x3a, 0x01, 0x30, 0x52, 0x0f, 0x75, 0x6e, 0x63, 0x6c,
+	0x61, 0x69, 0x6d, 0x65, 0x64, 0x49, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x40, 0x0a, 0x17, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba, 0xb9,
+	0x19, 0x05, 0x0a, 0x03, 0x3a, 0x01, 0x30, 0x52, 0x14, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65,
+	0x65, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x5d, 0x0a,
+	0x14, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x14, 0x20, 0x01, 0x28, 0x04, 0x42, 0x2b, 0xba, 0xb9, 0x19,
+	0x27, 0x0a, 0x25, 0x3a, 0x01, 0x30, 0x52, 0x20, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f,
+	0x69, 0x64, 0x78, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x12, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53,
+	0x65, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x3d, 0x0a, 0x1b,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x18, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x42, 0x0a, 0x18, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x42, 0x09, 0xba,
+	0xb9, 0x19, 0x05, 0x0a, 0x03, 0x3a, 0x01, 0x30, 0x52, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x60, 0x0a, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x17, 0x20, 0x01, 0x28, 0x04, 0x42, 0x2c,
+	0xba, 0xb9, 0x19, 0x28, 0x0a, 0x26, 0x3a, 0x01, 0x30, 0x52, 0x21, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x5f, 0x69, 0x64, 0x78, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x13, 0x6c, 0x61,
+	0x73, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x3a, 0x06, 0xba, 0xb9, 0x19, 0x02, 0x08, 0x01, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x07, 0x42,
+	0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -21,17 +21,28 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type AddressORM struct {
-	Balance          float64 `gorm:"index:address_idx_balance"`
-	CreatedTimestamp uint64  `gorm:"index:address_idx_created_timestamp"`
-	IsContract       bool    `gorm:"index:address_idx_is_contract"`
-	IsPrep           bool    `gorm:"index:address_idx_is_governance_prep"`
-	IsToken          bool    `gorm:"index:address_idx_is_token"`
-	LogCount         uint64  `gorm:"index:address_idx_log_count"`
-	Name             string
-	PublicKey        string `gorm:"primary_key"`
-	Status           string
-	TransactionCount uint64 `gorm:"index:address_idx_transaction_count"`
-	Type             string
+	AvailableBalanceLoop     string  `gorm:"type:numeric(78,0);default:0"`
+	Balance                  float64 `gorm:"index:address_idx_balance"`
+	BalanceLoop              string  `gorm:"type:numeric(78,0);default:0;index:address_idx_balance_loop"`
+	BondedBalanceLoop        string  `gorm:"type:numeric(78,0);default:0"`
+	CreatedTimestamp         uint64  `gorm:"index:address_idx_created_timestamp"`
+	DelegatedBalanceLoop     string  `gorm:"type:numeric(78,0);default:0"`
+	FirstSeenBlockNumber     uint64  `gorm:"default:0"`
+	FirstSeenTimestamp       uint64  `gorm:"default:0;index:address_idx_first_seen_timestamp"`
+	FirstSeenTransactionHash string
+	IsContract               bool   `gorm:"index:address_idx_is_contract"`
+	IsPrep                   bool   `gorm:"index:address_idx_is_governance_prep"`
+	IsToken                  bool   `gorm:"index:address_idx_is_token"`
+	LastActiveBlockNumber    uint64 `gorm:"default:0"`
+	LastActiveTimestamp      uint64 `gorm:"default:0;index:address_idx_last_active_timestamp"`
+	LogCount                 uint64 `gorm:"index:address_idx_log_count"`
+	Name                     string
+	PublicKey                string `gorm:"primary_key"`
+	StakedBalanceLoop        string `gorm:"type:numeric(78,0);default:0"`
+	Status                   string
+	TransactionCount         uint64 `gorm:"index:address_idx_transaction_count"`
+	UnclaimedIscore          string `gorm:"type:numeric(78,0);default:0"`
+	UnstakingBalanceLoop     string `gorm:"type:numeric(78,0);default:0"`
 }
 
 // TableName overrides the default tablename generated by GORM
@@ -54,12 +65,23 @@ func (m *Address) ToORM(ctx context.Context) (AddressORM, error) {
 	to.TransactionCount = m.TransactionCount
 	to.LogCount = m.LogCount
 	to.Balance = m.Balance
-	to.Type = m.Type
 	to.Name = m.Name
 	to.Status = m.Status
 	to.CreatedTimestamp = m.CreatedTimestamp
 	to.IsToken = m.IsToken
 	to.IsPrep = m.IsPrep
+	to.BalanceLoop = m.BalanceLoop
+	to.AvailableBalanceLoop = m.AvailableBalanceLoop
+	to.StakedBalanceLoop = m.StakedBalanceLoop
+	to.UnstakingBalanceLoop = m.UnstakingBalanceLoop
+	to.DelegatedBalanceLoop = m.DelegatedBalanceLoop
+	to.BondedBalanceLoop = m.BondedBalanceLoop
+	to.UnclaimedIscore = m.UnclaimedIscore
+	to.FirstSeenBlockNumber = m.FirstSeenBlockNumber
+	to.FirstSeenTimestamp = m.FirstSeenTimestamp
+	to.FirstSeenTransactionHash = m.FirstSeenTransactionHash
+	to.LastActiveBlockNumber = m.LastActiveBlockNumber
+	to.LastActiveTimestamp = m.LastActiveTimestamp
 	if posthook, ok := interface{}(m).(AddressWithAfterToORM); ok {
 		err = posthook.AfterToORM(ctx, &to)
 	}
@@ -81,12 +103,23 @@ func (m *AddressORM) ToPB(ctx context.Context) (Address, error) {
 	to.TransactionCount = m.TransactionCount
 	to.LogCount = m.LogCount
 	to.Balance = m.Balance
-	to.Type = m.Type
 	to.Name = m.Name
 	to.Status = m.Status
 	to.CreatedTimestamp = m.CreatedTimestamp
 	to.IsToken = m.IsToken
 	to.IsPrep = m.IsPrep
+	to.BalanceLoop = m.BalanceLoop
+	to.AvailableBalanceLoop = m.AvailableBalanceLoop
+	to.StakedBalanceLoop = m.StakedBalanceLoop
+	to.UnstakingBalanceLoop = m.UnstakingBalanceLoop
+	to.DelegatedBalanceLoop = m.DelegatedBalanceLoop
+	to.BondedBalanceLoop = m.BondedBalanceLoop
+	to.UnclaimedIscore = m.UnclaimedIscore
+	to.FirstSeenBlockNumber = m.FirstSeenBlockNumber
+	to.FirstSeenTimestamp = m.FirstSeenTimestamp
+	to.FirstSeenTransactionHash = m.FirstSeenTransactionHash
+	to.LastActiveBlockNumber = m.LastActiveBlockNumber
+	to.LastActiveTimestamp = m.LastActiveTimestamp
 	if posthook, ok := interface{}(m).(AddressWithAfterToPB); ok {
 		err = posthook.AfterToPB(ctx, &to)
 	}
@@ -178,10 +211,6 @@ func DefaultApplyFieldMaskAddress(ctx context.Context, patchee *Address, patcher
 			patchee.Balance = patcher.Balance
 			continue
 		}
-		if f == prefix+"Type" {
-			patchee.Type = patcher.Type
-			continue
-		}
 		if f == prefix+"Name" {
 			patchee.Name = patcher.Name
 			continue
@@ -202,6 +231,54 @@ func DefaultApplyFieldMaskAddress(ctx context.Context, patchee *Address, patcher
 			patchee.IsPrep = patcher.IsPrep
 			continue
 		}
+		if f == prefix+"BalanceLoop" {
+			patchee.BalanceLoop = patcher.BalanceLoop
+			continue
+		}
+		if f == prefix+"AvailableBalanceLoop" {
+			patchee.AvailableBalanceLoop = patcher.AvailableBalanceLoop
+			continue
+		}
+		if f == prefix+"StakedBalanceLoop" {
+			patchee.StakedBalanceLoop = patcher.StakedBalanceLoop
+			continue
+		}
+		if f == prefix+"UnstakingBalanceLoop" {
+			patchee.UnstakingBalanceLoop = patcher.UnstakingBalanceLoop
+			continue
+		}
+		if f == prefix+"DelegatedBalanceLoop" {
+			patchee.DelegatedBalanceLoop = patcher.DelegatedBalanceLoop
+			continue
+		}
+		if f == prefix+"BondedBalanceLoop" {
+			patchee.BondedBalanceLoop = patcher.BondedBalanceLoop
+			continue
+		}
+		if f == prefix+"UnclaimedIscore" {
+			patchee.UnclaimedIscore = patcher.UnclaimedIscore
+			continue
+		}
+		if f == prefix+"FirstSeenBlockNumber" {
+			patchee.FirstSeenBlockNumber = patcher.FirstSeenBlockNumber
+			continue
+		}
+		if f == prefix+"FirstSeenTimestamp" {
+			patchee.FirstSeenTimestamp = patcher.FirstSeenTimestamp
+			continue
+		}
+		if f == prefix+"FirstSeenTransactionHash" {
+			patchee.FirstSeenTransactionHash = patcher.FirstSeenTransactionHash
+			continue
+		}
+		if f == prefix+"LastActiveBlockNumber" {
+			patchee.LastActiveBlockNumber = patcher.LastActiveBlockNumber
+			continue
+		}
+		if f == prefix+"LastActiveTimestamp" {
+			patchee.LastActiveTimestamp = patcher.LastActiveTimestamp
+			continue
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -25,10 +25,16 @@ type AddressAPIList struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PublicKey        string  `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key"`
-	TransactionCount uint64  `protobuf:"varint,2,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count"`
-	Balance          float64 `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance"`
-	Type             string  `protobuf:"bytes,4,opt,name=type,proto3" json:"type"`
+	PublicKey             string  `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key"`
+	TransactionCount      uint64  `protobuf:"varint,2,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count"`
+	Balance               float64 `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance"`
+	BalanceLoop           string  `protobuf:"bytes,5,opt,name=balance_loop,json=balanceLoop,proto3" json:"balance_loop"`
+	FirstSeenBlockNumber  uint64  `protobuf:"varint,6,opt,name=first_seen_block_number,json=firstSeenBlockNumber,proto3" json:"first_seen_block_number"`
+	FirstSeenTimestamp    uint64  `protobuf:"varint,7,opt,name=first_seen_timestamp,json=firstSeenTimestamp,proto3" json:"first_seen_timestamp"`
+	LastActiveBlockNumber uint64  `protobuf:"varint,8,opt,name=last_active_block_number,json=lastActiveBlockNumber,proto3" json:"last_active_block_number"`
+	LastActiveTimestamp   uint64  `protobuf:"varint,9,opt,name=last_active_timestamp,json=lastActiveTimestamp,proto3" json:"last_active_timestamp"`
+	LogCount              uint64  `protobuf:"varint,10,opt,name=log_count,json=logCount,proto3" json:"log_count"`
+	CreatedTimestamp      uint64  `protobuf:"varint,11,opt,name=created_timestamp,json=createdTimestamp,proto3" json:"created_timestamp"`
 }
 
 func (x *AddressAPIList) Reset() {
@@ -84,29 +90,90 @@ func (x *AddressAPIList) GetBalance() float64 {
 	return 0
 }
 
-func (x *AddressAPIList) GetType() string {
+func (x *AddressAPIList) GetBalanceLoop() string {
 	if x != nil {
-		return x.Type
+		return x.BalanceLoop
 	}
 	return ""
 }
 
+func (x *AddressAPIList) GetFirstSeenBlockNumber() uint64 {
+	if x != nil {
+		return x.FirstSeenBlockNumber
+	}
+	return 0
+}
+
+func (x *AddressAPIList) GetFirstSeenTimestamp() uint64 {
+	if x != nil {
+		return x.FirstSeenTimestamp
+	}
+	return 0
+}
+
+func (x *AddressAPIList) GetLastActiveBlockNumber() uint64 {
+	if x != nil {
+		return x.LastActiveBlockNumber
+	}
+	return 0
+}
+
+func (x *AddressAPIList) GetLastActiveTimestamp() uint64 {
+	if x != nil {
+		return x.LastActiveTimestamp
+	}
+	return 0
+}
+
+func (x *AddressAPIList) GetLogCount() uint64 {
+	if x != nil {
+		return x.LogCount
+	}
+	return 0
+}
+
+func (x *AddressAPIList) GetCreatedTimestamp() uint64 {
+	if x != nil {
+		return x.CreatedTimestamp
+	}
+	return 0
+}
+
 var File_address_api_list_proto protoreflect.FileDescriptor
 
 var file_address_api_list_proto_rawDesc = []byte{
 	0x0a, 0x16, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x61, 0x70, 0x69, 0x5f, 0x6c, 0x69,
 	0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
-	0x22, 0x8a, 0x01, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x50, 0x49, 0x4c,
+	0x22, 0xbf, 0x03, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x50, 0x49, 0x4c,
 	0x69, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65,
 	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
 	0x65, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
 	0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x74,
 	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
 	0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
-	0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x42, 0x0a, 0x5a,
-	0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x70, 0x12, 0x35, 0x0a, 0x17,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x14, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x14, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65,
+	0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x12, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x37, 0x0a, 0x18, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x41, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x32,
+	0x0a, 0x15, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x6c,
+	0x61, 0x73, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x2b, 0x0a, 0x11, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4a, 0x04, 0x08, 0x04,
+	0x10, 0x05, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
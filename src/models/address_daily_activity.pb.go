@@ -0,0 +1,161 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.6.1
+// source: address_daily_activity.proto
+
+package models
+
+import (
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Address active in a day, used to count distinct active addresses
+// NOTE days before the day being built are pruned
+type AddressDailyActivity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Day       string `protobuf:"bytes,1,opt,name=day,proto3" json:"day"` // UTC, YYYY-MM-DD
+	PublicKey string `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key"`
+}
+
+func (x *AddressDailyActivity) Reset() {
+	*x = AddressDailyActivity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_address_daily_activity_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressDailyActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressDailyActivity) ProtoMessage() {}
+
+func (x *AddressDailyActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_address_daily_activity_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressDailyActivity.ProtoReflect.Descriptor instead.
+func (*AddressDailyActivity) Descriptor() ([]byte, []int) {
+	return file_address_daily_activity_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddressDailyActivity) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *AddressDailyActivity) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+var File_address_daily_activity_proto protoreflect.FileDescriptor
+
+var file_address_daily_activity_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x1a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x6f, 0x70, 0x65, 0x6e, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x72, 0x6d, 0x2f,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x63, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x44, 0x61, 0x69,
+	0x6c, 0x79, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x03, 0x64, 0x61,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0xb9, 0x19, 0x04, 0x0a, 0x02, 0x28,
+	0x01, 0x52, 0x03, 0x64, 0x61, 0x79, 0x12, 0x27, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0xb9, 0x19, 0x04,
+	0x0a, 0x02, 0x28, 0x01, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x3a,
+	0x06, 0xba, 0xb9, 0x19, 0x02, 0x08, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_address_daily_activity_proto_rawDescOnce sync.Once
+	file_address_daily_activity_proto_rawDescData = file_address_daily_activity_proto_rawDesc
+)
+
+func file_address_daily_activity_proto_rawDescGZIP() []byte {
+	file_address_daily_activity_proto_rawDescOnce.Do(func() {
+		file_address_daily_activity_proto_rawDescData = protoimpl.X.CompressGZIP(file_address_daily_activity_proto_rawDescData)
+	})
+	return file_address_daily_activity_proto_rawDescData
+}
+
+var file_address_daily_activity_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_address_daily_activity_proto_goTypes = []interface{}{
+	(*AddressDailyActivity)(nil), // 0: models.AddressDailyActivity
+}
+var file_address_daily_activity_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_address_daily_activity_proto_init() }
+func file_address_daily_activity_proto_init() {
+	if File_address_daily_activity_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_address_daily_activity_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressDailyActivity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_address_daily_activity_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_address_daily_activity_proto_goTypes,
+		DependencyIndexes: file_address_daily_activity_proto_depIdxs,
+		MessageInfos:      file_address_daily_activity_proto_msgTypes,
+	}.Build()
+	File_address_daily_activity_proto = out.File
+	file_address_daily_activity_proto_rawDesc = nil
+	file_address_daily_activity_proto_goTypes = nil
+	file_address_daily_activity_proto_depIdxs = nil
+}
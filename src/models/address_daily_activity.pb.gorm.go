@@ -0,0 +1,200 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: address_daily_activity.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type AddressDailyActivityORM struct {
+	Day       string `gorm:"primary_key"`
+	PublicKey string `gorm:"primary_key"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (AddressDailyActivityORM) TableName() string {
+	return "address_daily_activities"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *AddressDailyActivity) ToORM(ctx context.Context) (AddressDailyActivityORM, error) {
+	to := AddressDailyActivityORM{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressDailyActivityWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Day = m.Day
+	to.PublicKey = m.PublicKey
+	if posthook, ok := interface{}(m).(AddressDailyActivityWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *AddressDailyActivityORM) ToPB(ctx context.Context) (AddressDailyActivity, error) {
+	to := AddressDailyActivity{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressDailyActivityWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Day = m.Day
+	to.PublicKey = m.PublicKey
+	if posthook, ok := interface{}(m).(AddressDailyActivityWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type AddressDailyActivity the arg will be the target, the caller the one being converted from
+
+// AddressDailyActivityBeforeToORM called before default ToORM code
+type AddressDailyActivityWithBeforeToORM interface {
+	BeforeToORM(context.Context, *AddressDailyActivityORM) error
+}
+
+// AddressDailyActivityAfterToORM called after default ToORM code
+type AddressDailyActivityWithAfterToORM interface {
+	AfterToORM(context.Context, *AddressDailyActivityORM) error
+}
+
+// AddressDailyActivityBeforeToPB called before default ToPB code
+type AddressDailyActivityWithBeforeToPB interface {
+	BeforeToPB(context.Context, *AddressDailyActivity) error
+}
+
+// AddressDailyActivityAfterToPB called after default ToPB code
+type AddressDailyActivityWithAfterToPB interface {
+	AfterToPB(context.Context, *AddressDailyActivity) error
+}
+
+// DefaultCreateAddressDailyActivity executes a basic gorm create call
+func DefaultCreateAddressDailyActivity(ctx context.Context, in *AddressDailyActivity, db *gorm1.DB) (*AddressDailyActivity, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyActivityORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyActivityORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type AddressDailyActivityORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressDailyActivityORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskAddressDailyActivity patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskAddressDailyActivity(ctx context.Context, patchee *AddressDailyActivity, patcher *AddressDailyActivity, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*AddressDailyActivity, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Day" {
+			patchee.Day = patcher.Day
+			continue
+		}
+		if f == prefix+"PublicKey" {
+			patchee.PublicKey = patcher.PublicKey
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListAddressDailyActivity executes a gorm list call
+func DefaultListAddressDailyActivity(ctx context.Context, db *gorm1.DB) ([]*AddressDailyActivity, error) {
+	in := AddressDailyActivity{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyActivityORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &AddressDailyActivityORM{}, &AddressDailyActivity{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyActivityORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("day")
+	ormResponse := []AddressDailyActivityORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyActivityORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*AddressDailyActivity{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type AddressDailyActivityORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressDailyActivityORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressDailyActivityORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]AddressDailyActivityORM) error
+}
@@ -0,0 +1,229 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.6.1
+// source: address_daily_stat.proto
+
+package models
+
+import (
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Daily rollup of address activity, built by the address daily stat builder
+type AddressDailyStat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Day                string `protobuf:"bytes,1,opt,name=day,proto3" json:"day"`                                                            // UTC, YYYY-MM-DD
+	StartBlockNumber   uint64 `protobuf:"varint,2,opt,name=start_block_number,json=startBlockNumber,proto3" json:"start_block_number"`       // First block with transactions in the day
+	EndBlockNumber     uint64 `protobuf:"varint,3,opt,name=end_block_number,json=endBlockNumber,proto3" json:"end_block_number"`             // Last block built into the day
+	ActiveAddressCount uint64 `protobuf:"varint,4,opt,name=active_address_count,json=activeAddressCount,proto3" json:"active_address_count"` // Addresses sending or receiving a transaction
+	NewAddressCount    uint64 `protobuf:"varint,5,opt,name=new_address_count,json=newAddressCount,proto3" json:"new_address_count"`          // Active addresses without transactions before the day
+	TransactionCount   uint64 `protobuf:"varint,6,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count"`         // Base transactions
+	VolumeLoop         string `protobuf:"bytes,7,opt,name=volume_loop,json=volumeLoop,proto3" json:"volume_loop"`                            // ICX value of base and internal transactions
+	UpdatedTimestamp   uint64 `protobuf:"varint,8,opt,name=updated_timestamp,json=updatedTimestamp,proto3" json:"updated_timestamp"`
+}
+
+func (x *AddressDailyStat) Reset() {
+	*x = AddressDailyStat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_address_daily_stat_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressDailyStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressDailyStat) ProtoMessage() {}
+
+func (x *AddressDailyStat) ProtoReflect() protoreflect.Message {
+	mi := &file_address_daily_stat_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressDailyStat.ProtoReflect.Descriptor instead.
+func (*AddressDailyStat) Descriptor() ([]byte, []int) {
+	return file_address_daily_stat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddressDailyStat) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *AddressDailyStat) GetStartBlockNumber() uint64 {
+	if x != nil {
+		return x.StartBlockNumber
+	}
+	return 0
+}
+
+func (x *AddressDailyStat) GetEndBlockNumber() uint64 {
+	if x != nil {
+		return x.EndBlockNumber
+	}
+	return 0
+}
+
+func (x *AddressDailyStat) GetActiveAddressCount() uint64 {
+	if x != nil {
+		return x.ActiveAddressCount
+	}
+	return 0
+}
+
+func (x *AddressDailyStat) GetNewAddressCount() uint64 {
+	if x != nil {
+		return x.NewAddressCount
+	}
+	return 0
+}
+
+func (x *AddressDailyStat) GetTransactionCount() uint64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *AddressDailyStat) GetVolumeLoop() string {
+	if x != nil {
+		return x.VolumeLoop
+	}
+	return ""
+}
+
+func (x *AddressDailyStat) GetUpdatedTimestamp() uint64 {
+	if x != nil {
+		return x.UpdatedTimestamp
+	}
+	return 0
+}
+
+var File_address_daily_stat_proto protoreflect.FileDescriptor
+
+var file_address_daily_stat_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x1a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69,
+	0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x6f, 0x70, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x72, 0x6d, 0x2f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2f, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb2,
+	0x03, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x53,
+	0x74, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x03, 0x64, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x08, 0xba, 0xb9, 0x19, 0x04, 0x0a, 0x02, 0x28, 0x01, 0x52, 0x03, 0x64, 0x61, 0x79, 0x12,
+	0x2c, 0x0a, 0x12, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x59, 0x0a,
+	0x10, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x2f, 0xba, 0xb9, 0x19, 0x2b, 0x0a, 0x29, 0x52,
+	0x27, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x5f, 0x69, 0x64, 0x78, 0x5f, 0x65, 0x6e, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x0e, 0x65, 0x6e, 0x64, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x14, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x65,
+	0x77, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x6e, 0x65, 0x77, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0b, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x6c, 0x6f,
+	0x6f, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xba, 0xb9, 0x19, 0x14, 0x0a, 0x12,
+	0x3a, 0x01, 0x30, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28, 0x37, 0x38, 0x2c,
+	0x30, 0x29, 0x52, 0x0a, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4c, 0x6f, 0x6f, 0x70, 0x12, 0x2b,
+	0x0a, 0x11, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x3a, 0x06, 0xba, 0xb9, 0x19,
+	0x02, 0x08, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_address_daily_stat_proto_rawDescOnce sync.Once
+	file_address_daily_stat_proto_rawDescData = file_address_daily_stat_proto_rawDesc
+)
+
+func file_address_daily_stat_proto_rawDescGZIP() []byte {
+	file_address_daily_stat_proto_rawDescOnce.Do(func() {
+		file_address_daily_stat_proto_rawDescData = protoimpl.X.CompressGZIP(file_address_daily_stat_proto_rawDescData)
+	})
+	return file_address_daily_stat_proto_rawDescData
+}
+
+var file_address_daily_stat_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_address_daily_stat_proto_goTypes = []interface{}{
+	(*AddressDailyStat)(nil), // 0: models.AddressDailyStat
+}
+var file_address_daily_stat_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_address_daily_stat_proto_init() }
+func file_address_daily_stat_proto_init() {
+	if File_address_daily_stat_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_address_daily_stat_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressDailyStat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_address_daily_stat_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_address_daily_stat_proto_goTypes,
+		DependencyIndexes: file_address_daily_stat_proto_depIdxs,
+		MessageInfos:      file_address_daily_stat_proto_msgTypes,
+	}.Build()
+	File_address_daily_stat_proto = out.File
+	file_address_daily_stat_proto_rawDesc = nil
+	file_address_daily_stat_proto_goTypes = nil
+	file_address_daily_stat_proto_depIdxs = nil
+}
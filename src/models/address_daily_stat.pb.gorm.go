@@ -0,0 +1,242 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: address_daily_stat.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type AddressDailyStatORM struct {
+	ActiveAddressCount uint64
+	Day                string `gorm:"primary_key"`
+	EndBlockNumber     uint64 `gorm:"index:address_daily_stat_idx_end_block_number"`
+	NewAddressCount    uint64
+	StartBlockNumber   uint64
+	TransactionCount   uint64
+	UpdatedTimestamp   uint64
+	VolumeLoop         string `gorm:"type:numeric(78,0);default:0"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (AddressDailyStatORM) TableName() string {
+	return "address_daily_stats"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *AddressDailyStat) ToORM(ctx context.Context) (AddressDailyStatORM, error) {
+	to := AddressDailyStatORM{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressDailyStatWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Day = m.Day
+	to.StartBlockNumber = m.StartBlockNumber
+	to.EndBlockNumber = m.EndBlockNumber
+	to.ActiveAddressCount = m.ActiveAddressCount
+	to.NewAddressCount = m.NewAddressCount
+	to.TransactionCount = m.TransactionCount
+	to.VolumeLoop = m.VolumeLoop
+	to.UpdatedTimestamp = m.UpdatedTimestamp
+	if posthook, ok := interface{}(m).(AddressDailyStatWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *AddressDailyStatORM) ToPB(ctx context.Context) (AddressDailyStat, error) {
+	to := AddressDailyStat{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressDailyStatWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Day = m.Day
+	to.StartBlockNumber = m.StartBlockNumber
+	to.EndBlockNumber = m.EndBlockNumber
+	to.ActiveAddressCount = m.ActiveAddressCount
+	to.NewAddressCount = m.NewAddressCount
+	to.TransactionCount = m.TransactionCount
+	to.VolumeLoop = m.VolumeLoop
+	to.UpdatedTimestamp = m.UpdatedTimestamp
+	if posthook, ok := interface{}(m).(AddressDailyStatWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type AddressDailyStat the arg will be the target, the caller the one being converted from
+
+// AddressDailyStatBeforeToORM called before default ToORM code
+type AddressDailyStatWithBeforeToORM interface {
+	BeforeToORM(context.Context, *AddressDailyStatORM) error
+}
+
+// AddressDailyStatAfterToORM called after default ToORM code
+type AddressDailyStatWithAfterToORM interface {
+	AfterToORM(context.Context, *AddressDailyStatORM) error
+}
+
+// AddressDailyStatBeforeToPB called before default ToPB code
+type AddressDailyStatWithBeforeToPB interface {
+	BeforeToPB(context.Context, *AddressDailyStat) error
+}
+
+// AddressDailyStatAfterToPB called after default ToPB code
+type AddressDailyStatWithAfterToPB interface {
+	AfterToPB(context.Context, *AddressDailyStat) error
+}
+
+// DefaultCreateAddressDailyStat executes a basic gorm create call
+func DefaultCreateAddressDailyStat(ctx context.Context, in *AddressDailyStat, db *gorm1.DB) (*AddressDailyStat, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyStatORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyStatORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type AddressDailyStatORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressDailyStatORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskAddressDailyStat patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskAddressDailyStat(ctx context.Context, patchee *AddressDailyStat, patcher *AddressDailyStat, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*AddressDailyStat, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Day" {
+			patchee.Day = patcher.Day
+			continue
+		}
+		if f == prefix+"StartBlockNumber" {
+			patchee.StartBlockNumber = patcher.StartBlockNumber
+			continue
+		}
+		if f == prefix+"EndBlockNumber" {
+			patchee.EndBlockNumber = patcher.EndBlockNumber
+			continue
+		}
+		if f == prefix+"ActiveAddressCount" {
+			patchee.ActiveAddressCount = patcher.ActiveAddressCount
+			continue
+		}
+		if f == prefix+"NewAddressCount" {
+			patchee.NewAddressCount = patcher.NewAddressCount
+			continue
+		}
+		if f == prefix+"TransactionCount" {
+			patchee.TransactionCount = patcher.TransactionCount
+			continue
+		}
+		if f == prefix+"VolumeLoop" {
+			patchee.VolumeLoop = patcher.VolumeLoop
+			continue
+		}
+		if f == prefix+"UpdatedTimestamp" {
+			patchee.UpdatedTimestamp = patcher.UpdatedTimestamp
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListAddressDailyStat executes a gorm list call
+func DefaultListAddressDailyStat(ctx context.Context, db *gorm1.DB) ([]*AddressDailyStat, error) {
+	in := AddressDailyStat{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyStatORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &AddressDailyStatORM{}, &AddressDailyStat{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyStatORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("day")
+	ormResponse := []AddressDailyStatORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressDailyStatORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*AddressDailyStat{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type AddressDailyStatORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressDailyStatORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressDailyStatORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]AddressDailyStatORM) error
+}
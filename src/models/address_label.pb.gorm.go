@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: address_label.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type AddressLabelORM struct {
+	Label     string `gorm:"primary_key;index:address_label_idx_label"`
+	PublicKey string `gorm:"primary_key"`
+	Source    string
+	Timestamp uint64
+}
+
+// TableName overrides the default tablename generated by GORM
+func (AddressLabelORM) TableName() string {
+	return "address_labels"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *AddressLabel) ToORM(ctx context.Context) (AddressLabelORM, error) {
+	to := AddressLabelORM{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressLabelWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.PublicKey = m.PublicKey
+	to.Label = m.Label
+	to.Source = m.Source
+	to.Timestamp = m.Timestamp
+	if posthook, ok := interface{}(m).(AddressLabelWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *AddressLabelORM) ToPB(ctx context.Context) (AddressLabel, error) {
+	to := AddressLabel{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressLabelWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.PublicKey = m.PublicKey
+	to.Label = m.Label
+	to.Source = m.Source
+	to.Timestamp = m.Timestamp
+	if posthook, ok := interface{}(m).(AddressLabelWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type AddressLabel the arg will be the target, the caller the one being converted from
+
+// AddressLabelBeforeToORM called before default ToORM code
+type AddressLabelWithBeforeToORM interface {
+	BeforeToORM(context.Context, *AddressLabelORM) error
+}
+
+// AddressLabelAfterToORM called after default ToORM code
+type AddressLabelWithAfterToORM interface {
+	AfterToORM(context.Context, *AddressLabelORM) error
+}
+
+// AddressLabelBeforeToPB called before default ToPB code
+type AddressLabelWithBeforeToPB interface {
+	BeforeToPB(context.Context, *AddressLabel) error
+}
+
+// AddressLabelAfterToPB called after default ToPB code
+type AddressLabelWithAfterToPB interface {
+	AfterToPB(context.Context, *AddressLabel) error
+}
+
+// DefaultCreateAddressLabel executes a basic gorm create call
+func DefaultCreateAddressLabel(ctx context.Context, in *AddressLabel, db *gorm1.DB) (*AddressLabel, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressLabelORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressLabelORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type AddressLabelORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressLabelORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskAddressLabel patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskAddressLabel(ctx context.Context, patchee *AddressLabel, patcher *AddressLabel, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*AddressLabel, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"PublicKey" {
+			patchee.PublicKey = patcher.PublicKey
+			continue
+		}
+		if f == prefix+"Label" {
+			patchee.Label = patcher.Label
+			continue
+		}
+		if f == prefix+"Source" {
+			patchee.Source = patcher.Source
+			continue
+		}
+		if f == prefix+"Timestamp" {
+			patchee.Timestamp = patcher.Timestamp
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListAddressLabel executes a gorm list call
+func DefaultListAddressLabel(ctx context.Context, db *gorm1.DB) ([]*AddressLabel, error) {
+	in := AddressLabel{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressLabelORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &AddressLabelORM{}, &AddressLabel{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressLabelORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("public_key")
+	ormResponse := []AddressLabelORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressLabelORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*AddressLabel{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type AddressLabelORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressLabelORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressLabelORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]AddressLabelORM) error
+}
@@ -0,0 +1,232 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.6.1
+// source: address_search_api_list.proto
+
+package models
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AddressSearchAPIList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey   string  `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key"`
+	Type        string  `protobuf:"bytes,2,opt,name=type,proto3" json:"type"` // Derived from labels
+	Balance     float64 `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance"`
+	BalanceLoop string  `protobuf:"bytes,4,opt,name=balance_loop,json=balanceLoop,proto3" json:"balance_loop"`
+	IsContract  bool    `protobuf:"varint,5,opt,name=is_contract,json=isContract,proto3" json:"is_contract"`
+	IsToken     bool    `protobuf:"varint,6,opt,name=is_token,json=isToken,proto3" json:"is_token"`
+	Name        string  `protobuf:"bytes,7,opt,name=name,proto3" json:"name"`
+	// Best match of the address
+	MatchField string  `protobuf:"bytes,8,opt,name=match_field,json=matchField,proto3" json:"match_field"` // public_key, name or label
+	MatchValue string  `protobuf:"bytes,9,opt,name=match_value,json=matchValue,proto3" json:"match_value"`
+	Score      float64 `protobuf:"fixed64,10,opt,name=score,proto3" json:"score"` // Higher first
+}
+
+func (x *AddressSearchAPIList) Reset() {
+	*x = AddressSearchAPIList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_address_search_api_list_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressSearchAPIList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressSearchAPIList) ProtoMessage() {}
+
+func (x *AddressSearchAPIList) ProtoReflect() protoreflect.Message {
+	mi := &file_address_search_api_list_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressSearchAPIList.ProtoReflect.Descriptor instead.
+func (*AddressSearchAPIList) Descriptor() ([]byte, []int) {
+	return file_address_search_api_list_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddressSearchAPIList) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *AddressSearchAPIList) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddressSearchAPIList) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *AddressSearchAPIList) GetBalanceLoop() string {
+	if x != nil {
+		return x.BalanceLoop
+	}
+	return ""
+}
+
+func (x *AddressSearchAPIList) GetIsContract() bool {
+	if x != nil {
+		return x.IsContract
+	}
+	return false
+}
+
+func (x *AddressSearchAPIList) GetIsToken() bool {
+	if x != nil {
+		return x.IsToken
+	}
+	return false
+}
+
+func (x *AddressSearchAPIList) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddressSearchAPIList) GetMatchField() string {
+	if x != nil {
+		return x.MatchField
+	}
+	return ""
+}
+
+func (x *AddressSearchAPIList) GetMatchValue() string {
+	if x != nil {
+		return x.MatchValue
+	}
+	return ""
+}
+
+func (x *AddressSearchAPIList) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+var File_address_search_api_list_proto protoreflect.FileDescriptor
+
+var file_address_search_api_list_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x5f, 0x61, 0x70, 0x69, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x06, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x22, 0xae, 0x02, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x41, 0x50, 0x49, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x6f, 0x6f, 0x70,
+	0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_address_search_api_list_proto_rawDescOnce sync.Once
+	file_address_search_api_list_proto_rawDescData = file_address_search_api_list_proto_rawDesc
+)
+
+func file_address_search_api_list_proto_rawDescGZIP() []byte {
+	file_address_search_api_list_proto_rawDescOnce.Do(func() {
+		file_address_search_api_list_proto_rawDescData = protoimpl.X.CompressGZIP(file_address_search_api_list_proto_rawDescData)
+	})
+	return file_address_search_api_list_proto_rawDescData
+}
+
+var file_address_search_api_list_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_address_search_api_list_proto_goTypes = []interface{}{
+	(*AddressSearchAPIList)(nil), // 0: models.AddressSearchAPIList
+}
+var file_address_search_api_list_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_address_search_api_list_proto_init() }
+func file_address_search_api_list_proto_init() {
+	if File_address_search_api_list_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_address_search_api_list_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressSearchAPIList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_address_search_api_list_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_address_search_api_list_proto_goTypes,
+		DependencyIndexes: file_address_search_api_list_proto_depIdxs,
+		MessageInfos:      file_address_search_api_list_proto_msgTypes,
+	}.Build()
+	File_address_search_api_list_proto = out.File
+	file_address_search_api_list_proto_rawDesc = nil
+	file_address_search_api_list_proto_goTypes = nil
+	file_address_search_api_list_proto_depIdxs = nil
+}
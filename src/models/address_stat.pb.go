@@ -0,0 +1,209 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.6.1
+// source: address_stat.proto
+
+package models
+
+import (
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Snapshot of aggregate address statistics, replaced by the address stats routine
+type AddressStat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        string  `protobuf:"bytes,1,opt,name=type,proto3" json:"type"`                                  // total, top_holders, balance_distribution, address_type, new_addresses
+	Key         string  `protobuf:"bytes,2,opt,name=key,proto3" json:"key"`                                    // e.g. 100, 1-10, contract, 2021-08-01
+	Position    uint32  `protobuf:"varint,3,opt,name=position,proto3" json:"position"`                         // Order within type
+	Count       uint64  `protobuf:"varint,4,opt,name=count,proto3" json:"count"`                               // Addresses
+	BalanceLoop string  `protobuf:"bytes,5,opt,name=balance_loop,json=balanceLoop,proto3" json:"balance_loop"` // Sum of the balances of the addresses
+	Share       float64 `protobuf:"fixed64,6,opt,name=share,proto3" json:"share"`                              // balance_loop / total balance_loop
+	Timestamp   uint64  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp"`                       // Snapshot time
+}
+
+func (x *AddressStat) Reset() {
+	*x = AddressStat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_address_stat_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressStat) ProtoMessage() {}
+
+func (x *AddressStat) ProtoReflect() protoreflect.Message {
+	mi := &file_address_stat_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressStat.ProtoReflect.Descriptor instead.
+func (*AddressStat) Descriptor() ([]byte, []int) {
+	return file_address_stat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AddressStat) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AddressStat) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AddressStat) GetPosition() uint32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *AddressStat) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AddressStat) GetBalanceLoop() string {
+	if x != nil {
+		return x.BalanceLoop
+	}
+	return ""
+}
+
+func (x *AddressStat) GetShare() float64 {
+	if x != nil {
+		return x.Share
+	}
+	return 0
+}
+
+func (x *AddressStat) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+var File_address_stat_proto protoreflect.FileDescriptor
+
+var file_address_stat_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x1a, 0x3a, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f,
+	0x78, 0x6f, 0x70, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e,
+	0x2d, 0x67, 0x6f, 0x72, 0x6d, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x67, 0x6f,
+	0x72, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf2, 0x01, 0x0a, 0x0b, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x53, 0x74, 0x61, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0xb9, 0x19, 0x04, 0x0a, 0x02, 0x28, 0x01,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0xb9, 0x19, 0x04, 0x0a, 0x02, 0x28, 0x01, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x0c, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f,
+	0x6c, 0x6f, 0x6f, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xba, 0xb9, 0x19, 0x14,
+	0x0a, 0x12, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28, 0x37, 0x38, 0x2c, 0x30,
+	0x29, 0x3a, 0x01, 0x30, 0x52, 0x0b, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4c, 0x6f, 0x6f,
+	0x70, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x3a, 0x06, 0xba, 0xb9, 0x19, 0x02, 0x08, 0x01, 0x42, 0x0a, 0x5a,
+	0x08, 0x2e, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_address_stat_proto_rawDescOnce sync.Once
+	file_address_stat_proto_rawDescData = file_address_stat_proto_rawDesc
+)
+
+func file_address_stat_proto_rawDescGZIP() []byte {
+	file_address_stat_proto_rawDescOnce.Do(func() {
+		file_address_stat_proto_rawDescData = protoimpl.X.CompressGZIP(file_address_stat_proto_rawDescData)
+	})
+	return file_address_stat_proto_rawDescData
+}
+
+var file_address_stat_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_address_stat_proto_goTypes = []interface{}{
+	(*AddressStat)(nil), // 0: models.AddressStat
+}
+var file_address_stat_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_address_stat_proto_init() }
+func file_address_stat_proto_init() {
+	if File_address_stat_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_address_stat_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressStat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_address_stat_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_address_stat_proto_goTypes,
+		DependencyIndexes: file_address_stat_proto_depIdxs,
+		MessageInfos:      file_address_stat_proto_msgTypes,
+	}.Build()
+	File_address_stat_proto = out.File
+	file_address_stat_proto_rawDesc = nil
+	file_address_stat_proto_goTypes = nil
+	file_address_stat_proto_depIdxs = nil
+}
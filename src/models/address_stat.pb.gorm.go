@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: address_stat.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type AddressStatORM struct {
+	BalanceLoop string `gorm:"type:numeric(78,0);default:0"`
+	Count       uint64
+	Key         string `gorm:"primary_key"`
+	Position    uint32
+	Share       float64
+	Timestamp   uint64
+	Type        string `gorm:"primary_key"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (AddressStatORM) TableName() string {
+	return "address_stats"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *AddressStat) ToORM(ctx context.Context) (AddressStatORM, error) {
+	to := AddressStatORM{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressStatWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Type = m.Type
+	to.Key = m.Key
+	to.Position = m.Position
+	to.Count = m.Count
+	to.BalanceLoop = m.BalanceLoop
+	to.Share = m.Share
+	to.Timestamp = m.Timestamp
+	if posthook, ok := interface{}(m).(AddressStatWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *AddressStatORM) ToPB(ctx context.Context) (AddressStat, error) {
+	to := AddressStat{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressStatWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Type = m.Type
+	to.Key = m.Key
+	to.Position = m.Position
+	to.Count = m.Count
+	to.BalanceLoop = m.BalanceLoop
+	to.Share = m.Share
+	to.Timestamp = m.Timestamp
+	if posthook, ok := interface{}(m).(AddressStatWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type AddressStat the arg will be the target, the caller the one being converted from
+
+// AddressStatBeforeToORM called before default ToORM code
+type AddressStatWithBeforeToORM interface {
+	BeforeToORM(context.Context, *AddressStatORM) error
+}
+
+// AddressStatAfterToORM called after default ToORM code
+type AddressStatWithAfterToORM interface {
+	AfterToORM(context.Context, *AddressStatORM) error
+}
+
+// AddressStatBeforeToPB called before default ToPB code
+type AddressStatWithBeforeToPB interface {
+	BeforeToPB(context.Context, *AddressStat) error
+}
+
+// AddressStatAfterToPB called after default ToPB code
+type AddressStatWithAfterToPB interface {
+	AfterToPB(context.Context, *AddressStat) error
+}
+
+// DefaultCreateAddressStat executes a basic gorm create call
+func DefaultCreateAddressStat(ctx context.Context, in *AddressStat, db *gorm1.DB) (*AddressStat, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressStatORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressStatORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type AddressStatORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressStatORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskAddressStat patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskAddressStat(ctx context.Context, patchee *AddressStat, patcher *AddressStat, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*AddressStat, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Type" {
+			patchee.Type = patcher.Type
+			continue
+		}
+		if f == prefix+"Key" {
+			patchee.Key = patcher.Key
+			continue
+		}
+		if f == prefix+"Position" {
+			patchee.Position = patcher.Position
+			continue
+		}
+		if f == prefix+"Count" {
+			patchee.Count = patcher.Count
+			continue
+		}
+		if f == prefix+"BalanceLoop" {
+			patchee.BalanceLoop = patcher.BalanceLoop
+			continue
+		}
+		if f == prefix+"Share" {
+			patchee.Share = patcher.Share
+			continue
+		}
+		if f == prefix+"Timestamp" {
+			patchee.Timestamp = patcher.Timestamp
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListAddressStat executes a gorm list call
+func DefaultListAddressStat(ctx context.Context, db *gorm1.DB) ([]*AddressStat, error) {
+	in := AddressStat{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressStatORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &AddressStatORM{}, &AddressStat{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressStatORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("type")
+	ormResponse := []AddressStatORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressStatORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*AddressStat{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type AddressStatORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressStatORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressStatORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]AddressStatORM) error
+}
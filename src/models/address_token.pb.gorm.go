@@ -21,8 +21,11 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type AddressTokenORM struct {
-	PublicKey            string `gorm:"primary_key"`
-	TokenContractAddress string `gorm:"primary_key"`
+	Balance                string  `gorm:"default:0x0"`
+	BalanceDecimal         float64 `gorm:"default:0;index:address_token_idx_token_contract_address_balance_decimal,priority:2"`
+	LastUpdatedBlockNumber uint64  `gorm:"default:0"`
+	PublicKey              string  `gorm:"primary_key"`
+	TokenContractAddress   string  `gorm:"primary_key;index:address_token_idx_token_contract_address_balance_decimal,priority:1"`
 }
 
 // TableName overrides the default tablename generated by GORM
@@ -42,6 +45,9 @@ func (m *AddressToken) ToORM(ctx context.Context) (AddressTokenORM, error) {
 	}
 	to.PublicKey = m.PublicKey
 	to.TokenContractAddress = m.TokenContractAddress
+	to.Balance = m.Balance
+	to.BalanceDecimal = m.BalanceDecimal
+	to.LastUpdatedBlockNumber = m.LastUpdatedBlockNumber
 	if posthook, ok := interface{}(m).(AddressTokenWithAfterToORM); ok {
 		err = posthook.AfterToORM(ctx, &to)
 	}
@@ -60,6 +66,9 @@ func (m *AddressTokenORM) ToPB(ctx context.Context) (AddressToken, error) {
 	}
 	to.PublicKey = m.PublicKey
 	to.TokenContractAddress = m.TokenContractAddress
+	to.Balance = m.Balance
+	to.BalanceDecimal = m.BalanceDecimal
+	to.LastUpdatedBlockNumber = m.LastUpdatedBlockNumber
 	if posthook, ok := interface{}(m).(AddressTokenWithAfterToPB); ok {
 		err = posthook.AfterToPB(ctx, &to)
 	}
@@ -139,6 +148,18 @@ func DefaultApplyFieldMaskAddressToken(ctx context.Context, patchee *AddressToke
 			patchee.TokenContractAddress = patcher.TokenContractAddress
 			continue
 		}
+		if f == prefix+"Balance" {
+			patchee.Balance = patcher.Balance
+			continue
+		}
+		if f == prefix+"BalanceDecimal" {
+			patchee.BalanceDecimal = patcher.BalanceDecimal
+			continue
+		}
+		if f == prefix+"LastUpdatedBlockNumber" {
+			patchee.LastUpdatedBlockNumber = patcher.LastUpdatedBlockNumber
+			continue
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: address_unstake.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type AddressUnstakeORM struct {
+	PublicKey         string `gorm:"primary_key"`
+	UnlockBlockNumber uint64
+	UnstakeIndex      uint64 `gorm:"primary_key"`
+	ValueLoop         string `gorm:"type:numeric(78,0);default:0"`
+}
+
+// TableName overrides the default tablename generated by GORM
+func (AddressUnstakeORM) TableName() string {
+	return "address_unstakes"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *AddressUnstake) ToORM(ctx context.Context) (AddressUnstakeORM, error) {
+	to := AddressUnstakeORM{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressUnstakeWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.PublicKey = m.PublicKey
+	to.UnstakeIndex = m.UnstakeIndex
+	to.ValueLoop = m.ValueLoop
+	to.UnlockBlockNumber = m.UnlockBlockNumber
+	if posthook, ok := interface{}(m).(AddressUnstakeWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *AddressUnstakeORM) ToPB(ctx context.Context) (AddressUnstake, error) {
+	to := AddressUnstake{}
+	var err error
+	if prehook, ok := interface{}(m).(AddressUnstakeWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.PublicKey = m.PublicKey
+	to.UnstakeIndex = m.UnstakeIndex
+	to.ValueLoop = m.ValueLoop
+	to.UnlockBlockNumber = m.UnlockBlockNumber
+	if posthook, ok := interface{}(m).(AddressUnstakeWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type AddressUnstake the arg will be the target, the caller the one being converted from
+
+// AddressUnstakeBeforeToORM called before default ToORM code
+type AddressUnstakeWithBeforeToORM interface {
+	BeforeToORM(context.Context, *AddressUnstakeORM) error
+}
+
+// AddressUnstakeAfterToORM called after default ToORM code
+type AddressUnstakeWithAfterToORM interface {
+	AfterToORM(context.Context, *AddressUnstakeORM) error
+}
+
+// AddressUnstakeBeforeToPB called before default ToPB code
+type AddressUnstakeWithBeforeToPB interface {
+	BeforeToPB(context.Context, *AddressUnstake) error
+}
+
+// AddressUnstakeAfterToPB called after default ToPB code
+type AddressUnstakeWithAfterToPB interface {
+	AfterToPB(context.Context, *AddressUnstake) error
+}
+
+// DefaultCreateAddressUnstake executes a basic gorm create call
+func DefaultCreateAddressUnstake(ctx context.Context, in *AddressUnstake, db *gorm1.DB) (*AddressUnstake, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressUnstakeORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressUnstakeORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type AddressUnstakeORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressUnstakeORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskAddressUnstake patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskAddressUnstake(ctx context.Context, patchee *AddressUnstake, patcher *AddressUnstake, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*AddressUnstake, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"PublicKey" {
+			patchee.PublicKey = patcher.PublicKey
+			continue
+		}
+		if f == prefix+"UnstakeIndex" {
+			patchee.UnstakeIndex = patcher.UnstakeIndex
+			continue
+		}
+		if f == prefix+"ValueLoop" {
+			patchee.ValueLoop = patcher.ValueLoop
+			continue
+		}
+		if f == prefix+"UnlockBlockNumber" {
+			patchee.UnlockBlockNumber = patcher.UnlockBlockNumber
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListAddressUnstake executes a gorm list call
+func DefaultListAddressUnstake(ctx context.Context, db *gorm1.DB) ([]*AddressUnstake, error) {
+	in := AddressUnstake{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressUnstakeORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &AddressUnstakeORM{}, &AddressUnstake{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressUnstakeORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("public_key")
+	ormResponse := []AddressUnstakeORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(AddressUnstakeORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*AddressUnstake{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type AddressUnstakeORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressUnstakeORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type AddressUnstakeORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]AddressUnstakeORM) error
+}
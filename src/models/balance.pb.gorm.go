@@ -23,11 +23,12 @@ var _ = math.Inf
 type BalanceORM struct {
 	BlockNumber      uint64 `gorm:"primary_key"`
 	LogIndex         int32  `gorm:"primary_key"`
-	PublicKey        string `gorm:"primary_key"`
+	PublicKey        string `gorm:"primary_key;index:balance_idx_public_key"`
 	Timestamp        uint64 `gorm:"index:balance_idx_timestamp"`
 	TransactionIndex uint32 `gorm:"primary_key"`
 	Value            string
 	ValueDecimal     float64
+	ValueLoop        string `gorm:"type:numeric(78,0);default:0"`
 }
 
 // TableName overrides the default tablename generated by GORM
@@ -52,6 +53,7 @@ func (m *Balance) ToORM(ctx context.Context) (BalanceORM, error) {
 	to.Value = m.Value
 	to.ValueDecimal = m.ValueDecimal
 	to.Timestamp = m.Timestamp
+	to.ValueLoop = m.ValueLoop
 	if posthook, ok := interface{}(m).(BalanceWithAfterToORM); ok {
 		err = posthook.AfterToORM(ctx, &to)
 	}
@@ -75,6 +77,7 @@ func (m *BalanceORM) ToPB(ctx context.Context) (Balance, error) {
 	to.Value = m.Value
 	to.ValueDecimal = m.ValueDecimal
 	to.Timestamp = m.Timestamp
+	to.ValueLoop = m.ValueLoop
 	if posthook, ok := interface{}(m).(BalanceWithAfterToPB); ok {
 		err = posthook.AfterToPB(ctx, &to)
 	}
@@ -174,6 +177,10 @@ func DefaultApplyFieldMaskBalance(ctx context.Context, patchee *Balance, patcher
 			patchee.Timestamp = patcher.Timestamp
 			continue
 		}
+		if f == prefix+"ValueLoop" {
+			patchee.ValueLoop = patcher.ValueLoop
+			continue
+		}
 	}
 	if err != nil {
 		return nil, err
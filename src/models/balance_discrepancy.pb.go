@@ -0,0 +1,232 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.6.1
+// source: balance_discrepancy.proto
+
+package models
+
+import (
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Address whose balances ledger does not match the node
+// Values are exact in loop, decimal strings
+type BalanceDiscrepancy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey         string `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key"`
+	BlockNumber       uint64 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number"`                  // Block the values were compared at
+	FirstBlockNumber  uint64 `protobuf:"varint,3,opt,name=first_block_number,json=firstBlockNumber,proto3" json:"first_block_number"` // First block the values differ
+	LedgerValue       string `protobuf:"bytes,4,opt,name=ledger_value,json=ledgerValue,proto3" json:"ledger_value"`                   // Latest balances row
+	NodeValue         string `protobuf:"bytes,5,opt,name=node_value,json=nodeValue,proto3" json:"node_value"`                         // Balance, staked and unstaking balance
+	Difference        string `protobuf:"bytes,6,opt,name=difference,proto3" json:"difference"`                                        // node_value - ledger_value
+	DetectedTimestamp uint64 `protobuf:"varint,7,opt,name=detected_timestamp,json=detectedTimestamp,proto3" json:"detected_timestamp"`
+	UpdatedTimestamp  uint64 `protobuf:"varint,8,opt,name=updated_timestamp,json=updatedTimestamp,proto3" json:"updated_timestamp"`
+}
+
+func (x *BalanceDiscrepancy) Reset() {
+	*x = BalanceDiscrepancy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_balance_discrepancy_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BalanceDiscrepancy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceDiscrepancy) ProtoMessage() {}
+
+func (x *BalanceDiscrepancy) ProtoReflect() protoreflect.Message {
+	mi := &file_balance_discrepancy_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceDiscrepancy.ProtoReflect.Descriptor instead.
+func (*BalanceDiscrepancy) Descriptor() ([]byte, []int) {
+	return file_balance_discrepancy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BalanceDiscrepancy) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *BalanceDiscrepancy) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *BalanceDiscrepancy) GetFirstBlockNumber() uint64 {
+	if x != nil {
+		return x.FirstBlockNumber
+	}
+	return 0
+}
+
+func (x *BalanceDiscrepancy) GetLedgerValue() string {
+	if x != nil {
+		return x.LedgerValue
+	}
+	return ""
+}
+
+func (x *BalanceDiscrepancy) GetNodeValue() string {
+	if x != nil {
+		return x.NodeValue
+	}
+	return ""
+}
+
+func (x *BalanceDiscrepancy) GetDifference() string {
+	if x != nil {
+		return x.Difference
+	}
+	return ""
+}
+
+func (x *BalanceDiscrepancy) GetDetectedTimestamp() uint64 {
+	if x != nil {
+		return x.DetectedTimestamp
+	}
+	return 0
+}
+
+func (x *BalanceDiscrepancy) GetUpdatedTimestamp() uint64 {
+	if x != nil {
+		return x.UpdatedTimestamp
+	}
+	return 0
+}
+
+var File_balance_discrepancy_proto protoreflect.FileDescriptor
+
+var file_balance_discrepancy_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x72, 0x65,
+	0x70, 0x61, 0x6e, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x73, 0x1a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x6f, 0x70, 0x65, 0x6e, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x67, 0x6f, 0x72, 0x6d, 0x2f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x67, 0x6f, 0x72, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xd6, 0x03, 0x0a, 0x12, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x44, 0x69, 0x73, 0x63, 0x72,
+	0x65, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x12, 0x27, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xba, 0xb9, 0x19, 0x04,
+	0x0a, 0x02, 0x28, 0x01, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12,
+	0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x12, 0x60, 0x0a, 0x12, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x42, 0x32,
+	0xba, 0xb9, 0x19, 0x2e, 0x0a, 0x2c, 0x52, 0x2a, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f,
+	0x64, 0x69, 0x73, 0x63, 0x72, 0x65, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x5f, 0x69, 0x64, 0x78, 0x5f,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x52, 0x10, 0x66, 0x69, 0x72, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0c, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xba, 0xb9, 0x19, 0x14,
+	0x0a, 0x12, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28, 0x37, 0x38, 0x2c, 0x30,
+	0x29, 0x3a, 0x01, 0x30, 0x52, 0x0b, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x12, 0x37, 0x0a, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18, 0xba, 0xb9, 0x19, 0x14, 0x0a, 0x12, 0x12, 0x0d, 0x6e,
+	0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28, 0x37, 0x38, 0x2c, 0x30, 0x29, 0x3a, 0x01, 0x30, 0x52,
+	0x09, 0x6e, 0x6f, 0x64, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x0a, 0x64, 0x69,
+	0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42, 0x18,
+	0xba, 0xb9, 0x19, 0x14, 0x0a, 0x12, 0x12, 0x0d, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x28,
+	0x37, 0x38, 0x2c, 0x30, 0x29, 0x3a, 0x01, 0x30, 0x52, 0x0a, 0x64, 0x69, 0x66, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x11, 0x64, 0x65, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x2b, 0x0a, 0x11, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x3a, 0x06, 0xba, 0xb9, 0x19, 0x02, 0x08, 0x01, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_balance_discrepancy_proto_rawDescOnce sync.Once
+	file_balance_discrepancy_proto_rawDescData = file_balance_discrepancy_proto_rawDesc
+)
+
+func file_balance_discrepancy_proto_rawDescGZIP() []byte {
+	file_balance_discrepancy_proto_rawDescOnce.Do(func() {
+		file_balance_discrepancy_proto_rawDescData = protoimpl.X.CompressGZIP(file_balance_discrepancy_proto_rawDescData)
+	})
+	return file_balance_discrepancy_proto_rawDescData
+}
+
+var file_balance_discrepancy_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_balance_discrepancy_proto_goTypes = []interface{}{
+	(*BalanceDiscrepancy)(nil), // 0: models.BalanceDiscrepancy
+}
+var file_balance_discrepancy_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_balance_discrepancy_proto_init() }
+func file_balance_discrepancy_proto_init() {
+	if File_balance_discrepancy_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_balance_discrepancy_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BalanceDiscrepancy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_balance_discrepancy_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_balance_discrepancy_proto_goTypes,
+		DependencyIndexes: file_balance_discrepancy_proto_depIdxs,
+		MessageInfos:      file_balance_discrepancy_proto_msgTypes,
+	}.Build()
+	File_balance_discrepancy_proto = out.File
+	file_balance_discrepancy_proto_rawDesc = nil
+	file_balance_discrepancy_proto_goTypes = nil
+	file_balance_discrepancy_proto_depIdxs = nil
+}
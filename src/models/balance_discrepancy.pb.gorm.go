@@ -0,0 +1,242 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: balance_discrepancy.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type BalanceDiscrepancyORM struct {
+	BlockNumber       uint64
+	DetectedTimestamp uint64
+	Difference        string `gorm:"type:numeric(78,0);default:0"`
+	FirstBlockNumber  uint64 `gorm:"index:balance_discrepancy_idx_first_block_number"`
+	LedgerValue       string `gorm:"type:numeric(78,0);default:0"`
+	NodeValue         string `gorm:"type:numeric(78,0);default:0"`
+	PublicKey         string `gorm:"primary_key"`
+	UpdatedTimestamp  uint64
+}
+
+// TableName overrides the default tablename generated by GORM
+func (BalanceDiscrepancyORM) TableName() string {
+	return "balance_discrepancies"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *BalanceDiscrepancy) ToORM(ctx context.Context) (BalanceDiscrepancyORM, error) {
+	to := BalanceDiscrepancyORM{}
+	var err error
+	if prehook, ok := interface{}(m).(BalanceDiscrepancyWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.PublicKey = m.PublicKey
+	to.BlockNumber = m.BlockNumber
+	to.FirstBlockNumber = m.FirstBlockNumber
+	to.LedgerValue = m.LedgerValue
+	to.NodeValue = m.NodeValue
+	to.Difference = m.Difference
+	to.DetectedTimestamp = m.DetectedTimestamp
+	to.UpdatedTimestamp = m.UpdatedTimestamp
+	if posthook, ok := interface{}(m).(BalanceDiscrepancyWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *BalanceDiscrepancyORM) ToPB(ctx context.Context) (BalanceDiscrepancy, error) {
+	to := BalanceDiscrepancy{}
+	var err error
+	if prehook, ok := interface{}(m).(BalanceDiscrepancyWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.PublicKey = m.PublicKey
+	to.BlockNumber = m.BlockNumber
+	to.FirstBlockNumber = m.FirstBlockNumber
+	to.LedgerValue = m.LedgerValue
+	to.NodeValue = m.NodeValue
+	to.Difference = m.Difference
+	to.DetectedTimestamp = m.DetectedTimestamp
+	to.UpdatedTimestamp = m.UpdatedTimestamp
+	if posthook, ok := interface{}(m).(BalanceDiscrepancyWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type BalanceDiscrepancy the arg will be the target, the caller the one being converted from
+
+// BalanceDiscrepancyBeforeToORM called before default ToORM code
+type BalanceDiscrepancyWithBeforeToORM interface {
+	BeforeToORM(context.Context, *BalanceDiscrepancyORM) error
+}
+
+// BalanceDiscrepancyAfterToORM called after default ToORM code
+type BalanceDiscrepancyWithAfterToORM interface {
+	AfterToORM(context.Context, *BalanceDiscrepancyORM) error
+}
+
+// BalanceDiscrepancyBeforeToPB called before default ToPB code
+type BalanceDiscrepancyWithBeforeToPB interface {
+	BeforeToPB(context.Context, *BalanceDiscrepancy) error
+}
+
+// BalanceDiscrepancyAfterToPB called after default ToPB code
+type BalanceDiscrepancyWithAfterToPB interface {
+	AfterToPB(context.Context, *BalanceDiscrepancy) error
+}
+
+// DefaultCreateBalanceDiscrepancy executes a basic gorm create call
+func DefaultCreateBalanceDiscrepancy(ctx context.Context, in *BalanceDiscrepancy, db *gorm1.DB) (*BalanceDiscrepancy, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BalanceDiscrepancyORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BalanceDiscrepancyORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type BalanceDiscrepancyORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BalanceDiscrepancyORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskBalanceDiscrepancy patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskBalanceDiscrepancy(ctx context.Context, patchee *BalanceDiscrepancy, patcher *BalanceDiscrepancy, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*BalanceDiscrepancy, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"PublicKey" {
+			patchee.PublicKey = patcher.PublicKey
+			continue
+		}
+		if f == prefix+"BlockNumber" {
+			patchee.BlockNumber = patcher.BlockNumber
+			continue
+		}
+		if f == prefix+"FirstBlockNumber" {
+			patchee.FirstBlockNumber = patcher.FirstBlockNumber
+			continue
+		}
+		if f == prefix+"LedgerValue" {
+			patchee.LedgerValue = patcher.LedgerValue
+			continue
+		}
+		if f == prefix+"NodeValue" {
+			patchee.NodeValue = patcher.NodeValue
+			continue
+		}
+		if f == prefix+"Difference" {
+			patchee.Difference = patcher.Difference
+			continue
+		}
+		if f == prefix+"DetectedTimestamp" {
+			patchee.DetectedTimestamp = patcher.DetectedTimestamp
+			continue
+		}
+		if f == prefix+"UpdatedTimestamp" {
+			patchee.UpdatedTimestamp = patcher.UpdatedTimestamp
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListBalanceDiscrepancy executes a gorm list call
+func DefaultListBalanceDiscrepancy(ctx context.Context, db *gorm1.DB) ([]*BalanceDiscrepancy, error) {
+	in := BalanceDiscrepancy{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BalanceDiscrepancyORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &BalanceDiscrepancyORM{}, &BalanceDiscrepancy{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BalanceDiscrepancyORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("public_key")
+	ormResponse := []BalanceDiscrepancyORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BalanceDiscrepancyORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*BalanceDiscrepancy{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type BalanceDiscrepancyORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BalanceDiscrepancyORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BalanceDiscrepancyORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]BalanceDiscrepancyORM) error
+}
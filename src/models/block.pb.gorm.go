@@ -21,8 +21,10 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type BlockORM struct {
+	Hash             string
 	LogCount         uint32
 	Number           uint32 `gorm:"primary_key"`
+	ParentHash       string
 	TransactionCount uint32
 }
 
@@ -44,6 +46,8 @@ func (m *Block) ToORM(ctx context.Context) (BlockORM, error) {
 	to.Number = m.Number
 	to.TransactionCount = m.TransactionCount
 	to.LogCount = m.LogCount
+	to.Hash = m.Hash
+	to.ParentHash = m.ParentHash
 	if posthook, ok := interface{}(m).(BlockWithAfterToORM); ok {
 		err = posthook.AfterToORM(ctx, &to)
 	}
@@ -63,6 +67,8 @@ func (m *BlockORM) ToPB(ctx context.Context) (Block, error) {
 	to.Number = m.Number
 	to.TransactionCount = m.TransactionCount
 	to.LogCount = m.LogCount
+	to.Hash = m.Hash
+	to.ParentHash = m.ParentHash
 	if posthook, ok := interface{}(m).(BlockWithAfterToPB); ok {
 		err = posthook.AfterToPB(ctx, &to)
 	}
@@ -146,6 +152,14 @@ func DefaultApplyFieldMaskBlock(ctx context.Context, patchee *Block, patcher *Bl
 			patchee.LogCount = patcher.LogCount
 			continue
 		}
+		if f == prefix+"Hash" {
+			patchee.Hash = patcher.Hash
+			continue
+		}
+		if f == prefix+"ParentHash" {
+			patchee.ParentHash = patcher.ParentHash
+			continue
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: builder_checkpoint.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type BuilderCheckpointORM struct {
+	BlockNumber              uint64
+	Builder                  string `gorm:"primary_key"`
+	Checksum                 string
+	ChecksumStartBlockNumber uint64
+	Shard                    uint32 `gorm:"primary_key"`
+	ShardCount               uint32 `gorm:"primary_key"`
+	UpdatedTimestamp         uint64
+}
+
+// TableName overrides the default tablename generated by GORM
+func (BuilderCheckpointORM) TableName() string {
+	return "builder_checkpoints"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *BuilderCheckpoint) ToORM(ctx context.Context) (BuilderCheckpointORM, error) {
+	to := BuilderCheckpointORM{}
+	var err error
+	if prehook, ok := interface{}(m).(BuilderCheckpointWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Builder = m.Builder
+	to.Shard = m.Shard
+	to.ShardCount = m.ShardCount
+	to.BlockNumber = m.BlockNumber
+	to.UpdatedTimestamp = m.UpdatedTimestamp
+	to.Checksum = m.Checksum
+	to.ChecksumStartBlockNumber = m.ChecksumStartBlockNumber
+	if posthook, ok := interface{}(m).(BuilderCheckpointWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *BuilderCheckpointORM) ToPB(ctx context.Context) (BuilderCheckpoint, error) {
+	to := BuilderCheckpoint{}
+	var err error
+	if prehook, ok := interface{}(m).(BuilderCheckpointWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Builder = m.Builder
+	to.Shard = m.Shard
+	to.ShardCount = m.ShardCount
+	to.BlockNumber = m.BlockNumber
+	to.UpdatedTimestamp = m.UpdatedTimestamp
+	to.Checksum = m.Checksum
+	to.ChecksumStartBlockNumber = m.ChecksumStartBlockNumber
+	if posthook, ok := interface{}(m).(BuilderCheckpointWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type BuilderCheckpoint the arg will be the target, the caller the one being converted from
+
+// BuilderCheckpointBeforeToORM called before default ToORM code
+type BuilderCheckpointWithBeforeToORM interface {
+	BeforeToORM(context.Context, *BuilderCheckpointORM) error
+}
+
+// BuilderCheckpointAfterToORM called after default ToORM code
+type BuilderCheckpointWithAfterToORM interface {
+	AfterToORM(context.Context, *BuilderCheckpointORM) error
+}
+
+// BuilderCheckpointBeforeToPB called before default ToPB code
+type BuilderCheckpointWithBeforeToPB interface {
+	BeforeToPB(context.Context, *BuilderCheckpoint) error
+}
+
+// BuilderCheckpointAfterToPB called after default ToPB code
+type BuilderCheckpointWithAfterToPB interface {
+	AfterToPB(context.Context, *BuilderCheckpoint) error
+}
+
+// DefaultCreateBuilderCheckpoint executes a basic gorm create call
+func DefaultCreateBuilderCheckpoint(ctx context.Context, in *BuilderCheckpoint, db *gorm1.DB) (*BuilderCheckpoint, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BuilderCheckpointORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BuilderCheckpointORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type BuilderCheckpointORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BuilderCheckpointORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskBuilderCheckpoint patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskBuilderCheckpoint(ctx context.Context, patchee *BuilderCheckpoint, patcher *BuilderCheckpoint, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*BuilderCheckpoint, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Builder" {
+			patchee.Builder = patcher.Builder
+			continue
+		}
+		if f == prefix+"Shard" {
+			patchee.Shard = patcher.Shard
+			continue
+		}
+		if f == prefix+"ShardCount" {
+			patchee.ShardCount = patcher.ShardCount
+			continue
+		}
+		if f == prefix+"BlockNumber" {
+			patchee.BlockNumber = patcher.BlockNumber
+			continue
+		}
+		if f == prefix+"UpdatedTimestamp" {
+			patchee.UpdatedTimestamp = patcher.UpdatedTimestamp
+			continue
+		}
+		if f == prefix+"Checksum" {
+			patchee.Checksum = patcher.Checksum
+			continue
+		}
+		if f == prefix+"ChecksumStartBlockNumber" {
+			patchee.ChecksumStartBlockNumber = patcher.ChecksumStartBlockNumber
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListBuilderCheckpoint executes a gorm list call
+func DefaultListBuilderCheckpoint(ctx context.Context, db *gorm1.DB) ([]*BuilderCheckpoint, error) {
+	in := BuilderCheckpoint{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BuilderCheckpointORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &BuilderCheckpointORM{}, &BuilderCheckpoint{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BuilderCheckpointORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("builder")
+	ormResponse := []BuilderCheckpointORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(BuilderCheckpointORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*BuilderCheckpoint{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type BuilderCheckpointORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BuilderCheckpointORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type BuilderCheckpointORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]BuilderCheckpointORM) error
+}
@@ -0,0 +1,242 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: failed_message.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type FailedMessageORM struct {
+	CreatedTimestamp  uint64
+	Error             string
+	Key               []byte
+	Offset            int64  `gorm:"primary_key"`
+	Partition         int32  `gorm:"primary_key"`
+	ReplayedTimestamp uint64 `gorm:"index:failed_message_idx_replayed_timestamp"`
+	Topic             string `gorm:"primary_key"`
+	Value             []byte
+}
+
+// TableName overrides the default tablename generated by GORM
+func (FailedMessageORM) TableName() string {
+	return "failed_messages"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *FailedMessage) ToORM(ctx context.Context) (FailedMessageORM, error) {
+	to := FailedMessageORM{}
+	var err error
+	if prehook, ok := interface{}(m).(FailedMessageWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Topic = m.Topic
+	to.Partition = m.Partition
+	to.Offset = m.Offset
+	to.Key = m.Key
+	to.Value = m.Value
+	to.Error = m.Error
+	to.CreatedTimestamp = m.CreatedTimestamp
+	to.ReplayedTimestamp = m.ReplayedTimestamp
+	if posthook, ok := interface{}(m).(FailedMessageWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *FailedMessageORM) ToPB(ctx context.Context) (FailedMessage, error) {
+	to := FailedMessage{}
+	var err error
+	if prehook, ok := interface{}(m).(FailedMessageWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.Topic = m.Topic
+	to.Partition = m.Partition
+	to.Offset = m.Offset
+	to.Key = m.Key
+	to.Value = m.Value
+	to.Error = m.Error
+	to.CreatedTimestamp = m.CreatedTimestamp
+	to.ReplayedTimestamp = m.ReplayedTimestamp
+	if posthook, ok := interface{}(m).(FailedMessageWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type FailedMessage the arg will be the target, the caller the one being converted from
+
+// FailedMessageBeforeToORM called before default ToORM code
+type FailedMessageWithBeforeToORM interface {
+	BeforeToORM(context.Context, *FailedMessageORM) error
+}
+
+// FailedMessageAfterToORM called after default ToORM code
+type FailedMessageWithAfterToORM interface {
+	AfterToORM(context.Context, *FailedMessageORM) error
+}
+
+// FailedMessageBeforeToPB called before default ToPB code
+type FailedMessageWithBeforeToPB interface {
+	BeforeToPB(context.Context, *FailedMessage) error
+}
+
+// FailedMessageAfterToPB called after default ToPB code
+type FailedMessageWithAfterToPB interface {
+	AfterToPB(context.Context, *FailedMessage) error
+}
+
+// DefaultCreateFailedMessage executes a basic gorm create call
+func DefaultCreateFailedMessage(ctx context.Context, in *FailedMessage, db *gorm1.DB) (*FailedMessage, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FailedMessageORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FailedMessageORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type FailedMessageORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type FailedMessageORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskFailedMessage patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskFailedMessage(ctx context.Context, patchee *FailedMessage, patcher *FailedMessage, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*FailedMessage, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"Topic" {
+			patchee.Topic = patcher.Topic
+			continue
+		}
+		if f == prefix+"Partition" {
+			patchee.Partition = patcher.Partition
+			continue
+		}
+		if f == prefix+"Offset" {
+			patchee.Offset = patcher.Offset
+			continue
+		}
+		if f == prefix+"Key" {
+			patchee.Key = patcher.Key
+			continue
+		}
+		if f == prefix+"Value" {
+			patchee.Value = patcher.Value
+			continue
+		}
+		if f == prefix+"Error" {
+			patchee.Error = patcher.Error
+			continue
+		}
+		if f == prefix+"CreatedTimestamp" {
+			patchee.CreatedTimestamp = patcher.CreatedTimestamp
+			continue
+		}
+		if f == prefix+"ReplayedTimestamp" {
+			patchee.ReplayedTimestamp = patcher.ReplayedTimestamp
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListFailedMessage executes a gorm list call
+func DefaultListFailedMessage(ctx context.Context, db *gorm1.DB) ([]*FailedMessage, error) {
+	in := FailedMessage{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FailedMessageORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &FailedMessageORM{}, &FailedMessage{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FailedMessageORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("topic")
+	ormResponse := []FailedMessageORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(FailedMessageORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*FailedMessage{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type FailedMessageORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type FailedMessageORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type FailedMessageORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]FailedMessageORM) error
+}
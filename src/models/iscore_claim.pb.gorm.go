@@ -0,0 +1,242 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: iscore_claim.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type IScoreClaimORM struct {
+	BlockHash        string `gorm:"index:iscore_claim_idx_block_hash"`
+	BlockNumber      uint64 `gorm:"index:iscore_claim_idx_block_number"`
+	BlockTimestamp   uint64
+	LogIndex         int32  `gorm:"primary_key"`
+	PublicKey        string `gorm:"index:iscore_claim_idx_public_key"`
+	TransactionHash  string `gorm:"primary_key"`
+	TransactionIndex uint32
+	Value            string
+}
+
+// TableName overrides the default tablename generated by GORM
+func (IScoreClaimORM) TableName() string {
+	return "i_score_claims"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *IScoreClaim) ToORM(ctx context.Context) (IScoreClaimORM, error) {
+	to := IScoreClaimORM{}
+	var err error
+	if prehook, ok := interface{}(m).(IScoreClaimWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.TransactionHash = m.TransactionHash
+	to.LogIndex = m.LogIndex
+	to.PublicKey = m.PublicKey
+	to.Value = m.Value
+	to.BlockNumber = m.BlockNumber
+	to.TransactionIndex = m.TransactionIndex
+	to.BlockTimestamp = m.BlockTimestamp
+	to.BlockHash = m.BlockHash
+	if posthook, ok := interface{}(m).(IScoreClaimWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *IScoreClaimORM) ToPB(ctx context.Context) (IScoreClaim, error) {
+	to := IScoreClaim{}
+	var err error
+	if prehook, ok := interface{}(m).(IScoreClaimWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.TransactionHash = m.TransactionHash
+	to.LogIndex = m.LogIndex
+	to.PublicKey = m.PublicKey
+	to.Value = m.Value
+	to.BlockNumber = m.BlockNumber
+	to.TransactionIndex = m.TransactionIndex
+	to.BlockTimestamp = m.BlockTimestamp
+	to.BlockHash = m.BlockHash
+	if posthook, ok := interface{}(m).(IScoreClaimWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type IScoreClaim the arg will be the target, the caller the one being converted from
+
+// IScoreClaimBeforeToORM called before default ToORM code
+type IScoreClaimWithBeforeToORM interface {
+	BeforeToORM(context.Context, *IScoreClaimORM) error
+}
+
+// IScoreClaimAfterToORM called after default ToORM code
+type IScoreClaimWithAfterToORM interface {
+	AfterToORM(context.Context, *IScoreClaimORM) error
+}
+
+// IScoreClaimBeforeToPB called before default ToPB code
+type IScoreClaimWithBeforeToPB interface {
+	BeforeToPB(context.Context, *IScoreClaim) error
+}
+
+// IScoreClaimAfterToPB called after default ToPB code
+type IScoreClaimWithAfterToPB interface {
+	AfterToPB(context.Context, *IScoreClaim) error
+}
+
+// DefaultCreateIScoreClaim executes a basic gorm create call
+func DefaultCreateIScoreClaim(ctx context.Context, in *IScoreClaim, db *gorm1.DB) (*IScoreClaim, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(IScoreClaimORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(IScoreClaimORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type IScoreClaimORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type IScoreClaimORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskIScoreClaim patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskIScoreClaim(ctx context.Context, patchee *IScoreClaim, patcher *IScoreClaim, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*IScoreClaim, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"TransactionHash" {
+			patchee.TransactionHash = patcher.TransactionHash
+			continue
+		}
+		if f == prefix+"LogIndex" {
+			patchee.LogIndex = patcher.LogIndex
+			continue
+		}
+		if f == prefix+"PublicKey" {
+			patchee.PublicKey = patcher.PublicKey
+			continue
+		}
+		if f == prefix+"Value" {
+			patchee.Value = patcher.Value
+			continue
+		}
+		if f == prefix+"BlockNumber" {
+			patchee.BlockNumber = patcher.BlockNumber
+			continue
+		}
+		if f == prefix+"TransactionIndex" {
+			patchee.TransactionIndex = patcher.TransactionIndex
+			continue
+		}
+		if f == prefix+"BlockTimestamp" {
+			patchee.BlockTimestamp = patcher.BlockTimestamp
+			continue
+		}
+		if f == prefix+"BlockHash" {
+			patchee.BlockHash = patcher.BlockHash
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListIScoreClaim executes a gorm list call
+func DefaultListIScoreClaim(ctx context.Context, db *gorm1.DB) ([]*IScoreClaim, error) {
+	in := IScoreClaim{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(IScoreClaimORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &IScoreClaimORM{}, &IScoreClaim{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(IScoreClaimORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("transaction_hash")
+	ormResponse := []IScoreClaimORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(IScoreClaimORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*IScoreClaim{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type IScoreClaimORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type IScoreClaimORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type IScoreClaimORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]IScoreClaimORM) error
+}
@@ -21,14 +21,8 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Represents kafka consumer status, create by k9 bash job
-// CREATE TABLE kafka_jobs (
-//  job_id varchar,
-//  worker_group varchar,
-//  topic varchar,
-//  partition bigint,
-//  stop_offset bigint,
-//  PRIMARY KEY (job_id, worker_group, topic, partition, stop_offset));
+// Represents kafka consumer status, rows are written by the k9 bash job
+// Table is created by migrations/sql/0001_initial.up.sql
 type KafkaJob struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -21,6 +21,7 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type LogCountByBlockNumberORM struct {
+	BlockHash             string `gorm:"primary_key;index:log_count_by_block_number_idx_block_hash"`
 	BlockNumber           uint64 `gorm:"index:log_count_by_block_count_idx_block_count"`
 	Count                 uint32 `gorm:"index:log_count_by_block_count_idx_count"`
 	LogIndex              uint64 `gorm:"primary_key"`
@@ -48,6 +49,7 @@ func (m *LogCountByBlockNumber) ToORM(ctx context.Context) (LogCountByBlockNumbe
 	to.BlockNumber = m.BlockNumber
 	to.Count = m.Count
 	to.MaxCountByTransaction = m.MaxCountByTransaction
+	to.BlockHash = m.BlockHash
 	if posthook, ok := interface{}(m).(LogCountByBlockNumberWithAfterToORM); ok {
 		err = posthook.AfterToORM(ctx, &to)
 	}
@@ -69,6 +71,7 @@ func (m *LogCountByBlockNumberORM) ToPB(ctx context.Context) (LogCountByBlockNum
 	to.BlockNumber = m.BlockNumber
 	to.Count = m.Count
 	to.MaxCountByTransaction = m.MaxCountByTransaction
+	to.BlockHash = m.BlockHash
 	if posthook, ok := interface{}(m).(LogCountByBlockNumberWithAfterToPB); ok {
 		err = posthook.AfterToPB(ctx, &to)
 	}
@@ -160,6 +163,10 @@ func DefaultApplyFieldMaskLogCountByBlockNumber(ctx context.Context, patchee *Lo
 			patchee.MaxCountByTransaction = patcher.MaxCountByTransaction
 			continue
 		}
+		if f == prefix+"BlockHash" {
+			patchee.BlockHash = patcher.BlockHash
+			continue
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -0,0 +1,270 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: token_balance.proto
+
+package models
+
+import (
+	context "context"
+	fmt "fmt"
+	
+	_ "github.com/infobloxopen/protoc-gen-gorm/options"
+	math "math"
+
+	gorm2 "github.com/infobloxopen/atlas-app-toolkit/gorm"
+	errors1 "github.com/infobloxopen/protoc-gen-gorm/errors"
+	gorm1 "github.com/jinzhu/gorm"
+	field_mask1 "google.golang.org/genproto/protobuf/field_mask"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type TokenBalanceORM struct {
+	BlockHash            string `gorm:"index:token_balance_idx_block_hash"`
+	BlockNumber          uint64 `gorm:"primary_key"`
+	LogIndex             int32  `gorm:"primary_key"`
+	PublicKey            string `gorm:"primary_key;index:token_balance_idx_public_key"`
+	Timestamp            uint64
+	TokenContractAddress string `gorm:"index:token_balance_idx_token_contract_address"`
+	TokenDecimals        uint32
+	TransactionHash      string
+	TransactionIndex     uint32 `gorm:"primary_key"`
+	Value                string
+	ValueChange          string
+	ValueDecimal         float64
+}
+
+// TableName overrides the default tablename generated by GORM
+func (TokenBalanceORM) TableName() string {
+	return "token_balances"
+}
+
+// ToORM runs the BeforeToORM hook if present, converts the fields of this
+// object to ORM format, runs the AfterToORM hook, then returns the ORM object
+func (m *TokenBalance) ToORM(ctx context.Context) (TokenBalanceORM, error) {
+	to := TokenBalanceORM{}
+	var err error
+	if prehook, ok := interface{}(m).(TokenBalanceWithBeforeToORM); ok {
+		if err = prehook.BeforeToORM(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.BlockNumber = m.BlockNumber
+	to.TransactionIndex = m.TransactionIndex
+	to.LogIndex = m.LogIndex
+	to.PublicKey = m.PublicKey
+	to.TokenContractAddress = m.TokenContractAddress
+	to.ValueChange = m.ValueChange
+	to.Value = m.Value
+	to.ValueDecimal = m.ValueDecimal
+	to.TokenDecimals = m.TokenDecimals
+	to.Timestamp = m.Timestamp
+	to.BlockHash = m.BlockHash
+	to.TransactionHash = m.TransactionHash
+	if posthook, ok := interface{}(m).(TokenBalanceWithAfterToORM); ok {
+		err = posthook.AfterToORM(ctx, &to)
+	}
+	return to, err
+}
+
+// ToPB runs the BeforeToPB hook if present, converts the fields of this
+// object to PB format, runs the AfterToPB hook, then returns the PB object
+func (m *TokenBalanceORM) ToPB(ctx context.Context) (TokenBalance, error) {
+	to := TokenBalance{}
+	var err error
+	if prehook, ok := interface{}(m).(TokenBalanceWithBeforeToPB); ok {
+		if err = prehook.BeforeToPB(ctx, &to); err != nil {
+			return to, err
+		}
+	}
+	to.BlockNumber = m.BlockNumber
+	to.TransactionIndex = m.TransactionIndex
+	to.LogIndex = m.LogIndex
+	to.PublicKey = m.PublicKey
+	to.TokenContractAddress = m.TokenContractAddress
+	to.ValueChange = m.ValueChange
+	to.Value = m.Value
+	to.ValueDecimal = m.ValueDecimal
+	to.TokenDecimals = m.TokenDecimals
+	to.Timestamp = m.Timestamp
+	to.BlockHash = m.BlockHash
+	to.TransactionHash = m.TransactionHash
+	if posthook, ok := interface{}(m).(TokenBalanceWithAfterToPB); ok {
+		err = posthook.AfterToPB(ctx, &to)
+	}
+	return to, err
+}
+
+// The following are interfaces you can implement for special behavior during ORM/PB conversions
+// of type TokenBalance the arg will be the target, the caller the one being converted from
+
+// TokenBalanceBeforeToORM called before default ToORM code
+type TokenBalanceWithBeforeToORM interface {
+	BeforeToORM(context.Context, *TokenBalanceORM) error
+}
+
+// TokenBalanceAfterToORM called after default ToORM code
+type TokenBalanceWithAfterToORM interface {
+	AfterToORM(context.Context, *TokenBalanceORM) error
+}
+
+// TokenBalanceBeforeToPB called before default ToPB code
+type TokenBalanceWithBeforeToPB interface {
+	BeforeToPB(context.Context, *TokenBalance) error
+}
+
+// TokenBalanceAfterToPB called after default ToPB code
+type TokenBalanceWithAfterToPB interface {
+	AfterToPB(context.Context, *TokenBalance) error
+}
+
+// DefaultCreateTokenBalance executes a basic gorm create call
+func DefaultCreateTokenBalance(ctx context.Context, in *TokenBalance, db *gorm1.DB) (*TokenBalance, error) {
+	if in == nil {
+		return nil, errors1.NilArgumentError
+	}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(TokenBalanceORMWithBeforeCreate_); ok {
+		if db, err = hook.BeforeCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	if err = db.Create(&ormObj).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(TokenBalanceORMWithAfterCreate_); ok {
+		if err = hook.AfterCreate_(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse, err := ormObj.ToPB(ctx)
+	return &pbResponse, err
+}
+
+type TokenBalanceORMWithBeforeCreate_ interface {
+	BeforeCreate_(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type TokenBalanceORMWithAfterCreate_ interface {
+	AfterCreate_(context.Context, *gorm1.DB) error
+}
+
+// DefaultApplyFieldMaskTokenBalance patches an pbObject with patcher according to a field mask.
+func DefaultApplyFieldMaskTokenBalance(ctx context.Context, patchee *TokenBalance, patcher *TokenBalance, updateMask *field_mask1.FieldMask, prefix string, db *gorm1.DB) (*TokenBalance, error) {
+	if patcher == nil {
+		return nil, nil
+	} else if patchee == nil {
+		return nil, errors1.NilArgumentError
+	}
+	var err error
+	for _, f := range updateMask.Paths {
+		if f == prefix+"BlockNumber" {
+			patchee.BlockNumber = patcher.BlockNumber
+			continue
+		}
+		if f == prefix+"TransactionIndex" {
+			patchee.TransactionIndex = patcher.TransactionIndex
+			continue
+		}
+		if f == prefix+"LogIndex" {
+			patchee.LogIndex = patcher.LogIndex
+			continue
+		}
+		if f == prefix+"PublicKey" {
+			patchee.PublicKey = patcher.PublicKey
+			continue
+		}
+		if f == prefix+"TokenContractAddress" {
+			patchee.TokenContractAddress = patcher.TokenContractAddress
+			continue
+		}
+		if f == prefix+"ValueChange" {
+			patchee.ValueChange = patcher.ValueChange
+			continue
+		}
+		if f == prefix+"Value" {
+			patchee.Value = patcher.Value
+			continue
+		}
+		if f == prefix+"ValueDecimal" {
+			patchee.ValueDecimal = patcher.ValueDecimal
+			continue
+		}
+		if f == prefix+"TokenDecimals" {
+			patchee.TokenDecimals = patcher.TokenDecimals
+			continue
+		}
+		if f == prefix+"Timestamp" {
+			patchee.Timestamp = patcher.Timestamp
+			continue
+		}
+		if f == prefix+"BlockHash" {
+			patchee.BlockHash = patcher.BlockHash
+			continue
+		}
+		if f == prefix+"TransactionHash" {
+			patchee.TransactionHash = patcher.TransactionHash
+			continue
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return patchee, nil
+}
+
+// DefaultListTokenBalance executes a gorm list call
+func DefaultListTokenBalance(ctx context.Context, db *gorm1.DB) ([]*TokenBalance, error) {
+	in := TokenBalance{}
+	ormObj, err := in.ToORM(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(TokenBalanceORMWithBeforeListApplyQuery); ok {
+		if db, err = hook.BeforeListApplyQuery(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db, err = gorm2.ApplyCollectionOperators(ctx, db, &TokenBalanceORM{}, &TokenBalance{}, nil, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(TokenBalanceORMWithBeforeListFind); ok {
+		if db, err = hook.BeforeListFind(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+	db = db.Where(&ormObj)
+	db = db.Order("public_key")
+	ormResponse := []TokenBalanceORM{}
+	if err := db.Find(&ormResponse).Error; err != nil {
+		return nil, err
+	}
+	if hook, ok := interface{}(&ormObj).(TokenBalanceORMWithAfterListFind); ok {
+		if err = hook.AfterListFind(ctx, db, &ormResponse); err != nil {
+			return nil, err
+		}
+	}
+	pbResponse := []*TokenBalance{}
+	for _, responseEntry := range ormResponse {
+		temp, err := responseEntry.ToPB(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pbResponse = append(pbResponse, &temp)
+	}
+	return pbResponse, nil
+}
+
+type TokenBalanceORMWithBeforeListApplyQuery interface {
+	BeforeListApplyQuery(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type TokenBalanceORMWithBeforeListFind interface {
+	BeforeListFind(context.Context, *gorm1.DB) (*gorm1.DB, error)
+}
+type TokenBalanceORMWithAfterListFind interface {
+	AfterListFind(context.Context, *gorm1.DB, *[]TokenBalanceORM) error
+}
@@ -0,0 +1,175 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.6.1
+// source: token_holder_api_list.proto
+
+package models
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TokenHolderAPIList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey              string  `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key"`
+	Balance                string  `protobuf:"bytes,2,opt,name=balance,proto3" json:"balance"`
+	BalanceDecimal         float64 `protobuf:"fixed64,3,opt,name=balance_decimal,json=balanceDecimal,proto3" json:"balance_decimal"`
+	LastUpdatedBlockNumber uint64  `protobuf:"varint,4,opt,name=last_updated_block_number,json=lastUpdatedBlockNumber,proto3" json:"last_updated_block_number"`
+}
+
+func (x *TokenHolderAPIList) Reset() {
+	*x = TokenHolderAPIList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_token_holder_api_list_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TokenHolderAPIList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenHolderAPIList) ProtoMessage() {}
+
+func (x *TokenHolderAPIList) ProtoReflect() protoreflect.Message {
+	mi := &file_token_holder_api_list_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenHolderAPIList.ProtoReflect.Descriptor instead.
+func (*TokenHolderAPIList) Descriptor() ([]byte, []int) {
+	return file_token_holder_api_list_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TokenHolderAPIList) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+func (x *TokenHolderAPIList) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *TokenHolderAPIList) GetBalanceDecimal() float64 {
+	if x != nil {
+		return x.BalanceDecimal
+	}
+	return 0
+}
+
+func (x *TokenHolderAPIList) GetLastUpdatedBlockNumber() uint64 {
+	if x != nil {
+		return x.LastUpdatedBlockNumber
+	}
+	return 0
+}
+
+var File_token_holder_api_list_proto protoreflect.FileDescriptor
+
+var file_token_holder_api_list_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x68, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x61,
+	0x70, 0x69, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x22, 0xb1, 0x01, 0x0a, 0x12, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x48,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x41, 0x50, 0x49, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x5f, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e,
+	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x39,
+	0x0a, 0x19, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x16, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_token_holder_api_list_proto_rawDescOnce sync.Once
+	file_token_holder_api_list_proto_rawDescData = file_token_holder_api_list_proto_rawDesc
+)
+
+func file_token_holder_api_list_proto_rawDescGZIP() []byte {
+	file_token_holder_api_list_proto_rawDescOnce.Do(func() {
+		file_token_holder_api_list_proto_rawDescData = protoimpl.X.CompressGZIP(file_token_holder_api_list_proto_rawDescData)
+	})
+	return file_token_holder_api_list_proto_rawDescData
+}
+
+var file_token_holder_api_list_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_token_holder_api_list_proto_goTypes = []interface{}{
+	(*TokenHolderAPIList)(nil), // 0: models.TokenHolderAPIList
+}
+var file_token_holder_api_list_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_token_holder_api_list_proto_init() }
+func file_token_holder_api_list_proto_init() {
+	if File_token_holder_api_list_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_token_holder_api_list_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenHolderAPIList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_token_holder_api_list_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_token_holder_api_list_proto_goTypes,
+		DependencyIndexes: file_token_holder_api_list_proto_depIdxs,
+		MessageInfos:      file_token_holder_api_list_proto_msgTypes,
+	}.Build()
+	File_token_holder_api_list_proto = out.File
+	file_token_holder_api_list_proto_rawDesc = nil
+	file_token_holder_api_list_proto_goTypes = nil
+	file_token_holder_api_list_proto_depIdxs = nil
+}
@@ -21,6 +21,7 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type TransactionORM struct {
+	BlockHash        string `gorm:"index:transaction_idx_block_hash"`
 	BlockNumber      uint64 `gorm:"index:transaction_idx_block_number"`
 	BlockTimestamp   uint64
 	FromAddress      string `gorm:"index:transaction_idx_from_address"`
@@ -56,6 +57,7 @@ func (m *Transaction) ToORM(ctx context.Context) (TransactionORM, error) {
 	to.BlockTimestamp = m.BlockTimestamp
 	to.TransactionFee = m.TransactionFee
 	to.LogIndex = m.LogIndex
+	to.BlockHash = m.BlockHash
 	if posthook, ok := interface{}(m).(TransactionWithAfterToORM); ok {
 		err = posthook.AfterToORM(ctx, &to)
 	}
@@ -81,6 +83,7 @@ func (m *TransactionORM) ToPB(ctx context.Context) (Transaction, error) {
 	to.BlockTimestamp = m.BlockTimestamp
 	to.TransactionFee = m.TransactionFee
 	to.LogIndex = m.LogIndex
+	to.BlockHash = m.BlockHash
 	if posthook, ok := interface{}(m).(TransactionWithAfterToPB); ok {
 		err = posthook.AfterToPB(ctx, &to)
 	}
@@ -188,6 +191,10 @@ func DefaultApplyFieldMaskTransaction(ctx context.Context, patchee *Transaction,
 			patchee.LogIndex = patcher.LogIndex
 			continue
 		}
+		if f == prefix+"BlockHash" {
+			patchee.BlockHash = patcher.BlockHash
+			continue
+		}
 	}
 	if err != nil {
 		return nil, err
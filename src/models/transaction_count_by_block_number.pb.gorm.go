@@ -21,6 +21,7 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type TransactionCountByBlockNumberORM struct {
+	BlockHash       string `gorm:"primary_key;index:transaction_count_by_block_number_idx_block_hash"`
 	BlockNumber     uint64 `gorm:"index:transaction_count_by_block_count_idx_block_count"`
 	Count           uint32 `gorm:"index:transaction_count_by_block_number_idx_count"`
 	TransactionHash string `gorm:"primary_key"`
@@ -44,6 +45,7 @@ func (m *TransactionCountByBlockNumber) ToORM(ctx context.Context) (TransactionC
 	to.TransactionHash = m.TransactionHash
 	to.BlockNumber = m.BlockNumber
 	to.Count = m.Count
+	to.BlockHash = m.BlockHash
 	if posthook, ok := interface{}(m).(TransactionCountByBlockNumberWithAfterToORM); ok {
 		err = posthook.AfterToORM(ctx, &to)
 	}
@@ -63,6 +65,7 @@ func (m *TransactionCountByBlockNumberORM) ToPB(ctx context.Context) (Transactio
 	to.TransactionHash = m.TransactionHash
 	to.BlockNumber = m.BlockNumber
 	to.Count = m.Count
+	to.BlockHash = m.BlockHash
 	if posthook, ok := interface{}(m).(TransactionCountByBlockNumberWithAfterToPB); ok {
 		err = posthook.AfterToPB(ctx, &to)
 	}
@@ -146,6 +149,10 @@ func DefaultApplyFieldMaskTransactionCountByBlockNumber(ctx context.Context, pat
 			patchee.Count = patcher.Count
 			continue
 		}
+		if f == prefix+"BlockHash" {
+			patchee.BlockHash = patcher.BlockHash
+			continue
+		}
 	}
 	if err != nil {
 		return nil, err
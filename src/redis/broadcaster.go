@@ -16,6 +16,9 @@ type Broadcaster struct {
 
 	// Output
 	OutputChannels map[BroadcasterID]chan []byte
+
+	// Guards OutputChannels and lastBroadcasterID
+	mutex sync.RWMutex
 }
 
 var broadcaster *Broadcaster
@@ -34,6 +37,8 @@ func GetBroadcaster() *Broadcaster {
 
 // AddBroadcastChannel - add channel to  broadcaster
 func (b *Broadcaster) AddBroadcastChannel(channel chan []byte) BroadcasterID {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
 
 	id := lastBroadcasterID
 	lastBroadcasterID++
@@ -44,12 +49,23 @@ func (b *Broadcaster) AddBroadcastChannel(channel chan []byte) BroadcasterID {
 }
 
 // RemoveBroadcastChannelnel - remove channel from broadcaster
+// NOTE the channel is not closed, the broadcaster may still be sending to it
 func (b *Broadcaster) RemoveBroadcastChannel(id BroadcasterID) {
+	b.removeBroadcastChannel(id)
+}
+
+// removeBroadcastChannel - remove channel from broadcaster
+// Returns false if the channel was already removed
+func (b *Broadcaster) removeBroadcastChannel(id BroadcasterID) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
 
 	_, ok := b.OutputChannels[id]
 	if ok {
 		delete(b.OutputChannels, id)
 	}
+
+	return ok
 }
 
 // Start - Start broadcaster go routine
@@ -58,11 +74,24 @@ func (b *Broadcaster) Start() {
 		for {
 			msg := <-b.InputChannel
 
+			// Copy output channels
+			// NOTE channels may be removed while broadcasting
+			b.mutex.RLock()
+			outputChannels := make(map[BroadcasterID]chan []byte, len(b.OutputChannels))
 			for id, channel := range b.OutputChannels {
+				outputChannels[id] = channel
+			}
+			b.mutex.RUnlock()
+
+			for id, channel := range outputChannels {
 				select {
 				case channel <- msg:
 				case <-time.After(time.Second * 1):
-					b.RemoveBroadcastChannel(id)
+					// Slow receiver, close the channel so the receiver stops
+					// NOTE only the broadcaster sends and closes, receivers only remove
+					if b.removeBroadcastChannel(id) == true {
+						close(channel)
+					}
 				}
 			}
 		}
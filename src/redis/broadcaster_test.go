@@ -0,0 +1,39 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcasterClosesBlockedChannel(t *testing.T) {
+	assert := assert.New(t)
+
+	b := &Broadcaster{
+		InputChannel:   make(chan []byte),
+		OutputChannels: make(map[BroadcasterID]chan []byte),
+	}
+	b.Start()
+
+	msgChan := make(chan []byte, 1)
+	b.AddBroadcastChannel(msgChan)
+
+	// Fill the buffer, then block
+	b.InputChannel <- []byte("1")
+	b.InputChannel <- []byte("2")
+	b.InputChannel <- []byte("3")
+
+	assert.Equal([]byte("1"), <-msgChan)
+	select {
+	case _, ok := <-msgChan:
+		assert.Equal(false, ok)
+	case <-time.After(3 * time.Second):
+		assert.Fail("blocked channel not closed")
+	}
+
+	// Removed
+	b.mutex.RLock()
+	assert.Equal(0, len(b.OutputChannels))
+	b.mutex.RUnlock()
+}
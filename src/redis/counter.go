@@ -34,3 +34,17 @@ func (c *Client) IncCount(countKey string) (int64, error) {
 
 	return count, err
 }
+
+func (c *Client) IncCountBy(countKey string, increment int64) (int64, error) {
+
+	count, err := c.client.IncrBy(context.Background(), countKey, increment).Result()
+
+	return count, err
+}
+
+func (c *Client) DecCountBy(countKey string, decrement int64) (int64, error) {
+
+	count, err := c.client.DecrBy(context.Background(), countKey, decrement).Result()
+
+	return count, err
+}
@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DirtyAddressesKey - set of addresses touched by new blocks, waiting for a balance refresh
+const DirtyAddressesKey = "icon_addresses_dirty_addresses"
+
+// DirtyAddresses - buffers touched addresses into the dirty addresses set
+// NOTE addresses buffered when the worker stops are lost, the full balance scan picks them up
+type DirtyAddresses struct {
+	InputChannel chan string
+
+	batchSize     int
+	flushInterval time.Duration
+}
+
+var dirtyAddresses *DirtyAddresses
+var dirtyAddressesOnce sync.Once
+
+func GetDirtyAddresses() *DirtyAddresses {
+	dirtyAddressesOnce.Do(func() {
+		dirtyAddresses = &DirtyAddresses{
+			InputChannel:  make(chan string, 1000),
+			batchSize:     1000,
+			flushInterval: time.Second,
+		}
+
+		dirtyAddresses.start()
+	})
+
+	return dirtyAddresses
+}
+
+// Add - mark addresses dirty, empty addresses are skipped
+func (d *DirtyAddresses) Add(publicKeys ...string) {
+	for _, publicKey := range publicKeys {
+		if publicKey == "" {
+			continue
+		}
+
+		d.InputChannel <- publicKey
+	}
+}
+
+// start - flush go routine
+// Deduplicates within a batch, the redis set deduplicates across batches
+func (d *DirtyAddresses) start() {
+	go func() {
+		batch := map[string]bool{}
+		isRetrying := false
+		ticker := time.NewTicker(d.flushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case publicKey := <-d.InputChannel:
+				batch[publicKey] = true
+				if len(batch) < d.batchSize || isRetrying == true {
+					// Failed batches are retried on the interval
+					continue
+				}
+			case <-ticker.C:
+				if len(batch) == 0 {
+					continue
+				}
+			}
+
+			members := make([]string, 0, len(batch))
+			for publicKey := range batch {
+				members = append(members, publicKey)
+			}
+
+			err := GetRedisClient().AddSetMembers(DirtyAddressesKey, members...)
+			if err != nil {
+				// Keep the batch
+				zap.S().Warn("DirtyAddresses: Unable to add ", len(members), " addresses - Error: ", err.Error())
+				isRetrying = true
+				continue
+			}
+
+			batch = map[string]bool{}
+			isRetrying = false
+		}
+	}()
+}
@@ -0,0 +1,32 @@
+package redis
+
+import (
+	"context"
+)
+
+func (c *Client) AddSetMembers(setKey string, members ...string) error {
+
+	values := make([]interface{}, len(members))
+	for i, member := range members {
+		values[i] = member
+	}
+
+	err := c.client.SAdd(context.Background(), setKey, values...).Err()
+
+	return err
+}
+
+// PopSetMembers - remove and return up to count random members
+func (c *Client) PopSetMembers(setKey string, count int64) ([]string, error) {
+
+	members, err := c.client.SPopN(context.Background(), setKey, count).Result()
+
+	return members, err
+}
+
+func (c *Client) CountSetMembers(setKey string) (int64, error) {
+
+	count, err := c.client.SCard(context.Background(), setKey).Result()
+
+	return count, err
+}
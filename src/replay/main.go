@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/kafka"
+	"github.com/geometry-labs/icon-addresses/logging"
+)
+
+// Replay - re-inject failed messages into their original topics
+// Usage: replay [-topic logs] [-limit 100] [-dry-run]
+func main() {
+	topic := flag.String("topic", "", "only replay messages from this topic")
+	limit := flag.Int("limit", 0, "max number of messages to replay, 0 for all")
+	dryRun := flag.Bool("dry-run", false, "list messages without replaying")
+	flag.Parse()
+
+	config.ReadEnvironment()
+
+	logging.Init()
+	log.Printf("Main: Starting logging with level %s", config.Config.LogLevel)
+
+	failedMessages, err := crud.GetFailedMessageModel().SelectManyNotReplayed(*limit, *topic)
+	if err != nil {
+		zap.S().Fatal("Unable to select failed messages: ", err.Error())
+	}
+
+	zap.S().Info("Replay: Found ", len(*failedMessages), " failed messages")
+
+	for i := range *failedMessages {
+		failedMessage := &(*failedMessages)[i]
+
+		zap.S().Info(
+			"TOPIC=", failedMessage.Topic,
+			",PARTITION=", failedMessage.Partition,
+			",OFFSET=", failedMessage.Offset,
+			",ERROR=", failedMessage.Error,
+			" - Replaying message",
+		)
+		if *dryRun == true {
+			continue
+		}
+
+		producerMessage := &sarama.ProducerMessage{
+			Topic: failedMessage.Topic,
+			Value: sarama.ByteEncoder(failedMessage.Value),
+		}
+		if len(failedMessage.Key) > 0 {
+			producerMessage.Key = sarama.ByteEncoder(failedMessage.Key)
+		}
+
+		_, _, err := kafka.GetKafkaProducer().SendMessage(producerMessage)
+		if err != nil {
+			zap.S().Fatal("Unable to replay message: ", err.Error())
+		}
+
+		err = crud.GetFailedMessageModel().UpdateReplayedTimestamp(
+			failedMessage,
+			uint64(time.Now().UnixNano()/1000),
+		)
+		if err != nil {
+			zap.S().Fatal("Unable to mark message as replayed: ", err.Error())
+		}
+	}
+
+	zap.S().Info("Replay: Done")
+}
@@ -0,0 +1,211 @@
+package builders
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/metrics"
+	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/worker/utils"
+)
+
+const addressDailyStatBuilderName = crud.AddressDailyStatBuilderName
+
+// Table builder for address daily stats
+// Follows the last block every balance builder shard completed
+func StartAddressDailyStatBuilder() {
+
+	go startAddressDailyStatBuilder()
+}
+
+func startAddressDailyStatBuilder() {
+
+	for {
+		// Rollbacks wait for the blocks being built
+		balanceBuilderLock.RLock()
+
+		numBlocks, err := buildAddressDailyStatBlocks()
+		balanceBuilderLock.RUnlock()
+
+		if errors.Is(err, crud.ErrBuilderCheckpointMoved) {
+			// Rewound while building
+			// Read the checkpoint again
+			continue
+		} else if err != nil {
+			// Postgres error
+			zap.S().Fatal(
+				"Builder=AddressDailyStatBuilder",
+				" - Error: ", err.Error(),
+			)
+		}
+
+		if numBlocks == 0 {
+			// Blocks not completed by the balance builder
+			// Sleep and try again
+			time.Sleep(3 * time.Second)
+			continue
+		}
+	}
+}
+
+// buildAddressDailyStatBlocks - add the next blocks completed by the balance builder to the days
+// Returns the number of blocks built, 0 if no block is ready
+func buildAddressDailyStatBlocks() (uint64, error) {
+
+	// NOTE the checkpoint is read every batch, it may be rewound by a rollback or an admin
+	checkpoint, err := crud.GetBuilderCheckpointModel().SelectOne(addressDailyStatBuilderName, 0, 1)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		checkpoint = nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	startBlockNumber := uint64(0)
+	if checkpoint != nil {
+		startBlockNumber = checkpoint.BlockNumber + 1
+	}
+
+	completedBlockNumber, err := GetBalanceBuilderCompletedBlockNumber()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Balance builder has not completed a block
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	if completedBlockNumber < startBlockNumber {
+		return 0, nil
+	}
+
+	////////////
+	// Rewind //
+	////////////
+	rewindBlockNumber, err := crud.GetAddressDailyStatModel().SelectRewindBlockNumber(startBlockNumber)
+	if err == nil && rewindBlockNumber < startBlockNumber {
+		// Checkpoint moved into a day, rebuild the day from its first block
+		zap.S().Info(
+			"Builder=AddressDailyStatBuilder,",
+			"BlockNumber=", startBlockNumber,
+			",RewindBlockNumber=", rewindBlockNumber,
+			" - Checkpoint moved, rewinding builder to the start of the day",
+		)
+
+		err = crud.GetBuilderCheckpointModel().RewindOne(addressDailyStatBuilderName, 0, 1, rewindBlockNumber, nil)
+		if err != nil {
+			return 0, err
+		}
+
+		return 0, crud.ErrBuilderCheckpointMoved
+	} else if err != nil && errors.Is(err, gorm.ErrRecordNotFound) == false {
+		return 0, err
+	}
+
+	endBlockNumber := startBlockNumber + uint64(config.Config.AddressDailyStatBuilderBatchBlocks) - 1
+	if endBlockNumber > completedBlockNumber {
+		endBlockNumber = completedBlockNumber
+	}
+
+	transactions, err := crud.GetTransactionModel().SelectManyByBlockNumberRange(startBlockNumber, endBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	addressDailyStats, activePublicKeys := computeAddressDailyStats(transactions)
+
+	//////////////////////
+	// Load to postgres //
+	//////////////////////
+	err = crud.GetAddressDailyStatModel().UpsertManyWithCheckpoint(
+		startBlockNumber,
+		addressDailyStats,
+		activePublicKeys,
+		&models.BuilderCheckpoint{
+			Builder:          addressDailyStatBuilderName,
+			Shard:            0,
+			ShardCount:       1,
+			BlockNumber:      endBlockNumber,
+			UpdatedTimestamp: uint64(time.Now().UnixNano() / 1000),
+		},
+		checkpoint,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	metrics.AddressDailyStatBuilderBlockNumberGauge.Set(float64(endBlockNumber))
+	zap.S().Debug(
+		"Builder=AddressDailyStatBuilder,",
+		"StartBlockNumber=", startBlockNumber,
+		",EndBlockNumber=", endBlockNumber,
+		",Days=", len(addressDailyStats),
+		" - Built blocks",
+	)
+
+	return endBlockNumber - startBlockNumber + 1, nil
+}
+
+// computeAddressDailyStats - block range, transaction count and volume by UTC day of the block timestamps
+// Returns the days in order and the public keys active in each day
+func computeAddressDailyStats(transactions *[]models.Transaction) ([]*models.AddressDailyStat, map[string][]string) {
+	addressDailyStats := map[string]*models.AddressDailyStat{}
+	volumes := map[string]*big.Int{}
+	activePublicKeys := map[string][]string{}
+	isActivePublicKeys := map[string]map[string]bool{}
+
+	for i := range *transactions {
+		transaction := &(*transactions)[i]
+
+		// Microseconds
+		day := time.Unix(0, int64(transaction.BlockTimestamp)*1000).UTC().Format("2006-01-02")
+
+		addressDailyStat, ok := addressDailyStats[day]
+		if ok == false {
+			addressDailyStat = &models.AddressDailyStat{
+				Day:              day,
+				StartBlockNumber: transaction.BlockNumber,
+			}
+			addressDailyStats[day] = addressDailyStat
+			volumes[day] = new(big.Int)
+			isActivePublicKeys[day] = map[string]bool{}
+		}
+		addressDailyStat.EndBlockNumber = transaction.BlockNumber
+
+		if transaction.LogIndex == -1 {
+			// Base transaction
+			addressDailyStat.TransactionCount++
+		}
+		volumes[day].Add(volumes[day], utils.StringHexToBigInt(transaction.Value))
+
+		for _, publicKey := range []string{transaction.FromAddress, transaction.ToAddress} {
+			if publicKey == "" || isActivePublicKeys[day][publicKey] == true {
+				continue
+			}
+			isActivePublicKeys[day][publicKey] = true
+			activePublicKeys[day] = append(activePublicKeys[day], publicKey)
+		}
+	}
+
+	days := []string{}
+	for day := range addressDailyStats {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+
+	updatedTimestamp := uint64(time.Now().UnixNano() / 1000)
+	sortedAddressDailyStats := make([]*models.AddressDailyStat, len(days))
+	for i, day := range days {
+		addressDailyStat := addressDailyStats[day]
+		addressDailyStat.VolumeLoop = volumes[day].String()
+		addressDailyStat.UpdatedTimestamp = updatedTimestamp
+
+		sortedAddressDailyStats[i] = addressDailyStat
+	}
+
+	return sortedAddressDailyStats, activePublicKeys
+}
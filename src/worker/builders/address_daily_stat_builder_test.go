@@ -0,0 +1,40 @@
+package builders
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestAddressDailyStatBuilderComputeAddressDailyStats(t *testing.T) {
+	assert := assert.New(t)
+
+	// 2021-07-31T23:59:59Z and 2021-08-01T00:00:00Z in microseconds
+	transactions := &[]models.Transaction{
+		{BlockNumber: 10, TransactionIndex: 0, LogIndex: -1, FromAddress: "hx1", ToAddress: "hx2", Value: "0x64", BlockTimestamp: 1627775999000000},
+		{BlockNumber: 10, TransactionIndex: 0, LogIndex: 0, FromAddress: "cx1", ToAddress: "hx1", Value: "0xa", BlockTimestamp: 1627775999000000},
+		{BlockNumber: 11, TransactionIndex: 0, LogIndex: -1, FromAddress: "hx2", ToAddress: "", Value: "0x0", BlockTimestamp: 1627776000000000},
+		{BlockNumber: 12, TransactionIndex: 0, LogIndex: -1, FromAddress: "hx2", ToAddress: "hx3", Value: "0x1", BlockTimestamp: 1627776002000000},
+	}
+
+	addressDailyStats, activePublicKeys := computeAddressDailyStats(transactions)
+	assert.Equal(2, len(addressDailyStats))
+
+	// Internal transactions add volume, not transactions
+	assert.Equal("2021-07-31", addressDailyStats[0].Day)
+	assert.Equal(uint64(10), addressDailyStats[0].StartBlockNumber)
+	assert.Equal(uint64(10), addressDailyStats[0].EndBlockNumber)
+	assert.Equal(uint64(1), addressDailyStats[0].TransactionCount)
+	assert.Equal("110", addressDailyStats[0].VolumeLoop)
+	assert.Equal([]string{"hx1", "hx2", "cx1"}, activePublicKeys["2021-07-31"])
+
+	// Active public keys are distinct
+	assert.Equal("2021-08-01", addressDailyStats[1].Day)
+	assert.Equal(uint64(11), addressDailyStats[1].StartBlockNumber)
+	assert.Equal(uint64(12), addressDailyStats[1].EndBlockNumber)
+	assert.Equal(uint64(2), addressDailyStats[1].TransactionCount)
+	assert.Equal("1", addressDailyStats[1].VolumeLoop)
+	assert.Equal([]string{"hx2", "hx3"}, activePublicKeys["2021-08-01"])
+}
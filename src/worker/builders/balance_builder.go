@@ -1,260 +1,596 @@
 package builders
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"math/big"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/geometry-labs/icon-addresses/config"
 	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/metrics"
 	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/worker/utils"
 )
 
-// Table builder for balances
-func StartBalanceBuilder() {
-
-	// Tail
-	go startBalanceBuilder(0)
-
-	// Head
-	headBlockNumber, err := crud.GetBalanceModel().SelectLatestBlockNumber()
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		// No entries yet
-		// No need for head builder
-		return
-	} else if err != nil {
-		// Postgres error
-		zap.S().Fatal(err.Error())
-	} else if headBlockNumber <= 10000 {
-		return
+const balanceBuilderName = crud.BalanceBuilderName
+
+// Hardcode genesis transaction in block#0
+// hx54f7853dc6481b670caf69c5a27c7c8fe5be8269
+func newBalanceBuilderGenesis() *models.Balance {
+	return &models.Balance{
+		BlockNumber:      0,
+		TransactionIndex: 0,
+		LogIndex:         -1,
+		PublicKey:        "hx54f7853dc6481b670caf69c5a27c7c8fe5be8269",
+		// Value:            "0x2961FFF8CA4A62327800000",
+		// ValueDecimal:     800460000,
+		Value:        "0x0",
+		ValueDecimal: 0,
+		ValueLoop:    "0",
+		Timestamp:    0,
 	}
-
-	go startBalanceBuilder(headBlockNumber)
 }
 
-func startBalanceBuilder(startBlockNumber uint64) {
+// Rollbacks take the write lock, builders take the read lock while building blocks
+var balanceBuilderLock sync.RWMutex
+
+// RewindBalanceBuilders - run a rollback while the balance builders are paused
+// NOTE the rollback is expected to rewind the builder checkpoints, see crud.RollbackBlocks
+func RewindBalanceBuilders(blockNumber uint64, rollback func() error) error {
+	balanceBuilderLock.Lock()
+	defer balanceBuilderLock.Unlock()
 
 	zap.S().Info(
 		"Builder=BalanceBuilder,",
-		"BlockNumber=", startBlockNumber,
-		" - Starting builder",
+		"RewindBlockNumber=", blockNumber,
+		" - Rewinding builders",
 	)
 
-	currentBlockNumber := startBlockNumber
-
-	// Hardcode genesis transaction in block#0
-	// hx54f7853dc6481b670caf69c5a27c7c8fe5be8269
-	if currentBlockNumber == 0 {
-
-		// Load to database
-		crud.GetBalanceModel().LoaderChannel <- &models.Balance{
-			BlockNumber:      0,
-			TransactionIndex: 0,
-			LogIndex:         -1,
-			PublicKey:        "hx54f7853dc6481b670caf69c5a27c7c8fe5be8269",
-			// Value:            "0x2961FFF8CA4A62327800000",
-			// ValueDecimal:     800460000,
-			Value:        "0x0",
-			ValueDecimal: 0,
-			Timestamp:    0,
+	return rollback()
+}
+
+// balanceBuilder - builds balances for the addresses of one shard
+type balanceBuilder struct {
+	shard      uint32
+	shardCount uint32
+
+	// Latest balance by public key
+	// NOTE only reset between batches, balances in a batch are not written yet
+	balances map[string]*big.Int
+}
+
+// GetBalanceBuilderCompletedBlockNumber - last block the balances ledger is complete for
+// Returns gorm.ErrRecordNotFound if a shard has not completed a block yet
+func GetBalanceBuilderCompletedBlockNumber() (uint64, error) {
+	return crud.GetBuilderCheckpointModel().SelectCompletedBlockNumber(balanceBuilderName, getBalanceBuilderShardCount())
+}
+
+func getBalanceBuilderShardCount() uint32 {
+	if config.Config.BalanceBuilderShards > 1 {
+		return uint32(config.Config.BalanceBuilderShards)
+	}
+
+	return 1
+}
+
+// Table builder for balances
+// Addresses are split across shards by hash, each shard builds every block for its addresses
+func StartBalanceBuilder() {
+	shardCount := getBalanceBuilderShardCount()
+
+	for shard := uint32(0); shard < shardCount; shard++ {
+		builder := &balanceBuilder{
+			shard:      shard,
+			shardCount: shardCount,
+			balances:   map[string]*big.Int{},
 		}
 
-		currentBlockNumber++
+		go builder.start()
 	}
+}
+
+func (b *balanceBuilder) start() {
+
+	isStarting := true
+	nextBlockNumber := uint64(0)
 
 	for {
+		// Rollbacks wait for the blocks being built
+		balanceBuilderLock.RLock()
 
-		//////////////////////////
-		// Check database state //
-		//////////////////////////
+		// NOTE the checkpoint is read every batch, it may be rewound by a rollback or an admin
+		checkpoint := b.selectCheckpoint()
+		startBlockNumber := b.getStartBlockNumber(checkpoint)
+
+		if isStarting == true {
+			isStarting = false
 
-		// check for block
-		currentBlock, err := crud.GetBlockModel().SelectOne(uint32(currentBlockNumber))
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Block does not exist yet
-			// Sleep and try again
 			zap.S().Info(
 				"Builder=BalanceBuilder,",
-				"BlockNumber=", currentBlockNumber,
-				" - Block not seen yet. Sleeping 3 seconds...",
+				"Shard=", b.shard,
+				",ShardCount=", b.shardCount,
+				",BlockNumber=", startBlockNumber,
+				" - Starting builder",
 			)
 
-			time.Sleep(3 * time.Second)
-			continue
-		} else if err != nil {
-			// Postgres error
-			zap.S().Fatal(err.Error())
-		}
+			if b.verifyCheckpoint(checkpoint) == false {
+				// Rebuild the last batch
+				// NOTE balances of transactions no longer in the batch are not overwritten, delete them
+				publicKeys, err := crud.GetBalanceModel().SelectPublicKeysFromBlockNumber(checkpoint.ChecksumStartBlockNumber)
+				if err != nil {
+					// Postgres error
+					zap.S().Fatal(err.Error())
+				}
 
-		// Check for block transactions
-		currentBlockTransactionCount, err := crud.GetTransactionCountByBlockNumberModel().SelectLargestCountByBlockNumber(currentBlockNumber)
-		if err != nil {
-			// Postgres error
-			zap.S().Fatal(err.Error())
-		} else if currentBlockTransactionCount != uint64(currentBlock.TransactionCount) {
+				err = crud.GetBuilderCheckpointModel().RewindOne(
+					balanceBuilderName,
+					b.shard,
+					b.shardCount,
+					checkpoint.ChecksumStartBlockNumber,
+					b.getShardPublicKeys(publicKeys),
+				)
+				if err != nil {
+					// Postgres error
+					zap.S().Fatal(err.Error())
+				}
+
+				balanceBuilderLock.RUnlock()
+				continue
+			}
+		} else if startBlockNumber != nextBlockNumber {
 			zap.S().Info(
 				"Builder=BalanceBuilder,",
-				"BlockNumber=", currentBlockNumber,
-				"BlockTransactionCount=", currentBlockTransactionCount,
-				"BlockTransactions=", currentBlock.TransactionCount,
-				" - Transactions not yet seen. Sleeping 3 seconds...",
+				"Shard=", b.shard,
+				",BlockNumber=", nextBlockNumber,
+				",CheckpointBlockNumber=", startBlockNumber,
+				" - Checkpoint moved, rewinding builder",
 			)
 
-			time.Sleep(3 * time.Second)
-			continue
+			// Cached balances may be rolled back
+			b.balances = map[string]*big.Int{}
 		}
 
-		// Check for block logs
-		currentBlockLogCount, err := crud.GetLogCountByBlockNumberModel().SelectLargestCountByBlockNumber(currentBlockNumber)
-		if err != nil {
+		numBlocks, err := b.buildBalanceBlocks(startBlockNumber, checkpoint)
+		balanceBuilderLock.RUnlock()
+
+		if errors.Is(err, crud.ErrBuilderCheckpointMoved) {
+			// Rewound while building
+			// Read the checkpoint again
+			nextBlockNumber = startBlockNumber
+			b.balances = map[string]*big.Int{}
+			continue
+		} else if err != nil {
 			// Postgres error
-			zap.S().Fatal(err.Error())
-		} else if currentBlockLogCount != uint64(currentBlock.LogCount) {
-			zap.S().Info(
+			zap.S().Fatal(
 				"Builder=BalanceBuilder,",
-				"BlockNumber=", currentBlockNumber,
-				"BlockLogCount=", currentBlockLogCount,
-				"BlockLogs=", currentBlock.LogCount,
-				" - Logs not yet seen. Sleeping 3 seconds...",
+				"Shard=", b.shard,
+				",BlockNumber=", startBlockNumber,
+				" - Error: ", err.Error(),
 			)
+		}
 
+		if numBlocks == 0 {
+			// Block not ready
+			// Sleep and try again
+			nextBlockNumber = startBlockNumber
 			time.Sleep(3 * time.Second)
 			continue
 		}
 
-		//////////////////////
-		// Compute Balances //
-		//////////////////////
-		currentBlockTransactions, err := crud.GetTransactionModel().SelectManyByBlockNumber(currentBlockNumber)
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Transactions do not exist yet
-			// Sleep and try again
-			zap.S().Warn(
-				"Builder=BalanceBuilder,",
-				"BlockNumber=", currentBlockNumber,
-				" - Transactions not seen in table. CHECK DATABASE. Sleeping 3 seconds...",
-			)
+		///////////////
+		// Increment //
+		///////////////
+		nextBlockNumber = startBlockNumber + numBlocks
 
-			time.Sleep(3 * time.Second)
-			continue
-		} else if err != nil {
-			// Postgres error
-			zap.S().Fatal(err.Error())
+		metrics.BalanceBuilderBlockNumberGauge.WithLabelValues(strconv.Itoa(int(b.shard))).Set(float64(nextBlockNumber - 1))
+		zap.S().Debug(
+			"Builder=BalanceBuilder,",
+			"Shard=", b.shard,
+			",BlockNumber=", nextBlockNumber,
+			" - Reading next block...",
+		)
+	}
+}
+
+// selectCheckpoint - checkpoint of the shard, nil if there is none
+func (b *balanceBuilder) selectCheckpoint() *models.BuilderCheckpoint {
+	checkpoint, err := crud.GetBuilderCheckpointModel().SelectOne(balanceBuilderName, b.shard, b.shardCount)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	} else if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	}
+
+	return checkpoint
+}
+
+// getStartBlockNumber - first block not completed by the shard
+func (b *balanceBuilder) getStartBlockNumber(checkpoint *models.BuilderCheckpoint) uint64 {
+	if checkpoint != nil {
+		return checkpoint.BlockNumber + 1
+	}
+
+	// Shard count changed
+	blockNumber, err := crud.GetBuilderCheckpointModel().SelectResumeBlockNumber(balanceBuilderName, b.shardCount)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// No checkpoints yet
+		return 0
+	} else if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	}
+
+	return blockNumber + 1
+}
+
+// verifyCheckpoint - check the balances of the last batch match the checkpoint checksum
+// Returns true if the checkpoint has no checksum
+func (b *balanceBuilder) verifyCheckpoint(checkpoint *models.BuilderCheckpoint) bool {
+	if checkpoint == nil || checkpoint.Checksum == "" {
+		return true
+	}
+
+	balances, err := crud.GetBalanceModel().SelectManyByBlockNumberRange(
+		checkpoint.ChecksumStartBlockNumber,
+		checkpoint.BlockNumber,
+	)
+	if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	}
+
+	shardBalances := []*models.Balance{}
+	for i := range *balances {
+		balance := &(*balances)[i]
+
+		if b.isShardPublicKey(balance.PublicKey) == true {
+			shardBalances = append(shardBalances, balance)
 		}
+	}
 
-		// NOTE the transactions should already be sorted by transaction_index, log_index in crud
-		for _, transaction := range *currentBlockTransactions {
-			publicKeys := []string{transaction.FromAddress, transaction.ToAddress}
+	checksum := getBalanceChecksum(shardBalances)
+	if checksum != checkpoint.Checksum {
+		zap.S().Warn(
+			"Builder=BalanceBuilder,",
+			"Shard=", b.shard,
+			",BlockNumber=", checkpoint.BlockNumber,
+			",Checksum=", checkpoint.Checksum,
+			",BalancesChecksum=", checksum,
+			" - Checksum mismatch, rebuilding from block ", checkpoint.ChecksumStartBlockNumber,
+		)
 
-			for _, key := range publicKeys {
+		return false
+	}
 
-				curValue := "0x0"
-				newValue := transaction.Value
-				newFee := transaction.TransactionFee
+	return true
+}
 
-				// Get current balance of public key
-				curBalance, err := crud.GetBalanceModel().SelectOneByBlockNumber(
-					key,
-					transaction.BlockNumber-1,
-				)
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					curValue = "0x0"
-				} else if err != nil {
-					// Postgres error
-					zap.S().Fatal(err.Error())
-				} else {
-					curValue = curBalance.Value
-				}
+// getBalanceChecksum - hash of balance rows, independent of their order
+func getBalanceChecksum(balances []*models.Balance) string {
+	lines := make([]string, len(balances))
+	for i, balance := range balances {
+		lines[i] = fmt.Sprintf(
+			"%d:%d:%d:%s:%s",
+			balance.BlockNumber,
+			balance.TransactionIndex,
+			balance.LogIndex,
+			balance.PublicKey,
+			balance.ValueLoop,
+		)
+	}
+	sort.Strings(lines)
 
-				// Hex -> big.Int
-				curValueBigInt, _ := new(big.Int).SetString(curValue[2:], 16)
-				newValueBigInt, _ := new(big.Int).SetString(newValue[2:], 16)
-				newFeeBigInt, _ := new(big.Int).SetString(newFee[2:], 16)
+	hash := sha256.New()
+	for _, line := range lines {
+		hash.Write([]byte(line + "\n"))
+	}
 
-				if key == transaction.ToAddress {
-					// Add value
-					newValueBigInt = newValueBigInt.Add(curValueBigInt, newValueBigInt)
-				} else if key == transaction.FromAddress {
-					// Subtract value
-					newValueBigInt = newValueBigInt.Sub(curValueBigInt, newValueBigInt)
+	return hex.EncodeToString(hash.Sum(nil))
+}
 
-					// Subtact Fee
-					newValueBigInt = newValueBigInt.Sub(newValueBigInt, newFeeBigInt)
-				}
+// getShardPublicKeys - public keys that belong to the shard
+func (b *balanceBuilder) getShardPublicKeys(publicKeys []string) []string {
+	shardPublicKeys := []string{}
+	for _, publicKey := range publicKeys {
+		if b.isShardPublicKey(publicKey) == true {
+			shardPublicKeys = append(shardPublicKeys, publicKey)
+		}
+	}
 
-				newValue = fmt.Sprintf("0x%x", newValueBigInt)
+	return shardPublicKeys
+}
 
-				// Value -> ValueDecimal
-				newValueDecimal := float64(0)
+// isShardPublicKey - check if a public key belongs to the shard
+func (b *balanceBuilder) isShardPublicKey(publicKey string) bool {
+	if publicKey == "" {
+		return false
+	}
 
-				baseBigFloat, _ := new(big.Float).SetString("1000000000000000000") // 10^18
-				newValueBigFloat := new(big.Float).SetInt(newValueBigInt)
+	return getBalanceBuilderShard(publicKey, b.shardCount) == b.shard
+}
 
-				// newValue / 10^18
-				newValueBigFloat = newValueBigFloat.Quo(newValueBigFloat, baseBigFloat)
+func getBalanceBuilderShard(publicKey string, shardCount uint32) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(publicKey))
 
-				newValueDecimal, _ = newValueBigFloat.Float64()
+	return hash.Sum32() % shardCount
+}
 
-				// Load to database
-				crud.GetBalanceModel().LoaderChannel <- &models.Balance{
-					BlockNumber:      transaction.BlockNumber,
-					TransactionIndex: transaction.TransactionIndex,
-					LogIndex:         transaction.LogIndex,
-					PublicKey:        key,
-					Value:            newValue,
-					ValueDecimal:     newValueDecimal,
-					Timestamp:        transaction.BlockTimestamp,
-				}
+// buildBalanceBlocks - compute and write balances for ready blocks starting at a block number
+// Returns the number of blocks built, 0 if the first block is not ready
+func (b *balanceBuilder) buildBalanceBlocks(startBlockNumber uint64, checkpoint *models.BuilderCheckpoint) (uint64, error) {
 
-				// Wait until state is set
-				for {
-					latestBalance, err := crud.GetBalanceModel().SelectOneByBlockNumberTransactionIndexLogIndex(
-						key,
-						transaction.BlockNumber,
-						transaction.TransactionIndex,
-						transaction.LogIndex,
-					)
-					if errors.Is(err, gorm.ErrRecordNotFound) {
-						// No record yet
-						zap.S().Info(
-							"Builder=BalanceBuilder,",
-							"BlockNumber=", currentBlockNumber,
-							" - Balance not set yet...No record",
-						)
-						time.Sleep(1 * time.Millisecond)
-						continue
-					} else if err != nil {
-						// Postgres error
-						zap.S().Fatal(err.Error())
-					} else if latestBalance.Value != newValue {
-						zap.S().Info(
-							"Builder=BalanceBuilder,",
-							"BlockNumber=", currentBlockNumber,
-							",PublicKey=", key,
-							",curValue=", latestBalance.Value,
-							",newValue=", newValue,
-							" - Balance not set yet...",
-						)
-						time.Sleep(1 * time.Millisecond)
-						continue
-					}
-					// Successful
-					break
-				}
+	// Cap memory between batches
+	if len(b.balances) > config.Config.BalanceBuilderCacheSize {
+		b.balances = map[string]*big.Int{}
+	}
+
+	balances := []*models.Balance{}
+
+	numBlocks := uint64(0)
+	for numBlocks < uint64(config.Config.BalanceBuilderBatchBlocks) {
+		currentBlockNumber := startBlockNumber + numBlocks
+
+		if currentBlockNumber == 0 {
+			genesisBalance := newBalanceBuilderGenesis()
+			if b.isShardPublicKey(genesisBalance.PublicKey) == true {
+				balances = append(balances, genesisBalance)
+				b.balances[genesisBalance.PublicKey] = new(big.Int)
 			}
+
+			numBlocks++
+			continue
 		}
 
-		///////////////
-		// Increment //
-		///////////////
+		currentBlockTransactions, isReady := selectBalanceBlockTransactions(currentBlockNumber)
+		if isReady == false {
+			break
+		}
+
+		balances = append(balances, b.computeBalances(currentBlockNumber, currentBlockTransactions)...)
+		numBlocks++
+	}
+
+	if numBlocks == 0 {
+		return 0, nil
+	}
+
+	//////////////////////
+	// Load to postgres //
+	//////////////////////
+	err := crud.GetBalanceModel().UpsertManyWithCheckpoint(balances, &models.BuilderCheckpoint{
+		Builder:                  balanceBuilderName,
+		Shard:                    b.shard,
+		ShardCount:               b.shardCount,
+		BlockNumber:              startBlockNumber + numBlocks - 1,
+		UpdatedTimestamp:         uint64(time.Now().UnixNano() / 1000),
+		Checksum:                 getBalanceChecksum(balances),
+		ChecksumStartBlockNumber: startBlockNumber,
+	}, checkpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	return numBlocks, nil
+}
+
+// selectBalanceBlockTransactions - select the transactions of a block once all are loaded
+// Returns false if the block is not ready to be built
+func selectBalanceBlockTransactions(currentBlockNumber uint64) (*[]models.Transaction, bool) {
+
+	//////////////////////////
+	// Check database state //
+	//////////////////////////
+
+	// check for block
+	currentBlock, err := crud.GetBlockModel().SelectOne(uint32(currentBlockNumber))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Block does not exist yet
 		zap.S().Debug(
 			"Builder=BalanceBuilder,",
 			"BlockNumber=", currentBlockNumber,
-			" - Reading next block...",
+			" - Block not seen yet",
+		)
+
+		return nil, false
+	} else if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	}
+
+	// Check for block transactions
+	currentBlockTransactionCount, err := crud.GetTransactionCountByBlockNumberModel().SelectLargestCountByBlockNumber(currentBlockNumber, currentBlock.Hash)
+	if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	} else if currentBlockTransactionCount != uint64(currentBlock.TransactionCount) {
+		zap.S().Debug(
+			"Builder=BalanceBuilder,",
+			"BlockNumber=", currentBlockNumber,
+			"BlockTransactionCount=", currentBlockTransactionCount,
+			"BlockTransactions=", currentBlock.TransactionCount,
+			" - Transactions not yet seen",
+		)
+
+		return nil, false
+	}
+
+	// Check for block logs
+	currentBlockLogCount, err := crud.GetLogCountByBlockNumberModel().SelectLargestCountByBlockNumber(currentBlockNumber, currentBlock.Hash)
+	if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	} else if currentBlockLogCount != uint64(currentBlock.LogCount) {
+		zap.S().Debug(
+			"Builder=BalanceBuilder,",
+			"BlockNumber=", currentBlockNumber,
+			"BlockLogCount=", currentBlockLogCount,
+			"BlockLogs=", currentBlock.LogCount,
+			" - Logs not yet seen",
+		)
+
+		return nil, false
+	}
+
+	currentBlockTransactions, err := crud.GetTransactionModel().SelectManyByBlockNumber(currentBlockNumber, currentBlock.Hash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Transactions do not exist yet
+		zap.S().Warn(
+			"Builder=BalanceBuilder,",
+			"BlockNumber=", currentBlockNumber,
+			" - Transactions not seen in table. CHECK DATABASE",
 		)
-		currentBlockNumber++
+
+		return nil, false
+	} else if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	}
+
+	currentBlockIScoreClaims, err := crud.GetIScoreClaimModel().SelectManyByBlockNumber(currentBlockNumber, currentBlock.Hash)
+	if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	}
+
+	return mergeIScoreClaimTransactions(currentBlockTransactions, currentBlockIScoreClaims), true
+}
+
+// mergeIScoreClaimTransactions - add I-Score claims to the transactions of a block as transfers without a sender
+// NOTE claimed ICX is minted, only the claimer balance changes
+func mergeIScoreClaimTransactions(transactions *[]models.Transaction, iScoreClaims *[]models.IScoreClaim) *[]models.Transaction {
+	if len(*iScoreClaims) == 0 {
+		return transactions
 	}
+
+	mergedTransactions := append([]models.Transaction{}, *transactions...)
+	for i := range *iScoreClaims {
+		iScoreClaim := &(*iScoreClaims)[i]
+
+		mergedTransactions = append(mergedTransactions, models.Transaction{
+			FromAddress:      "",
+			ToAddress:        iScoreClaim.PublicKey,
+			Value:            iScoreClaim.Value,
+			Hash:             iScoreClaim.TransactionHash,
+			BlockNumber:      iScoreClaim.BlockNumber,
+			TransactionIndex: iScoreClaim.TransactionIndex,
+			BlockTimestamp:   iScoreClaim.BlockTimestamp,
+			TransactionFee:   "0x0", // Fee is paid by the claim transaction
+			LogIndex:         iScoreClaim.LogIndex,
+			BlockHash:        iScoreClaim.BlockHash,
+		})
+	}
+
+	// Sorted by transaction_index, log_index like in crud
+	sort.SliceStable(mergedTransactions, func(i, j int) bool {
+		if mergedTransactions[i].TransactionIndex != mergedTransactions[j].TransactionIndex {
+			return mergedTransactions[i].TransactionIndex < mergedTransactions[j].TransactionIndex
+		}
+		return mergedTransactions[i].LogIndex < mergedTransactions[j].LogIndex
+	})
+
+	return &mergedTransactions
+}
+
+// computeBalances - balances of shard addresses after each transaction in a block
+func (b *balanceBuilder) computeBalances(currentBlockNumber uint64, currentBlockTransactions *[]models.Transaction) []*models.Balance {
+
+	/////////////////////////
+	// Load prior balances //
+	/////////////////////////
+	missingPublicKeys := []string{}
+	for _, transaction := range *currentBlockTransactions {
+		for _, publicKey := range []string{transaction.FromAddress, transaction.ToAddress} {
+			if b.isShardPublicKey(publicKey) == false {
+				continue
+			}
+			if _, ok := b.balances[publicKey]; ok == true {
+				continue
+			}
+
+			b.balances[publicKey] = new(big.Int)
+			missingPublicKeys = append(missingPublicKeys, publicKey)
+		}
+	}
+
+	if len(missingPublicKeys) > 0 {
+		priorBalances, err := crud.GetBalanceModel().SelectManyLatestByPublicKeys(missingPublicKeys, currentBlockNumber)
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+
+		for _, priorBalance := range *priorBalances {
+			b.balances[priorBalance.PublicKey] = utils.StringHexToBigInt(priorBalance.Value)
+		}
+	}
+
+	//////////////////////
+	// Compute Balances //
+	//////////////////////
+	balances := []*models.Balance{}
+
+	// NOTE the transactions should already be sorted by transaction_index, log_index in crud
+	for _, transaction := range *currentBlockTransactions {
+		valueBigInt := utils.StringHexToBigInt(transaction.Value)
+		feeBigInt := utils.StringHexToBigInt(transaction.TransactionFee)
+
+		// Sender pays value and fee, receiver gets value
+		publicKeys := []string{transaction.FromAddress, transaction.ToAddress}
+		deltas := map[string]*big.Int{
+			transaction.FromAddress: new(big.Int).Neg(new(big.Int).Add(valueBigInt, feeBigInt)),
+			transaction.ToAddress:   valueBigInt,
+		}
+		if transaction.FromAddress == transaction.ToAddress {
+			// Self transfer only pays the fee
+			publicKeys = publicKeys[:1]
+			deltas[transaction.FromAddress] = new(big.Int).Neg(feeBigInt)
+		}
+
+		for _, publicKey := range publicKeys {
+			if b.isShardPublicKey(publicKey) == false {
+				continue
+			}
+
+			newValueBigInt := new(big.Int).Add(b.balances[publicKey], deltas[publicKey])
+			b.balances[publicKey] = newValueBigInt
+
+			balances = append(balances, &models.Balance{
+				BlockNumber:      transaction.BlockNumber,
+				TransactionIndex: transaction.TransactionIndex,
+				LogIndex:         transaction.LogIndex,
+				PublicKey:        publicKey,
+				Value:            fmt.Sprintf("0x%x", newValueBigInt),
+				ValueDecimal:     balanceValueDecimal(newValueBigInt),
+				ValueLoop:        newValueBigInt.String(),
+				Timestamp:        transaction.BlockTimestamp,
+			})
+		}
+	}
+
+	return balances
+}
+
+// balanceValueDecimal - loop value in ICX
+func balanceValueDecimal(valueBigInt *big.Int) float64 {
+	baseBigFloat, _ := new(big.Float).SetString("1000000000000000000") // 10^18
+	valueBigFloat := new(big.Float).SetInt(valueBigInt)
+
+	// value / 10^18
+	valueBigFloat = valueBigFloat.Quo(valueBigFloat, baseBigFloat)
+
+	valueDecimal, _ := valueBigFloat.Float64()
+
+	return valueDecimal
 }
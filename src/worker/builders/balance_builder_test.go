@@ -0,0 +1,128 @@
+package builders
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestBalanceBuilderComputeBalances(t *testing.T) {
+	assert := assert.New(t)
+
+	builder := &balanceBuilder{
+		shard:      0,
+		shardCount: 1,
+		balances: map[string]*big.Int{
+			"hx1": big.NewInt(1000),
+			"hx2": big.NewInt(0),
+		},
+	}
+
+	transactions := &[]models.Transaction{
+		{BlockNumber: 10, TransactionIndex: 0, LogIndex: -1, FromAddress: "hx1", ToAddress: "hx2", Value: "0x64", TransactionFee: "0xa"},
+		{BlockNumber: 10, TransactionIndex: 1, LogIndex: -1, FromAddress: "hx1", ToAddress: "hx1", Value: "0x64", TransactionFee: "0xa"},
+		{BlockNumber: 10, TransactionIndex: 2, LogIndex: -1, FromAddress: "hx2", ToAddress: "", Value: "0x0", TransactionFee: "0x1"},
+	}
+
+	balances := builder.computeBalances(10, transactions)
+	assert.Equal(4, len(balances))
+
+	// Sender pays value and fee
+	assert.Equal("hx1", balances[0].PublicKey)
+	assert.Equal("890", balances[0].ValueLoop)
+	assert.Equal("0x37a", balances[0].Value)
+
+	// Receiver gets value
+	assert.Equal("hx2", balances[1].PublicKey)
+	assert.Equal("100", balances[1].ValueLoop)
+
+	// Self transfer only pays the fee
+	assert.Equal("hx1", balances[2].PublicKey)
+	assert.Equal(uint32(1), balances[2].TransactionIndex)
+	assert.Equal("880", balances[2].ValueLoop)
+
+	// Balances carry over within a block
+	assert.Equal("hx2", balances[3].PublicKey)
+	assert.Equal("99", balances[3].ValueLoop)
+}
+
+func TestBalanceBuilderMergeIScoreClaims(t *testing.T) {
+	assert := assert.New(t)
+
+	builder := &balanceBuilder{
+		shard:      0,
+		shardCount: 1,
+		balances: map[string]*big.Int{
+			"hx1": big.NewInt(1000),
+			"hx2": big.NewInt(0),
+			"cx1": big.NewInt(0),
+		},
+	}
+
+	transactions := &[]models.Transaction{
+		{BlockNumber: 10, TransactionIndex: 0, LogIndex: -1, FromAddress: "hx1", ToAddress: "cx1", Value: "0x0", TransactionFee: "0xa"},
+		{BlockNumber: 10, TransactionIndex: 1, LogIndex: -1, FromAddress: "hx1", ToAddress: "hx2", Value: "0x64", TransactionFee: "0xa"},
+	}
+	iScoreClaims := &[]models.IScoreClaim{
+		{BlockNumber: 10, TransactionIndex: 0, LogIndex: 0, PublicKey: "hx1", Value: "0x32"},
+	}
+
+	// Claims are ordered after the logs before them
+	mergedTransactions := mergeIScoreClaimTransactions(transactions, iScoreClaims)
+	assert.Equal(3, len(*mergedTransactions))
+	assert.Equal(2, len(*transactions))
+	assert.Equal(int32(0), (*mergedTransactions)[1].LogIndex)
+	assert.Equal("", (*mergedTransactions)[1].FromAddress)
+
+	// Claimed ICX is minted to the claimer
+	balances := builder.computeBalances(10, mergedTransactions)
+	assert.Equal(5, len(balances))
+	assert.Equal("hx1", balances[2].PublicKey)
+	assert.Equal(int32(0), balances[2].LogIndex)
+	assert.Equal("1040", balances[2].ValueLoop)
+	assert.Equal("hx1", balances[3].PublicKey)
+	assert.Equal("930", balances[3].ValueLoop)
+
+	// No claims
+	assert.Equal(transactions, mergeIScoreClaimTransactions(transactions, &[]models.IScoreClaim{}))
+}
+
+func TestBalanceBuilderShard(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey := "hx54f7853dc6481b670caf69c5a27c7c8fe5be8269"
+
+	shard := getBalanceBuilderShard(publicKey, 4)
+	assert.True(shard < 4)
+	assert.Equal(shard, getBalanceBuilderShard(publicKey, 4))
+	assert.Equal(uint32(0), getBalanceBuilderShard(publicKey, 1))
+
+	builder := &balanceBuilder{shard: shard, shardCount: 4}
+	assert.True(builder.isShardPublicKey(publicKey))
+	assert.False(builder.isShardPublicKey(""))
+
+	// Rewound rows of other shards are kept
+	otherShard := &balanceBuilder{shard: (shard + 1) % 4, shardCount: 4}
+	assert.Equal([]string{publicKey}, builder.getShardPublicKeys([]string{publicKey, ""}))
+	assert.Equal([]string{}, otherShard.getShardPublicKeys([]string{publicKey, ""}))
+}
+
+func TestBalanceBuilderChecksum(t *testing.T) {
+	assert := assert.New(t)
+
+	balances := []*models.Balance{
+		{BlockNumber: 10, TransactionIndex: 0, LogIndex: -1, PublicKey: "hx1", ValueLoop: "890"},
+		{BlockNumber: 10, TransactionIndex: 0, LogIndex: -1, PublicKey: "hx2", ValueLoop: "100"},
+	}
+	reversedBalances := []*models.Balance{balances[1], balances[0]}
+
+	checksum := getBalanceChecksum(balances)
+	assert.Equal(64, len(checksum))
+	assert.Equal(checksum, getBalanceChecksum(reversedBalances))
+
+	balances[1] = &models.Balance{BlockNumber: 10, TransactionIndex: 0, LogIndex: -1, PublicKey: "hx2", ValueLoop: "101"}
+	assert.NotEqual(checksum, getBalanceChecksum(balances))
+}
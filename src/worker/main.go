@@ -25,25 +25,31 @@ func main() {
 	if config.Config.OnlyRunAllRoutines == true {
 		// Start Routines
 		routines.StartBalanceRoutine()
+		routines.StartBalanceRefreshRoutine()
 		routines.StartAddressCountRoutine()
-		routines.StartAddressTypeRoutine()
+		routines.StartAddressLabelsRoutine()
 		routines.StartTransactionCountByPublicKeyRoutine()
+		routines.StartBalanceReconciliationRoutine()
+		routines.StartAddressStatsRoutine()
+		routines.StartAddressActivityRoutine()
 
 		global.WaitShutdownSig()
+		return
 	}
 
 	// Start kafka consumer
 	kafka.StartWorkerConsumers()
 
 	// Start transformers
-	transformers.StartBlocksTransformer()
-	transformers.StartTransactionsTransformer()
-	transformers.StartLogsTransformer()
-	transformers.StartContractsTransformer()
-	transformers.StartGovernancePrepsTransformer()
+	transformers.StartBlocksTransformer(global.ShutdownContext())
+	transformers.StartTransactionsTransformer(global.ShutdownContext())
+	transformers.StartLogsTransformer(global.ShutdownContext())
+	transformers.StartContractsTransformer(global.ShutdownContext())
+	transformers.StartGovernancePrepsTransformer(global.ShutdownContext())
 
 	// Start builders
 	builders.StartBalanceBuilder()
+	builders.StartAddressDailyStatBuilder()
 
 	global.WaitShutdownSig()
 }
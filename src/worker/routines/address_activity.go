@@ -0,0 +1,101 @@
+package routines
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func StartAddressActivityRoutine() {
+
+	// routine every day
+	go addressActivityRoutine(time.Duration(config.Config.AddressActivityIntervalSeconds) * time.Second)
+}
+
+// addressActivityRoutine - backfill the first seen and last active of every address from the transactions and token transfers
+// Slow consistency pass for addresses loaded before activity was tracked
+func addressActivityRoutine(duration time.Duration) {
+
+	// Loop every duration
+	for {
+
+		// Loop through all addresses
+		afterPublicKey := ""
+		limit := 100
+		for {
+			addresses, err := crud.GetAddressModel().SelectManyAfterPublicKey(limit, afterPublicKey)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// Sleep
+				break
+			} else if err != nil {
+				zap.S().Fatal(err.Error())
+			}
+			if len(*addresses) == 0 {
+				// Sleep
+				break
+			}
+
+			publicKeys := []string{}
+			for i := range *addresses {
+				publicKeys = append(publicKeys, (*addresses)[i].PublicKey)
+			}
+
+			activities, err := crud.GetAddressModel().SelectActivitiesByPublicKeys(publicKeys)
+			if err != nil {
+				// Postgres error
+				zap.S().Fatal(err.Error())
+			}
+
+			changedActivities := getChangedAddressActivities(addresses, activities)
+			zap.S().Info("Routine=AddressActivity", " - Updating ", len(changedActivities), " of ", len(*addresses), " addresses...")
+
+			for _, activity := range changedActivities {
+				// NOTE loader keeps the earliest first seen and the latest last active
+				crud.GetAddressModel().LoaderChannel <- activity
+			}
+
+			afterPublicKey = publicKeys[len(publicKeys)-1]
+		}
+
+		zap.S().Info("Routine=AddressActivity - Completed routine, sleeping...")
+		time.Sleep(duration)
+	}
+}
+
+// getChangedAddressActivities - activities that differ from the stored addresses
+func getChangedAddressActivities(addresses *[]models.Address, activities *[]models.Address) []*models.Address {
+	storedAddresses := map[string]*models.Address{}
+	for i := range *addresses {
+		address := &(*addresses)[i]
+
+		storedAddresses[address.PublicKey] = address
+	}
+
+	changedActivities := []*models.Address{}
+	for i := range *activities {
+		activity := &(*activities)[i]
+
+		storedAddress, ok := storedAddresses[activity.PublicKey]
+		if ok == false {
+			continue
+		}
+
+		if storedAddress.FirstSeenTimestamp == activity.FirstSeenTimestamp &&
+			storedAddress.FirstSeenBlockNumber == activity.FirstSeenBlockNumber &&
+			storedAddress.LastActiveTimestamp == activity.LastActiveTimestamp &&
+			storedAddress.LastActiveBlockNumber == activity.LastActiveBlockNumber {
+			// Up to date
+			continue
+		}
+
+		changedActivities = append(changedActivities, activity)
+	}
+
+	return changedActivities
+}
@@ -0,0 +1,29 @@
+package routines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+func TestGetChangedAddressActivities(t *testing.T) {
+	assert := assert.New(t)
+
+	addresses := &[]models.Address{
+		{PublicKey: "hx1", FirstSeenTimestamp: 1000, FirstSeenBlockNumber: 10, LastActiveTimestamp: 2000, LastActiveBlockNumber: 20},
+		{PublicKey: "hx2"},
+		{PublicKey: "hx3"},
+	}
+	activities := &[]models.Address{
+		{PublicKey: "hx1", FirstSeenTimestamp: 1000, FirstSeenBlockNumber: 10, LastActiveTimestamp: 2000, LastActiveBlockNumber: 20},
+		{PublicKey: "hx2", FirstSeenTimestamp: 1000, FirstSeenBlockNumber: 10, LastActiveTimestamp: 1000, LastActiveBlockNumber: 10},
+		{PublicKey: "hx4", FirstSeenTimestamp: 1000, FirstSeenBlockNumber: 10, LastActiveTimestamp: 1000, LastActiveBlockNumber: 10},
+	}
+
+	// Up to date and unknown addresses are left out
+	changedActivities := getChangedAddressActivities(addresses, activities)
+	assert.Equal(1, len(changedActivities))
+	assert.Equal("hx2", changedActivities[0].PublicKey)
+}
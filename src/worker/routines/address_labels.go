@@ -0,0 +1,118 @@
+package routines
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+//go:embed address_labels.yaml
+var defaultAddressLabels []byte
+
+// addressLabelsEntry - labels of an address in a labels file
+type addressLabelsEntry struct {
+	Address string   `yaml:"address"`
+	Labels  []string `yaml:"labels"`
+}
+
+func StartAddressLabelsRoutine() {
+
+	go addressLabelsRoutine()
+}
+
+// addressLabelsRoutine - load the default labels and the ADDRESS_LABELS_FILE labels
+// Labels set through the admin endpoints are kept
+// NOTE this routine only runs once
+func addressLabelsRoutine() {
+
+	// Default
+	err := loadAddressLabels("default", defaultAddressLabels)
+	if err != nil {
+		zap.S().Fatal("Routine=AddressLabels, Source=default - Error: ", err.Error())
+	}
+
+	// File
+	if config.Config.AddressLabelsFile == "" {
+		return
+	}
+
+	data, err := ioutil.ReadFile(config.Config.AddressLabelsFile)
+	if err == nil {
+		err = loadAddressLabels("file", data)
+	}
+	if err != nil {
+		zap.S().Fatal("Routine=AddressLabels, Source=file, File=", config.Config.AddressLabelsFile, " - Error: ", err.Error())
+	}
+}
+
+// loadAddressLabels - replace the labels of a source
+func loadAddressLabels(source string, data []byte) error {
+	addressLabels, err := parseAddressLabels(source, data)
+	if err != nil {
+		return err
+	}
+
+	err = crud.GetAddressLabelModel().ReplaceManyBySource(source, addressLabels)
+	if err != nil {
+		return err
+	}
+
+	zap.S().Info("Routine=AddressLabels, Source=", source, " - Loaded ", len(addressLabels), " labels")
+	return nil
+}
+
+// parseAddressLabels - parse a YAML or JSON labels file
+// [{"address": "hx...", "labels": ["treasury"]}, ...]
+func parseAddressLabels(source string, data []byte) ([]*models.AddressLabel, error) {
+	entries := []*addressLabelsEntry{}
+
+	// NOTE JSON is valid YAML
+	err := yaml.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := uint64(time.Now().UnixNano() / 1000)
+
+	addressLabels := []*models.AddressLabel{}
+	isParsed := map[string]bool{}
+	for i, entry := range entries {
+		if crud.IsAddressPublicKeyValid(entry.Address) == false {
+			return nil, fmt.Errorf("Invalid address in entry %d: %q", i, entry.Address)
+		}
+		if len(entry.Labels) == 0 {
+			return nil, errors.New("No labels for address " + entry.Address)
+		}
+
+		for _, label := range entry.Labels {
+			if crud.IsAddressLabelValid(label) == false {
+				return nil, fmt.Errorf("Invalid label for address %s: %q", entry.Address, label)
+			}
+
+			// Duplicates
+			key := entry.Address + ":" + label
+			if isParsed[key] == true {
+				continue
+			}
+			isParsed[key] = true
+
+			addressLabels = append(addressLabels, &models.AddressLabel{
+				PublicKey: entry.Address,
+				Label:     label,
+				Source:    source,
+				Timestamp: timestamp,
+			})
+		}
+	}
+
+	return addressLabels, nil
+}
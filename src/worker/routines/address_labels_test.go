@@ -0,0 +1,46 @@
+package routines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAddressLabels(t *testing.T) {
+	assert := assert.New(t)
+
+	// Default
+	addressLabels, err := parseAddressLabels("default", defaultAddressLabels)
+	assert.Equal(nil, err)
+	assert.Equal(51, len(addressLabels))
+	assert.Equal("treasury", addressLabels[0].Label)
+	assert.Equal("default", addressLabels[0].Source)
+
+	// YAML
+	addressLabels, err = parseAddressLabels("file", []byte(`
+- address: hx0000000000000000000000000000000000000001
+  labels: [exchange, team, exchange]
+- address: cx0000000000000000000000000000000000000002
+  labels:
+    - bridge
+`))
+	assert.Equal(nil, err)
+	assert.Equal(3, len(addressLabels))
+	assert.Equal("hx0000000000000000000000000000000000000001", addressLabels[1].PublicKey)
+	assert.Equal("team", addressLabels[1].Label)
+	assert.Equal("bridge", addressLabels[2].Label)
+
+	// JSON
+	addressLabels, err = parseAddressLabels("file", []byte(`[{"address": "hx0000000000000000000000000000000000000001", "labels": ["scam"]}]`))
+	assert.Equal(nil, err)
+	assert.Equal(1, len(addressLabels))
+	assert.Equal("scam", addressLabels[0].Label)
+
+	// Invalid
+	_, err = parseAddressLabels("file", []byte(`[{"address": "hx1", "labels": ["scam"]}]`))
+	assert.NotEqual(nil, err)
+	_, err = parseAddressLabels("file", []byte(`[{"address": "hx0000000000000000000000000000000000000001", "labels": ["Not A Slug"]}]`))
+	assert.NotEqual(nil, err)
+	_, err = parseAddressLabels("file", []byte(`[{"address": "hx0000000000000000000000000000000000000001"}]`))
+	assert.NotEqual(nil, err)
+}
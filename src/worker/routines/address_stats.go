@@ -0,0 +1,205 @@
+package routines
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// addressStatsTopHolders - top holder counts
+var addressStatsTopHolders = []int{10, 100, 1000}
+
+// addressStatsBalanceBuckets - lower bounds of the balance buckets in ICX, after the 0 and <1 buckets
+var addressStatsBalanceBuckets = []int64{1, 10, 100, 1000, 10000, 100000, 1000000, 10000000}
+
+func StartAddressStatsRoutine() {
+
+	// routine every hour
+	go addressStatsRoutine(time.Duration(config.Config.AddressStatsIntervalSeconds) * time.Second)
+}
+
+func addressStatsRoutine(duration time.Duration) {
+
+	// Loop every duration
+	for {
+
+		addressStats, err := computeAddressStats(time.Now().UTC())
+		if err != nil {
+			// Postgres error
+			zap.S().Warn("Routine=AddressStats - Error: ", err.Error())
+			time.Sleep(duration)
+			continue
+		}
+
+		err = crud.GetAddressStatModel().ReplaceAll(addressStats)
+		if err != nil {
+			// Postgres error
+			zap.S().Warn("Routine=AddressStats - Error: ", err.Error())
+			time.Sleep(duration)
+			continue
+		}
+
+		zap.S().Info("Routine=AddressStats - Completed routine, sleeping...")
+		time.Sleep(duration)
+	}
+}
+
+// computeAddressStats - compute a snapshot of every address stat
+func computeAddressStats(now time.Time) ([]*models.AddressStat, error) {
+	addressStats := []*models.AddressStat{}
+
+	///////////
+	// Total //
+	///////////
+	total, err := crud.GetAddressStatModel().ComputeTotal()
+	if err != nil {
+		return nil, err
+	}
+	total.Type = crud.AddressStatTypeTotal
+	total.Key = "all"
+	addressStats = append(addressStats, total)
+
+	/////////////////
+	// Top holders //
+	/////////////////
+	for i, top := range addressStatsTopHolders {
+		topHolders, err := crud.GetAddressStatModel().ComputeTopHolders(top)
+		if err != nil {
+			return nil, err
+		}
+		topHolders.Type = crud.AddressStatTypeTopHolders
+		topHolders.Key = strconv.Itoa(top)
+		topHolders.Position = uint32(i)
+		addressStats = append(addressStats, topHolders)
+	}
+
+	//////////////////////////
+	// Balance distribution //
+	//////////////////////////
+	keys, thresholdsLoop := getAddressStatsBalanceBuckets()
+
+	balanceDistribution, err := crud.GetAddressStatModel().ComputeBalanceDistribution(thresholdsLoop)
+	if err != nil {
+		return nil, err
+	}
+	bucketStats := map[uint32]*models.AddressStat{}
+	for i := range *balanceDistribution {
+		bucketStat := &(*balanceDistribution)[i]
+
+		bucketStats[bucketStat.Position] = bucketStat
+	}
+	for i, key := range keys {
+		bucketStat, ok := bucketStats[uint32(i)]
+		if ok == false {
+			// Empty bucket
+			bucketStat = &models.AddressStat{
+				Position:    uint32(i),
+				BalanceLoop: "0",
+			}
+		}
+		bucketStat.Type = crud.AddressStatTypeBalanceDistribution
+		bucketStat.Key = key
+		addressStats = append(addressStats, bucketStat)
+	}
+
+	///////////////////
+	// Address types //
+	///////////////////
+	addressTypes, err := crud.GetAddressStatModel().ComputeAddressTypes()
+	if err != nil {
+		return nil, err
+	}
+	for i := range *addressTypes {
+		addressType := &(*addressTypes)[i]
+
+		addressType.Type = crud.AddressStatTypeAddressType
+		addressStats = append(addressStats, addressType)
+	}
+
+	///////////////////
+	// New addresses //
+	///////////////////
+	days := config.Config.AddressStatsNewAddressesDays
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	fromDay := today.AddDate(0, 0, 1-days)
+
+	newAddresses, err := crud.GetAddressStatModel().ComputeNewAddresses(uint64(fromDay.UnixNano() / 1000))
+	if err != nil {
+		return nil, err
+	}
+	dayStats := map[string]*models.AddressStat{}
+	for i := range *newAddresses {
+		dayStat := &(*newAddresses)[i]
+
+		dayStats[dayStat.Key] = dayStat
+	}
+	for i := 0; i < days; i++ {
+		key := fromDay.AddDate(0, 0, i).Format("2006-01-02")
+
+		dayStat, ok := dayStats[key]
+		if ok == false {
+			// No new addresses
+			dayStat = &models.AddressStat{
+				Key:         key,
+				BalanceLoop: "0",
+			}
+		}
+		dayStat.Type = crud.AddressStatTypeNewAddresses
+		dayStat.Position = uint32(i)
+		addressStats = append(addressStats, dayStat)
+	}
+
+	////////////
+	// Shares //
+	////////////
+	timestamp := uint64(now.UnixNano() / 1000)
+	for _, addressStat := range addressStats {
+		addressStat.Share = getAddressStatShare(addressStat.BalanceLoop, total.BalanceLoop)
+		addressStat.Timestamp = timestamp
+	}
+
+	return addressStats, nil
+}
+
+// getAddressStatsBalanceBuckets - keys of the balance buckets and the thresholds between them in loop
+// Buckets are 0, 0-1, 1-10, ... and the last lower bound with a +
+func getAddressStatsBalanceBuckets() ([]string, []string) {
+	keys := []string{"0", "0-" + strconv.FormatInt(addressStatsBalanceBuckets[0], 10)}
+	thresholdsLoop := []string{"1"}
+
+	icxLoop := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	for i, lowerBound := range addressStatsBalanceBuckets {
+		key := strconv.FormatInt(lowerBound, 10)
+		if i+1 < len(addressStatsBalanceBuckets) {
+			key += "-" + strconv.FormatInt(addressStatsBalanceBuckets[i+1], 10)
+		} else {
+			key += "+"
+		}
+		keys = append(keys, key)
+
+		thresholdsLoop = append(thresholdsLoop, new(big.Int).Mul(big.NewInt(lowerBound), icxLoop).String())
+	}
+
+	return keys, thresholdsLoop
+}
+
+// getAddressStatShare - share of a total, both in loop
+func getAddressStatShare(balanceLoop string, totalLoop string) float64 {
+	balance, ok := new(big.Float).SetString(balanceLoop)
+	if ok == false {
+		return 0
+	}
+	total, ok := new(big.Float).SetString(totalLoop)
+	if ok == false || total.Sign() == 0 {
+		return 0
+	}
+
+	share, _ := new(big.Float).Quo(balance, total).Float64()
+	return share
+}
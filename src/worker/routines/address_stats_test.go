@@ -0,0 +1,30 @@
+package routines
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAddressStatsBalanceBuckets(t *testing.T) {
+	assert := assert.New(t)
+
+	keys, thresholdsLoop := getAddressStatsBalanceBuckets()
+	assert.Equal(len(keys), len(thresholdsLoop)+1)
+	assert.Equal([]string{"0", "0-1", "1-10", "10-100"}, keys[:4])
+	assert.Equal("10000000+", keys[len(keys)-1])
+	assert.Equal("1", thresholdsLoop[0])
+	assert.Equal("1000000000000000000", thresholdsLoop[1])
+	assert.Equal("10000000000000000000000000", thresholdsLoop[len(thresholdsLoop)-1])
+}
+
+func TestGetAddressStatShare(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0.25, getAddressStatShare("250000000000000000000", "1000000000000000000000"))
+	assert.Equal(1.0, getAddressStatShare("1000", "1000"))
+
+	// Empty total
+	assert.Equal(0.0, getAddressStatShare("0", "0"))
+	assert.Equal(0.0, getAddressStatShare("", "1000"))
+}
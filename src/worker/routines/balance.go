@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
+	"github.com/geometry-labs/icon-addresses/config"
 	"github.com/geometry-labs/icon-addresses/crud"
 	"github.com/geometry-labs/icon-addresses/metrics"
 	"github.com/geometry-labs/icon-addresses/models"
@@ -16,11 +17,16 @@ import (
 
 func StartBalanceRoutine() {
 
-	// routine every day
-	go balanceRoutine(3600 * time.Second)
+	// Full scan, dirty addresses are refreshed by the balance refresh routine
+	go balanceRoutine(
+		time.Duration(config.Config.BalanceRoutineIntervalSeconds)*time.Second,
+		utils.GetIconNodeClient(),
+	)
 }
 
-func balanceRoutine(duration time.Duration) {
+// balanceRoutine - refresh the balances of every address
+// Slow consistency pass for addresses the balance refresh routine missed
+func balanceRoutine(duration time.Duration, iconNodeClient utils.IconNodeClient) {
 
 	// Init metrics
 	metrics.BalanceRoutineNumRuns.Set(float64(0))
@@ -46,45 +52,13 @@ func balanceRoutine(duration time.Duration) {
 			}
 
 			zap.S().Info("Routine=Balance", " - Processing ", len(*addresses), " addresses...")
-			for _, a := range *addresses {
-
-				/////////////
-				// Balance //
-				/////////////
-
-				// Node call
-				balance, err := utils.IconNodeServiceGetBalanceOf(a.PublicKey)
-				if err != nil {
-					// Icon node error
-					zap.S().Warn("Routine=Balance, publicKey=", a.PublicKey, " - Error: ", err.Error())
-					continue
-				}
-
-				// Hex -> float64
-				a.Balance = utils.StringHexBase18ToFloat64(balance)
-
-				////////////////////
-				// Staked Balance //
-				////////////////////
-				stakedBalance, err := utils.IconNodeServiceGetStakedBalanceOf(a.PublicKey)
-				if err != nil {
-					// Icon node error
-					zap.S().Warn("Routine=Balance, publicKey=", a.PublicKey, " - Error: ", err.Error())
-					continue
-				}
-
-				// Hex -> float64
-				a.Balance += utils.StringHexBase18ToFloat64(stakedBalance)
-
-				// Copy struct for pointer conflicts
-				addressCopy := &models.Address{}
-				copier.Copy(addressCopy, &a)
-
-				// Insert to database
-				crud.GetAddressModel().LoaderChannel <- addressCopy
-				zap.S().Debug("PUBLICKEY=", a.PublicKey, ",BALANCE=", a.Balance)
-				metrics.BalanceRoutineNumAddressesComputed.Inc()
+
+			numAddresses, err := refreshAddressBalances(addresses, iconNodeClient)
+			if err != nil {
+				// Icon node error
+				zap.S().Warn("Routine=Balance - Error: ", err.Error())
 			}
+			metrics.BalanceRoutineNumAddressesComputed.Add(float64(numAddresses))
 
 			skip += limit
 		}
@@ -95,3 +69,101 @@ func balanceRoutine(duration time.Duration) {
 		time.Sleep(duration)
 	}
 }
+
+// refreshAddressBalances - query the node for the balances and staking of addresses and load them
+// Returns the number of addresses refreshed, addresses with node errors are skipped
+func refreshAddressBalances(addresses *[]models.Address, iconNodeClient utils.IconNodeClient) (int, error) {
+
+	// Node calls
+	publicKeys := []string{}
+	for _, a := range *addresses {
+		publicKeys = append(publicKeys, a.PublicKey)
+	}
+	addressBalances, err := iconNodeClient.GetAddressBalances(publicKeys)
+	if err != nil {
+		return 0, err
+	}
+	addressStakings, err := iconNodeClient.GetAddressStakings(publicKeys)
+	if err != nil {
+		// Icon node error, balances are still refreshed
+		zap.S().Warn("Routine=Balance - Error: ", err.Error())
+		addressStakings = map[string]*utils.IconNodeAddressStaking{}
+	}
+
+	numAddresses := 0
+	unstakesPublicKeys := []string{}
+	addressUnstakes := []*models.AddressUnstake{}
+	for _, a := range *addresses {
+		addressBalance, ok := addressBalances[a.PublicKey]
+		if ok == false {
+			// Icon node error, logged by client
+			continue
+		}
+
+		/////////////
+		// Balance //
+		/////////////
+
+		// Hex -> float64
+		a.Balance = utils.StringHexBase18ToFloat64(addressBalance.Balance)
+
+		// Hex -> loop
+		balanceLoop := utils.StringHexToBigInt(addressBalance.Balance)
+
+		////////////////////
+		// Staked Balance //
+		////////////////////
+
+		// Hex -> float64
+		a.Balance += utils.StringHexBase18ToFloat64(addressBalance.StakedBalance)
+
+		// Hex -> loop
+		a.AvailableBalanceLoop = balanceLoop.String()
+		a.StakedBalanceLoop = utils.StringHexToBigInt(addressBalance.StakedBalance).String()
+		a.BalanceLoop = balanceLoop.Add(balanceLoop, utils.StringHexToBigInt(addressBalance.StakedBalance)).String()
+
+		//////////////
+		// Unstakes //
+		//////////////
+		a.UnstakingBalanceLoop = utils.StringHexToBigInt(addressBalance.UnstakingBalance).String()
+
+		unstakesPublicKeys = append(unstakesPublicKeys, a.PublicKey)
+		for i, unstake := range addressBalance.Unstakes {
+			addressUnstakes = append(addressUnstakes, &models.AddressUnstake{
+				PublicKey:         a.PublicKey,
+				UnstakeIndex:      uint64(i),
+				ValueLoop:         utils.StringHexToBigInt(unstake.Value).String(),
+				UnlockBlockNumber: utils.StringHexToBigInt(unstake.UnlockBlockHeight).Uint64(),
+			})
+		}
+
+		/////////////
+		// Staking //
+		/////////////
+		addressStaking, ok := addressStakings[a.PublicKey]
+		if ok == true {
+			a.DelegatedBalanceLoop = utils.StringHexToBigInt(addressStaking.DelegatedBalance).String()
+			a.BondedBalanceLoop = utils.StringHexToBigInt(addressStaking.BondedBalance).String()
+			a.UnclaimedIscore = utils.StringHexToBigInt(addressStaking.IScore).String()
+		}
+
+		// Copy struct for pointer conflicts
+		addressCopy := &models.Address{}
+		copier.Copy(addressCopy, &a)
+
+		// Insert to database
+		crud.GetAddressModel().LoaderChannel <- addressCopy
+		zap.S().Debug("PUBLICKEY=", a.PublicKey, ",BALANCE=", a.Balance, ",BALANCE_LOOP=", a.BalanceLoop)
+		numAddresses++
+	}
+
+	if len(unstakesPublicKeys) > 0 {
+		err = crud.GetAddressUnstakeModel().ReplaceManyByPublicKeys(unstakesPublicKeys, addressUnstakes)
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+	}
+
+	return numAddresses, nil
+}
@@ -0,0 +1,298 @@
+package routines
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/metrics"
+	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/worker/builders"
+	"github.com/geometry-labs/icon-addresses/worker/utils"
+)
+
+// balanceReconciliationLedger - balances ledger reads of the reconciliation
+type balanceReconciliationLedger interface {
+	SelectManyLatestByPublicKeys(publicKeys []string, blockNumber uint64) (*[]models.Balance, error)
+	SelectOneByBlockNumber(publicKey string, blockNumber uint64) (*models.Balance, error)
+}
+
+// balanceReconciliationDiscrepancies - known discrepancies of the reconciliation
+type balanceReconciliationDiscrepancies interface {
+	SelectManyByPublicKeys(publicKeys []string) (*[]models.BalanceDiscrepancy, error)
+	UpsertOne(balanceDiscrepancy *models.BalanceDiscrepancy) error
+	DeleteOne(publicKey string) error
+}
+
+func StartBalanceReconciliationRoutine() {
+
+	go balanceReconciliationRoutine(
+		time.Duration(config.Config.BalanceReconciliationIntervalSeconds)*time.Second,
+		utils.GetIconNodeClient(),
+	)
+}
+
+// balanceReconciliationRoutine - compare the balances ledger with the node for every address
+// Compared at the last block the ledger is complete for
+func balanceReconciliationRoutine(duration time.Duration, iconNodeClient utils.IconNodeClient) {
+
+	// Loop every duration
+	for {
+
+		blockNumber, err := builders.GetBalanceBuilderCompletedBlockNumber()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			zap.S().Info("Routine=BalanceReconciliation - Balance builder has not completed a block, sleeping...")
+			time.Sleep(duration)
+			continue
+		} else if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+
+		// Loop through all addresses
+		afterPublicKey := ""
+		limit := 100
+		for {
+			addresses, err := crud.GetAddressModel().SelectManyAfterPublicKey(limit, afterPublicKey)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// Sleep
+				break
+			} else if err != nil {
+				zap.S().Fatal(err.Error())
+			}
+			if len(*addresses) == 0 {
+				// Sleep
+				break
+			}
+
+			publicKeys := []string{}
+			for i := range *addresses {
+				publicKeys = append(publicKeys, (*addresses)[i].PublicKey)
+			}
+
+			err = reconcileBalances(
+				publicKeys,
+				blockNumber,
+				iconNodeClient,
+				crud.GetBalanceModel(),
+				crud.GetBalanceDiscrepancyModel(),
+			)
+			if err != nil {
+				// Icon node error
+				zap.S().Warn("Routine=BalanceReconciliation - Error: ", err.Error())
+			}
+
+			afterPublicKey = publicKeys[len(publicKeys)-1]
+		}
+
+		count, err := crud.GetBalanceDiscrepancyModel().Count()
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+		metrics.BalanceDiscrepanciesGauge.Set(float64(count))
+
+		zap.S().Info(
+			"Routine=BalanceReconciliation,",
+			"BlockNumber=", blockNumber,
+			",Discrepancies=", count,
+			" - Completed routine, sleeping...",
+		)
+		time.Sleep(duration)
+	}
+}
+
+// reconcileBalances - record or clear discrepancies of public keys at a block
+func reconcileBalances(
+	publicKeys []string,
+	blockNumber uint64,
+	iconNodeClient utils.IconNodeClient,
+	ledger balanceReconciliationLedger,
+	discrepancies balanceReconciliationDiscrepancies,
+) error {
+
+	// Node values
+	nodeValues, err := getNodeBalanceValues(publicKeys, blockNumber, iconNodeClient)
+	if err != nil {
+		return err
+	}
+
+	// Ledger values
+	ledgerBalances, err := ledger.SelectManyLatestByPublicKeys(publicKeys, blockNumber+1)
+	if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	}
+	ledgerValues := map[string]*big.Int{}
+	for i := range *ledgerBalances {
+		ledgerBalance := &(*ledgerBalances)[i]
+
+		ledgerValues[ledgerBalance.PublicKey] = getLedgerBalanceValue(ledgerBalance)
+	}
+
+	// Known discrepancies
+	balanceDiscrepancies, err := discrepancies.SelectManyByPublicKeys(publicKeys)
+	if err != nil {
+		// Postgres error
+		zap.S().Fatal(err.Error())
+	}
+	knownBalanceDiscrepancies := map[string]*models.BalanceDiscrepancy{}
+	for i := range *balanceDiscrepancies {
+		balanceDiscrepancy := &(*balanceDiscrepancies)[i]
+
+		knownBalanceDiscrepancies[balanceDiscrepancy.PublicKey] = balanceDiscrepancy
+	}
+
+	for _, publicKey := range publicKeys {
+		nodeValue, ok := nodeValues[publicKey]
+		if ok == false {
+			// Icon node error, logged by client
+			continue
+		}
+
+		ledgerValue, ok := ledgerValues[publicKey]
+		if ok == false {
+			ledgerValue = new(big.Int)
+		}
+
+		knownBalanceDiscrepancy, isKnown := knownBalanceDiscrepancies[publicKey]
+
+		if nodeValue.Cmp(ledgerValue) == 0 {
+			if isKnown == true {
+				// Resolved
+				err = discrepancies.DeleteOne(publicKey)
+				if err != nil {
+					// Postgres error
+					zap.S().Fatal(err.Error())
+				}
+			}
+			continue
+		}
+
+		///////////////////////////
+		// First differing block //
+		///////////////////////////
+		firstBlockNumber := blockNumber
+		if isKnown == true && knownBalanceDiscrepancy.FirstBlockNumber <= blockNumber {
+			// Still differs
+			firstBlockNumber = knownBalanceDiscrepancy.FirstBlockNumber
+		} else {
+			firstBlockNumber, err = findFirstBalanceDiscrepancyBlockNumber(publicKey, blockNumber, iconNodeClient, ledger)
+			if err != nil {
+				zap.S().Warn(
+					"Routine=BalanceReconciliation,",
+					"PublicKey=", publicKey,
+					" - Unable to find first differing block, using ", blockNumber, ": ", err.Error(),
+				)
+				firstBlockNumber = blockNumber
+			}
+		}
+
+		zap.S().Info(
+			"Routine=BalanceReconciliation,",
+			"PublicKey=", publicKey,
+			",BlockNumber=", blockNumber,
+			",FirstBlockNumber=", firstBlockNumber,
+			",LedgerValue=", ledgerValue.String(),
+			",NodeValue=", nodeValue.String(),
+			" - Balance discrepancy",
+		)
+
+		now := uint64(time.Now().UnixNano() / 1000)
+		err = discrepancies.UpsertOne(&models.BalanceDiscrepancy{
+			PublicKey:         publicKey,
+			BlockNumber:       blockNumber,
+			FirstBlockNumber:  firstBlockNumber,
+			LedgerValue:       ledgerValue.String(),
+			NodeValue:         nodeValue.String(),
+			Difference:        new(big.Int).Sub(nodeValue, ledgerValue).String(),
+			DetectedTimestamp: now,
+			UpdatedTimestamp:  now,
+		})
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+	}
+
+	return nil
+}
+
+// findFirstBalanceDiscrepancyBlockNumber - binary search for the first block the ledger and node differ
+// NOTE assumes values keep differing once they differ
+// NOTE costs about log2(blockNumber) node calls, only run for new discrepancies
+func findFirstBalanceDiscrepancyBlockNumber(
+	publicKey string,
+	blockNumber uint64,
+	iconNodeClient utils.IconNodeClient,
+	ledger balanceReconciliationLedger,
+) (uint64, error) {
+	low := uint64(0)
+	high := blockNumber
+
+	for low < high {
+		middle := low + (high-low)/2
+
+		// Node value
+		nodeValues, err := getNodeBalanceValues([]string{publicKey}, middle, iconNodeClient)
+		if err != nil {
+			return 0, err
+		}
+		nodeValue, ok := nodeValues[publicKey]
+		if ok == false {
+			return 0, errors.New("No node balance at block " + big.NewInt(int64(middle)).String())
+		}
+
+		// Ledger value
+		ledgerValue := new(big.Int)
+		ledgerBalance, err := ledger.SelectOneByBlockNumber(publicKey, middle)
+		if err == nil {
+			ledgerValue = getLedgerBalanceValue(ledgerBalance)
+		} else if errors.Is(err, gorm.ErrRecordNotFound) == false {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+
+		if nodeValue.Cmp(ledgerValue) == 0 {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+
+	return low, nil
+}
+
+// getNodeBalanceValues - balance, staked and unstaking balance in loop by public key
+// Public keys with node errors are left out
+func getNodeBalanceValues(publicKeys []string, blockNumber uint64, iconNodeClient utils.IconNodeClient) (map[string]*big.Int, error) {
+	addressBalances, err := iconNodeClient.GetAddressBalancesAtBlockNumber(publicKeys, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeValues := map[string]*big.Int{}
+	for publicKey, addressBalance := range addressBalances {
+		nodeValue := utils.StringHexToBigInt(addressBalance.Balance)
+		nodeValue.Add(nodeValue, utils.StringHexToBigInt(addressBalance.StakedBalance))
+		nodeValue.Add(nodeValue, utils.StringHexToBigInt(addressBalance.UnstakingBalance))
+
+		nodeValues[publicKey] = nodeValue
+	}
+
+	return nodeValues, nil
+}
+
+func getLedgerBalanceValue(balance *models.Balance) *big.Int {
+	value, ok := new(big.Int).SetString(balance.ValueLoop, 10)
+	if ok == false {
+		return utils.StringHexToBigInt(balance.Value)
+	}
+
+	return value
+}
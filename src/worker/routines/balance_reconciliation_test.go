@@ -0,0 +1,185 @@
+package routines
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/worker/utils"
+)
+
+// balanceChange - value of an address from a block number on, loop
+type balanceChange struct {
+	blockNumber uint64
+	value       int64
+}
+
+// balanceAtBlockNumber - value after the last change at or before a block number
+// Returns false before the first change
+func balanceAtBlockNumber(changes []balanceChange, blockNumber uint64) (int64, bool) {
+	value := int64(0)
+	ok := false
+	for _, change := range changes {
+		if change.blockNumber > blockNumber {
+			break
+		}
+		value = change.value
+		ok = true
+	}
+	return value, ok
+}
+
+// fakeIconNodeClient - node balances by public key
+type fakeIconNodeClient struct {
+	utils.IconNodeClient
+
+	balances map[string][]balanceChange
+	calls    int
+}
+
+func (c *fakeIconNodeClient) GetAddressBalancesAtBlockNumber(publicKeys []string, blockNumber uint64) (map[string]*utils.IconNodeAddressBalance, error) {
+	c.calls++
+
+	addressBalances := map[string]*utils.IconNodeAddressBalance{}
+	for _, publicKey := range publicKeys {
+		value, _ := balanceAtBlockNumber(c.balances[publicKey], blockNumber)
+		addressBalances[publicKey] = &utils.IconNodeAddressBalance{
+			PublicKey:        publicKey,
+			Balance:          fmt.Sprintf("0x%x", value),
+			StakedBalance:    "0x0",
+			UnstakingBalance: "0x0",
+		}
+	}
+	return addressBalances, nil
+}
+
+// fakeBalanceReconciliationLedger - ledger balances by public key
+type fakeBalanceReconciliationLedger struct {
+	balances map[string][]balanceChange
+}
+
+func (l *fakeBalanceReconciliationLedger) SelectManyLatestByPublicKeys(publicKeys []string, blockNumber uint64) (*[]models.Balance, error) {
+	balances := []models.Balance{}
+	for _, publicKey := range publicKeys {
+		balance, err := l.SelectOneByBlockNumber(publicKey, blockNumber-1)
+		if err == nil {
+			balances = append(balances, *balance)
+		}
+	}
+	return &balances, nil
+}
+
+func (l *fakeBalanceReconciliationLedger) SelectOneByBlockNumber(publicKey string, blockNumber uint64) (*models.Balance, error) {
+	value, ok := balanceAtBlockNumber(l.balances[publicKey], blockNumber)
+	if ok == false {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &models.Balance{
+		PublicKey: publicKey,
+		Value:     fmt.Sprintf("0x%x", value),
+		ValueLoop: fmt.Sprintf("%d", value),
+	}, nil
+}
+
+// fakeBalanceReconciliationDiscrepancies - discrepancies by public key
+type fakeBalanceReconciliationDiscrepancies struct {
+	balanceDiscrepancies map[string]*models.BalanceDiscrepancy
+}
+
+func (d *fakeBalanceReconciliationDiscrepancies) SelectManyByPublicKeys(publicKeys []string) (*[]models.BalanceDiscrepancy, error) {
+	balanceDiscrepancies := []models.BalanceDiscrepancy{}
+	for _, publicKey := range publicKeys {
+		balanceDiscrepancy, ok := d.balanceDiscrepancies[publicKey]
+		if ok == true {
+			balanceDiscrepancies = append(balanceDiscrepancies, *balanceDiscrepancy)
+		}
+	}
+	return &balanceDiscrepancies, nil
+}
+
+func (d *fakeBalanceReconciliationDiscrepancies) UpsertOne(balanceDiscrepancy *models.BalanceDiscrepancy) error {
+	d.balanceDiscrepancies[balanceDiscrepancy.PublicKey] = balanceDiscrepancy
+	return nil
+}
+
+func (d *fakeBalanceReconciliationDiscrepancies) DeleteOne(publicKey string) error {
+	delete(d.balanceDiscrepancies, publicKey)
+	return nil
+}
+
+func TestFindFirstBalanceDiscrepancyBlockNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	publicKey := "hx0000000000000000000000000000000000000001"
+	iconNodeClient := &fakeIconNodeClient{balances: map[string][]balanceChange{
+		publicKey: {{blockNumber: 10, value: 100}, {blockNumber: 600, value: 150}},
+	}}
+	ledger := &fakeBalanceReconciliationLedger{balances: map[string][]balanceChange{
+		publicKey: {{blockNumber: 10, value: 100}},
+	}}
+
+	// Missed change at block 600
+	firstBlockNumber, err := findFirstBalanceDiscrepancyBlockNumber(publicKey, 1000, iconNodeClient, ledger)
+	assert.Equal(nil, err)
+	assert.Equal(uint64(600), firstBlockNumber)
+	assert.LessOrEqual(iconNodeClient.calls, 10)
+
+	// Missed the first change
+	ledger.balances[publicKey] = nil
+	firstBlockNumber, err = findFirstBalanceDiscrepancyBlockNumber(publicKey, 1000, iconNodeClient, ledger)
+	assert.Equal(nil, err)
+	assert.Equal(uint64(10), firstBlockNumber)
+}
+
+func TestReconcileBalances(t *testing.T) {
+	assert := assert.New(t)
+
+	matchingPublicKey := "hx0000000000000000000000000000000000000001"
+	differingPublicKey := "hx0000000000000000000000000000000000000002"
+	resolvedPublicKey := "hx0000000000000000000000000000000000000003"
+	publicKeys := []string{matchingPublicKey, differingPublicKey, resolvedPublicKey}
+
+	iconNodeClient := &fakeIconNodeClient{balances: map[string][]balanceChange{
+		matchingPublicKey:  {{blockNumber: 5, value: 100}},
+		differingPublicKey: {{blockNumber: 5, value: 100}, {blockNumber: 40, value: 300}},
+		resolvedPublicKey:  {{blockNumber: 5, value: 100}},
+	}}
+	ledger := &fakeBalanceReconciliationLedger{balances: map[string][]balanceChange{
+		matchingPublicKey:  {{blockNumber: 5, value: 100}},
+		differingPublicKey: {{blockNumber: 5, value: 100}},
+		resolvedPublicKey:  {{blockNumber: 5, value: 100}},
+	}}
+	discrepancies := &fakeBalanceReconciliationDiscrepancies{balanceDiscrepancies: map[string]*models.BalanceDiscrepancy{
+		resolvedPublicKey: {PublicKey: resolvedPublicKey, BlockNumber: 50, FirstBlockNumber: 20},
+	}}
+
+	err := reconcileBalances(publicKeys, 64, iconNodeClient, ledger, discrepancies)
+	assert.Equal(nil, err)
+
+	// Resolved discrepancy is deleted
+	_, ok := discrepancies.balanceDiscrepancies[resolvedPublicKey]
+	assert.Equal(false, ok)
+	_, ok = discrepancies.balanceDiscrepancies[matchingPublicKey]
+	assert.Equal(false, ok)
+
+	// New discrepancy from its first differing block
+	balanceDiscrepancy, ok := discrepancies.balanceDiscrepancies[differingPublicKey]
+	assert.Equal(true, ok)
+	assert.Equal(uint64(64), balanceDiscrepancy.BlockNumber)
+	assert.Equal(uint64(40), balanceDiscrepancy.FirstBlockNumber)
+	assert.Equal("100", balanceDiscrepancy.LedgerValue)
+	assert.Equal("300", balanceDiscrepancy.NodeValue)
+	assert.Equal("200", balanceDiscrepancy.Difference)
+	assert.Equal(balanceDiscrepancy.DetectedTimestamp, balanceDiscrepancy.UpdatedTimestamp)
+
+	// Known discrepancy keeps its first differing block without searching
+	iconNodeClient.calls = 0
+	err = reconcileBalances(publicKeys, 80, iconNodeClient, ledger, discrepancies)
+	assert.Equal(nil, err)
+	assert.Equal(1, iconNodeClient.calls)
+	assert.Equal(uint64(80), discrepancies.balanceDiscrepancies[differingPublicKey].BlockNumber)
+	assert.Equal(uint64(40), discrepancies.balanceDiscrepancies[differingPublicKey].FirstBlockNumber)
+}
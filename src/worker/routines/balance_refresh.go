@@ -0,0 +1,84 @@
+package routines
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/crud"
+	"github.com/geometry-labs/icon-addresses/metrics"
+	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/redis"
+	"github.com/geometry-labs/icon-addresses/worker/utils"
+)
+
+func StartBalanceRefreshRoutine() {
+
+	go balanceRefreshRoutine(
+		time.Duration(config.Config.BalanceRefreshIntervalMilli)*time.Millisecond,
+		utils.GetIconNodeClient(),
+	)
+}
+
+// balanceRefreshRoutine - refresh the balances of addresses touched by new blocks
+// Dirty addresses are queued by the transactions and logs transformers
+func balanceRefreshRoutine(duration time.Duration, iconNodeClient utils.IconNodeClient) {
+
+	for {
+		publicKeys, err := redis.GetRedisClient().PopSetMembers(
+			redis.DirtyAddressesKey,
+			int64(config.Config.BalanceRefreshBatchSize),
+		)
+		if err != nil {
+			// Redis error
+			zap.S().Warn("Routine=BalanceRefresh - Error: ", err.Error())
+			time.Sleep(duration)
+			continue
+		}
+
+		count, err := redis.GetRedisClient().CountSetMembers(redis.DirtyAddressesKey)
+		if err == nil {
+			metrics.DirtyAddressesGauge.Set(float64(count))
+		}
+
+		if len(publicKeys) == 0 {
+			// Sleep
+			time.Sleep(duration)
+			continue
+		}
+
+		// Addresses not loaded yet are inserted by the address loader
+		addresses, err := crud.GetAddressModel().SelectManyByPublicKeys(publicKeys)
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal(err.Error())
+		}
+		isLoaded := map[string]bool{}
+		for i := range *addresses {
+			isLoaded[(*addresses)[i].PublicKey] = true
+		}
+		for _, publicKey := range publicKeys {
+			if isLoaded[publicKey] == false {
+				*addresses = append(*addresses, models.Address{PublicKey: publicKey})
+			}
+		}
+
+		numAddresses, err := refreshAddressBalances(addresses, iconNodeClient)
+		if err != nil {
+			// Icon node error, requeue
+			zap.S().Warn("Routine=BalanceRefresh - Error: ", err.Error())
+
+			err = redis.GetRedisClient().AddSetMembers(redis.DirtyAddressesKey, publicKeys...)
+			if err != nil {
+				zap.S().Warn("Routine=BalanceRefresh - Unable to requeue ", len(publicKeys), " addresses - Error: ", err.Error())
+			}
+
+			time.Sleep(duration)
+			continue
+		}
+		metrics.BalanceRefreshAddressesComputedCounter.Add(float64(numAddresses))
+
+		zap.S().Debug("Routine=BalanceRefresh - Refreshed ", numAddresses, " addresses")
+	}
+}
@@ -1,22 +1,26 @@
 package transformers
 
 import (
+	"context"
+	"errors"
+
 	"go.uber.org/zap"
-	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
 
 	"github.com/geometry-labs/icon-addresses/config"
 	"github.com/geometry-labs/icon-addresses/crud"
 	"github.com/geometry-labs/icon-addresses/kafka"
 	"github.com/geometry-labs/icon-addresses/metrics"
 	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/worker/builders"
 )
 
 // StartBlocksTransformer - start block transformer go routine
-func StartBlocksTransformer() {
-	go blocksTransformer()
+func StartBlocksTransformer(ctx context.Context) {
+	go blocksTransformer(ctx)
 }
 
-func blocksTransformer() {
+func blocksTransformer(ctx context.Context) {
 	consumerTopicNameBlocks := config.Config.ConsumerTopicBlocks
 
 	// Input channels
@@ -32,11 +36,50 @@ func blocksTransformer() {
 		// Kafka Message //
 		///////////////////
 
-		consumerTopicMsg := <-consumerTopicChanBlocks
+		var consumerTopicMsg *kafka.TopicMessage
+		select {
+		case consumerTopicMsg = <-consumerTopicChanBlocks:
+		case <-ctx.Done():
+			zap.S().Info("Blocks Transformer: stopped working")
+			return
+		}
 		blockRaw, err := convertToBlockRawProtoBuf(consumerTopicMsg.Value)
 		zap.S().Debug("Blocks Transformer: Processing block #", blockRaw.Number)
 		if err != nil {
-			zap.S().Fatal("Blocks transformer: Unable to proceed cannot convert kafka msg value to BlockRaw, err: ", err.Error())
+			kafka.HandleProcessError(consumerTopicMsg, err)
+			continue
+		}
+
+		////////////
+		// Reorgs //
+		////////////
+		if crud.IsOrphanedBlockHash(blockRaw.Hash) == true {
+			// Replayed block of a rolled back chain
+			zap.S().Warn("Blocks Transformer: Skipping orphaned block #", blockRaw.Number, " hash=", blockRaw.Hash)
+			consumerTopicMsg.Processed()
+			continue
+		}
+
+		forkBlockNumber, orphanedBlocks, err := detectBlockReorg(blockRaw, crud.GetBlockModel())
+		if err != nil {
+			// Postgres error
+			zap.S().Fatal("Blocks Transformer: Unable to detect reorgs at block #", blockRaw.Number, " - Error: ", err.Error())
+		}
+		if len(orphanedBlocks) > 0 {
+			zap.S().Warn(
+				"Blocks Transformer: Reorg detected, rolling back ", len(orphanedBlocks),
+				" orphaned blocks from block #", forkBlockNumber,
+			)
+
+			err = builders.RewindBalanceBuilders(uint64(forkBlockNumber), func() error {
+				return crud.RollbackBlocks(forkBlockNumber, orphanedBlocks)
+			})
+			if err != nil {
+				// Postgres or redis error
+				zap.S().Fatal("Blocks Transformer: Unable to roll back from block #", forkBlockNumber, " - Error: ", err.Error())
+			}
+
+			metrics.BlockReorgsCounter.Inc()
 		}
 
 		/////////////
@@ -45,8 +88,12 @@ func blocksTransformer() {
 
 		// Load to: blocks
 		block := transformBlockRawToBlock(blockRaw)
+		crud.SetLoaderAck(block, consumerTopicMsg.Track())
 		blockLoaderChan <- block
 
+		// Offset is marked once loads are persisted
+		consumerTopicMsg.Processed()
+
 		/////////////
 		// Metrics //
 		/////////////
@@ -58,7 +105,7 @@ func blocksTransformer() {
 
 func convertToBlockRawProtoBuf(value []byte) (*models.BlockRaw, error) {
 	block := models.BlockRaw{}
-	err := proto.Unmarshal(value[6:], &block)
+	err := kafka.GetDecoder(config.Config.ConsumerTopicBlocks).Decode(value, &block)
 	if err != nil {
 		zap.S().Error("Error: ", err.Error())
 	}
@@ -71,5 +118,115 @@ func transformBlockRawToBlock(blockRaw *models.BlockRaw) *models.Block {
 		Number:           blockRaw.Number,
 		TransactionCount: blockRaw.TransactionCount,
 		LogCount:         0, // Adds in loader
+		Hash:             blockRaw.Hash,
+		ParentHash:       blockRaw.ParentHash,
+	}
+}
+
+// blockReorgStore - stored blocks read to detect reorgs, see crud.BlockModel
+type blockReorgStore interface {
+	SelectOne(number uint32) (*models.Block, error)
+	SelectLatestNumber() (uint32, error)
+	SelectManyFromNumber(number uint32) (*[]models.Block, error)
+}
+
+// detectBlockReorg - stored blocks replaced by a new block
+// A stored parent or a stored block at the same number with a different hash means the chain was reorganized
+// Returns the fork block number and the orphaned blocks, none if there is no reorg
+// NOTE a parent mismatch forks at the parent, the replacement parent is loaded when it arrives
+func detectBlockReorg(blockRaw *models.BlockRaw, blocks blockReorgStore) (uint32, []*models.Block, error) {
+	if config.Config.ReorgMaxDepth == 0 || blockRaw.Hash == "" {
+		// Disabled
+		return 0, nil, nil
+	}
+
+	forkBlockNumber := blockRaw.Number
+	isReorg := false
+
+	// Parent block
+	if blockRaw.Number > 0 && blockRaw.ParentHash != "" {
+		parentBlock, err := blocks.SelectOne(blockRaw.Number - 1)
+		if err != nil && errors.Is(err, gorm.ErrRecordNotFound) == false {
+			// Postgres error
+			return 0, nil, err
+		} else if err == nil && parentBlock.Hash != "" && parentBlock.Hash != blockRaw.ParentHash {
+			forkBlockNumber = blockRaw.Number - 1
+			isReorg = true
+		}
+	}
+
+	// Same block number
+	if isReorg == false {
+		curBlock, err := blocks.SelectOne(blockRaw.Number)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil, nil
+		} else if err != nil {
+			// Postgres error
+			return 0, nil, err
+		}
+		if curBlock.Hash == "" || curBlock.Hash == blockRaw.Hash {
+			return 0, nil, nil
+		}
+	}
+
+	// Old blocks from a replayed topic are not reorgs
+	latestBlockNumber, err := blocks.SelectLatestNumber()
+	if err != nil {
+		// Postgres error
+		return 0, nil, err
+	}
+	if latestBlockNumber > forkBlockNumber+config.Config.ReorgMaxDepth {
+		zap.S().Warn(
+			"Blocks Transformer: Block #", blockRaw.Number,
+			" hash does not match stored chain, fork is deeper than ", config.Config.ReorgMaxDepth,
+			" blocks, skipping rollback",
+		)
+		return 0, nil, nil
+	}
+
+	storedBlocks, err := blocks.SelectManyFromNumber(forkBlockNumber)
+	if err != nil {
+		// Postgres error
+		return 0, nil, err
+	}
+
+	return forkBlockNumber, getOrphanedBlocks(blockRaw, forkBlockNumber, storedBlocks), nil
+}
+
+// getOrphanedBlocks - stored blocks at or above a fork block number that are not on the chain of a new block
+// NOTE stored blocks of the new chain may be loaded before the new block
+func getOrphanedBlocks(blockRaw *models.BlockRaw, forkBlockNumber uint32, storedBlocks *[]models.Block) []*models.Block {
+
+	// Block number -> hash of the new chain
+	chainHashes := map[uint32]string{
+		blockRaw.Number: blockRaw.Hash,
 	}
+	if blockRaw.Number > 0 && blockRaw.ParentHash != "" {
+		chainHashes[blockRaw.Number-1] = blockRaw.ParentHash
+	}
+
+	orphanedBlocks := []*models.Block{}
+	for i := range *storedBlocks {
+		storedBlock := &(*storedBlocks)[i]
+
+		if storedBlock.Number < forkBlockNumber {
+			continue
+		}
+
+		if chainHash, ok := chainHashes[storedBlock.Number]; ok == true && storedBlock.Hash == chainHash {
+			// New block or its parent
+			continue
+		}
+
+		chainHash, ok := chainHashes[storedBlock.Number-1]
+		if storedBlock.Number > blockRaw.Number && ok == true && storedBlock.ParentHash == chainHash {
+			// New chain
+			chainHashes[storedBlock.Number] = storedBlock.Hash
+			continue
+		}
+
+		orphanedBlocks = append(orphanedBlocks, storedBlock)
+	}
+
+	return orphanedBlocks
 }
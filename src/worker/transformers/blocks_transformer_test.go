@@ -0,0 +1,142 @@
+package transformers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/models"
+)
+
+// fakeBlockReorgStore - stored blocks by number
+type fakeBlockReorgStore struct {
+	blocks map[uint32]*models.Block
+}
+
+func (s *fakeBlockReorgStore) SelectOne(number uint32) (*models.Block, error) {
+	block, ok := s.blocks[number]
+	if ok == false {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return block, nil
+}
+
+func (s *fakeBlockReorgStore) SelectLatestNumber() (uint32, error) {
+	latestNumber := uint32(0)
+	for number := range s.blocks {
+		if number > latestNumber {
+			latestNumber = number
+		}
+	}
+	return latestNumber, nil
+}
+
+func (s *fakeBlockReorgStore) SelectManyFromNumber(number uint32) (*[]models.Block, error) {
+	blocks := []models.Block{}
+	latestNumber, _ := s.SelectLatestNumber()
+	for n := number; n <= latestNumber; n++ {
+		block, ok := s.blocks[n]
+		if ok == true {
+			blocks = append(blocks, *block)
+		}
+	}
+	return &blocks, nil
+}
+
+func newFakeBlockReorgStore(blocks ...*models.Block) *fakeBlockReorgStore {
+	store := &fakeBlockReorgStore{blocks: map[uint32]*models.Block{}}
+	for _, block := range blocks {
+		store.blocks[block.Number] = block
+	}
+	return store
+}
+
+func TestDetectBlockReorg(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.ReorgMaxDepth = 10
+	defer func() { config.Config.ReorgMaxDepth = 0 }()
+
+	store := newFakeBlockReorgStore(
+		&models.Block{Number: 9, Hash: "0x9", ParentHash: "0x8"},
+		&models.Block{Number: 10, Hash: "0xa", ParentHash: "0x9"},
+		&models.Block{Number: 11, Hash: "0xb", ParentHash: "0xa"},
+		// New chain, loaded before the new block 10
+		&models.Block{Number: 12, Hash: "0xc2", ParentHash: "0xb2"},
+	)
+
+	// Next block
+	forkBlockNumber, orphanedBlocks, err := detectBlockReorg(&models.BlockRaw{Number: 13, Hash: "0xd2", ParentHash: "0xc2"}, store)
+	assert.Nil(err)
+	assert.Equal(0, len(orphanedBlocks))
+
+	// Replayed block
+	forkBlockNumber, orphanedBlocks, err = detectBlockReorg(&models.BlockRaw{Number: 10, Hash: "0xa", ParentHash: "0x9"}, store)
+	assert.Nil(err)
+	assert.Equal(0, len(orphanedBlocks))
+
+	// Parent mismatch forks at the parent, blocks built on the new block are kept
+	forkBlockNumber, orphanedBlocks, err = detectBlockReorg(&models.BlockRaw{Number: 10, Hash: "0xa", ParentHash: "0x92"}, store)
+	assert.Nil(err)
+	assert.Equal(uint32(9), forkBlockNumber)
+	orphanedHashes := []string{}
+	for _, orphanedBlock := range orphanedBlocks {
+		orphanedHashes = append(orphanedHashes, orphanedBlock.Hash)
+	}
+	assert.Equal([]string{"0x9", "0xc2"}, orphanedHashes)
+
+	// Reorg, blocks built on the new block are kept
+	store.blocks[11] = &models.Block{Number: 11, Hash: "0xb", ParentHash: "0xa"}
+	store.blocks[12] = &models.Block{Number: 12, Hash: "0xc", ParentHash: "0xb"}
+	store.blocks[13] = &models.Block{Number: 13, Hash: "0xd2", ParentHash: "0xc2"}
+	forkBlockNumber, orphanedBlocks, err = detectBlockReorg(&models.BlockRaw{Number: 11, Hash: "0xb2", ParentHash: "0xa"}, store)
+	assert.Nil(err)
+	assert.Equal(uint32(11), forkBlockNumber)
+	orphanedHashes = []string{}
+	for _, orphanedBlock := range orphanedBlocks {
+		orphanedHashes = append(orphanedHashes, orphanedBlock.Hash)
+	}
+	assert.Equal([]string{"0xb", "0xc", "0xd2"}, orphanedHashes)
+
+	// Fork deeper than the max depth
+	store.blocks[30] = &models.Block{Number: 30, Hash: "0x1e", ParentHash: "0x1d"}
+	forkBlockNumber, orphanedBlocks, err = detectBlockReorg(&models.BlockRaw{Number: 11, Hash: "0xb2", ParentHash: "0xa"}, store)
+	assert.Nil(err)
+	assert.Equal(0, len(orphanedBlocks))
+
+	// Parent mismatch deeper than the max depth
+	forkBlockNumber, orphanedBlocks, err = detectBlockReorg(&models.BlockRaw{Number: 12, Hash: "0xc", ParentHash: "0xb2"}, store)
+	assert.Nil(err)
+	assert.Equal(0, len(orphanedBlocks))
+
+	// Disabled
+	config.Config.ReorgMaxDepth = 0
+	forkBlockNumber, orphanedBlocks, err = detectBlockReorg(&models.BlockRaw{Number: 30, Hash: "0x1e2", ParentHash: "0x1d"}, store)
+	assert.Nil(err)
+	assert.Equal(0, len(orphanedBlocks))
+}
+
+func TestGetOrphanedBlocks(t *testing.T) {
+	assert := assert.New(t)
+
+	storedBlocks := &[]models.Block{
+		{Number: 10, Hash: "0xa", ParentHash: "0x9"},
+		// Built on the new block
+		{Number: 11, Hash: "0xb2", ParentHash: "0xa2"},
+		{Number: 12, Hash: "0xc2", ParentHash: "0xb2"},
+		// Built on an orphaned block
+		{Number: 13, Hash: "0xd", ParentHash: "0xc"},
+		// Gap, not linked to the new chain
+		{Number: 15, Hash: "0xf2", ParentHash: "0xe2"},
+	}
+
+	orphanedBlocks := getOrphanedBlocks(&models.BlockRaw{Number: 10, Hash: "0xa2", ParentHash: "0x9"}, 10, storedBlocks)
+
+	orphanedNumbers := []uint32{}
+	for _, orphanedBlock := range orphanedBlocks {
+		orphanedNumbers = append(orphanedNumbers, orphanedBlock.Number)
+	}
+	assert.Equal([]uint32{10, 13, 15}, orphanedNumbers)
+}
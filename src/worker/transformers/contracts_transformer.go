@@ -1,8 +1,9 @@
 package transformers
 
 import (
+	"context"
+
 	"go.uber.org/zap"
-	"google.golang.org/protobuf/proto"
 
 	"github.com/geometry-labs/icon-addresses/config"
 	"github.com/geometry-labs/icon-addresses/crud"
@@ -11,11 +12,11 @@ import (
 )
 
 // StartContractsTransformer - start contract transformer go routine
-func StartContractsTransformer() {
-	go contractsTransformer()
+func StartContractsTransformer(ctx context.Context) {
+	go contractsTransformer(ctx)
 }
 
-func contractsTransformer() {
+func contractsTransformer(ctx context.Context) {
 	consumerTopicNameContracts := config.Config.ConsumerTopicContractsProcessed
 
 	// Input channels
@@ -32,11 +33,18 @@ func contractsTransformer() {
 		// Kafka Message //
 		///////////////////
 
-		consumerTopicMsg := <-consumerTopicChanContracts
+		var consumerTopicMsg *kafka.TopicMessage
+		select {
+		case consumerTopicMsg = <-consumerTopicChanContracts:
+		case <-ctx.Done():
+			zap.S().Info("Contracts Transformer: stopped working")
+			return
+		}
 		contractRaw, err := convertToContractRawProtoBuf(consumerTopicMsg.Value)
 		zap.S().Debug("Contracts Transformer: Processing contract #", contractRaw.Address)
 		if err != nil {
-			zap.S().Fatal("Contracts transformer: Unable to proceed cannot convert kafka msg value to BlockRaw, err: ", err.Error())
+			kafka.HandleProcessError(consumerTopicMsg, err)
+			continue
 		}
 
 		/////////////
@@ -44,18 +52,23 @@ func contractsTransformer() {
 		/////////////
 
 		// Load to: contracts
+		crud.SetLoaderAck(contractRaw, consumerTopicMsg.Track())
 		contractLoaderChan <- contractRaw
 
 		addressCountToken := transformContractToAddressCountToken(contractRaw)
 		if addressCountToken != nil {
+			crud.SetLoaderAck(addressCountToken, consumerTopicMsg.Track())
 			addressCountLoaderChan <- addressCountToken
 		}
+
+		// Offset is marked once loads are persisted
+		consumerTopicMsg.Processed()
 	}
 }
 
 func convertToContractRawProtoBuf(value []byte) (*models.ContractProcessed, error) {
 	contract := models.ContractProcessed{}
-	err := proto.Unmarshal(value[6:], &contract)
+	err := kafka.GetDecoder(config.Config.ConsumerTopicContractsProcessed).Decode(value, &contract)
 	if err != nil {
 		zap.S().Error("Error: ", err.Error())
 	}
@@ -1,8 +1,9 @@
 package transformers
 
 import (
+	"context"
+
 	"go.uber.org/zap"
-	"google.golang.org/protobuf/proto"
 
 	"github.com/geometry-labs/icon-addresses/config"
 	"github.com/geometry-labs/icon-addresses/crud"
@@ -11,11 +12,11 @@ import (
 )
 
 // StartGovernancePrepsTransformer - start governancePrep transformer go routine
-func StartGovernancePrepsTransformer() {
-	go governancePrepsTransformer()
+func StartGovernancePrepsTransformer(ctx context.Context) {
+	go governancePrepsTransformer(ctx)
 }
 
-func governancePrepsTransformer() {
+func governancePrepsTransformer(ctx context.Context) {
 	consumerTopicNameGovernancePreps := config.Config.ConsumerTopicGovernancePrepsProcessed
 
 	// Input channels
@@ -31,11 +32,18 @@ func governancePrepsTransformer() {
 		// Kafka Message //
 		///////////////////
 
-		consumerTopicMsg := <-consumerTopicChanGovernancePreps
+		var consumerTopicMsg *kafka.TopicMessage
+		select {
+		case consumerTopicMsg = <-consumerTopicChanGovernancePreps:
+		case <-ctx.Done():
+			zap.S().Info("GovernancePreps Transformer: stopped working")
+			return
+		}
 		governancePrepRaw, err := convertToGovernancePrepRawProtoBuf(consumerTopicMsg.Value)
 		zap.S().Debug("GovernancePreps Transformer: Processing governancePrep #", governancePrepRaw.Address)
 		if err != nil {
-			zap.S().Fatal("GovernancePreps transformer: Unable to proceed cannot convert kafka msg value to BlockRaw, err: ", err.Error())
+			kafka.HandleProcessError(consumerTopicMsg, err)
+			continue
 		}
 
 		/////////////
@@ -43,13 +51,17 @@ func governancePrepsTransformer() {
 		/////////////
 
 		// Load to: governancePreps
+		crud.SetLoaderAck(governancePrepRaw, consumerTopicMsg.Track())
 		governancePrepLoaderChan <- governancePrepRaw
+
+		// Offset is marked once loads are persisted
+		consumerTopicMsg.Processed()
 	}
 }
 
 func convertToGovernancePrepRawProtoBuf(value []byte) (*models.GovernancePrepProcessed, error) {
 	governancePrep := models.GovernancePrepProcessed{}
-	err := proto.Unmarshal(value[6:], &governancePrep)
+	err := kafka.GetDecoder(config.Config.ConsumerTopicGovernancePrepsProcessed).Decode(value, &governancePrep)
 	if err != nil {
 		zap.S().Error("Error: ", err.Error())
 	}
@@ -1,11 +1,15 @@
 package transformers
 
 import (
+	"context"
+
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
-	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
 	"github.com/geometry-labs/icon-addresses/config"
@@ -13,13 +17,24 @@ import (
 	"github.com/geometry-labs/icon-addresses/kafka"
 	"github.com/geometry-labs/icon-addresses/metrics"
 	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/redis"
+	"github.com/geometry-labs/icon-addresses/worker/utils"
 )
 
-func StartLogsTransformer() {
-	go logsTransformer()
+// Token contract address -> decimals
+// NOTE only accessed from the logs transformer go routine
+var tokenDecimalsCache = map[string]*tokenDecimalsCacheEntry{}
+
+type tokenDecimalsCacheEntry struct {
+	decimals  uint32
+	expiresAt time.Time // Zero for decimals from the node
+}
+
+func StartLogsTransformer(ctx context.Context) {
+	go logsTransformer(ctx)
 }
 
-func logsTransformer() {
+func logsTransformer(ctx context.Context) {
 	consumerTopicNameLogs := config.Config.ConsumerTopicLogs
 
 	// Input Channels
@@ -28,9 +43,12 @@ func logsTransformer() {
 	// Output channels
 	addressLoaderChan := crud.GetAddressModel().LoaderChannel
 	addressTokenLoaderChan := crud.GetAddressTokenModel().LoaderChannel
+	tokenBalanceLoaderChan := crud.GetTokenBalanceModel().LoaderChannel
 	transactionLoaderChan := crud.GetTransactionModel().LoaderChannel
+	iScoreClaimLoaderChan := crud.GetIScoreClaimModel().LoaderChannel
 	logCountByPublicKeyLoaderChan := crud.GetLogCountByPublicKeyModel().LoaderChannel
 	logCountByBlockNumberLoaderChan := crud.GetLogCountByBlockNumberModel().LoaderChannel
+	dirtyAddresses := redis.GetDirtyAddresses()
 
 	zap.S().Debug("Logs Worker: started working")
 	for {
@@ -39,11 +57,44 @@ func logsTransformer() {
 		// Kafka Message //
 		///////////////////
 
-		consumerTopicMsg := <-consumerTopicChanLogs
+		var consumerTopicMsg *kafka.TopicMessage
+		select {
+		case consumerTopicMsg = <-consumerTopicChanLogs:
+		case <-ctx.Done():
+			zap.S().Info("Logs Transformer: stopped working")
+			return
+		}
 		logRaw, err := convertBytesToLogRawProtoBuf(consumerTopicMsg.Value)
 		zap.S().Debug("Logs Transformer: Processing log in tx hash=", logRaw.TransactionHash)
 		if err != nil {
-			zap.S().Fatal("Logs Worker: Unable to proceed cannot convert kafka msg value to LogRaw, err: ", err.Error())
+			kafka.HandleProcessError(consumerTopicMsg, err)
+			continue
+		}
+
+		indexed, err := parseLogRawIndexed(logRaw)
+		if err != nil {
+			kafka.HandleProcessError(consumerTopicMsg, &kafka.PayloadError{Err: err})
+			continue
+		}
+
+		data, err := parseLogRawData(logRaw, indexed)
+		if err != nil {
+			kafka.HandleProcessError(consumerTopicMsg, &kafka.PayloadError{Err: err})
+			continue
+		}
+
+		////////////
+		// Reorgs //
+		////////////
+
+		// NOTE rollbacks wait until the rows of the message are sent
+		crud.RLockRollbacks()
+		if crud.IsOrphanedBlockHash(logRaw.BlockHash) == true {
+			// Rolled back
+			crud.RUnlockRollbacks()
+			zap.S().Debug("Logs Transformer: Skipping message of orphaned block #", logRaw.BlockNumber)
+			consumerTopicMsg.Processed()
+			continue
 		}
 
 		/////////////
@@ -51,42 +102,88 @@ func logsTransformer() {
 		/////////////
 
 		// Loads to addresses (from address)
-		fromAddress := transformLogRawToAddress(logRaw, true)
+		fromAddress := transformLogRawToAddress(logRaw, indexed, true)
 		if fromAddress != nil {
+			crud.SetLoaderAck(fromAddress, consumerTopicMsg.Track())
 			addressLoaderChan <- fromAddress
 		}
 
 		// Loads to addresses (to address)
-		toAddress := transformLogRawToAddress(logRaw, false)
+		toAddress := transformLogRawToAddress(logRaw, indexed, false)
 		if toAddress != nil {
+			crud.SetLoaderAck(toAddress, consumerTopicMsg.Track())
 			addressLoaderChan <- toAddress
 		}
 
 		// Loads to address_tokens (from address)
-		fromAddressToken := transformLogRawToAddressToken(logRaw, true)
+		fromAddressToken := transformLogRawToAddressToken(logRaw, indexed, true)
 		if fromAddressToken != nil {
+			crud.SetLoaderAck(fromAddressToken, consumerTopicMsg.Track())
 			addressTokenLoaderChan <- fromAddressToken
 		}
 
 		// Loads to address_tokens (to address)
-		toAddressToken := transformLogRawToAddressToken(logRaw, false)
+		toAddressToken := transformLogRawToAddressToken(logRaw, indexed, false)
 		if toAddressToken != nil {
+			crud.SetLoaderAck(toAddressToken, consumerTopicMsg.Track())
 			addressTokenLoaderChan <- toAddressToken
 		}
 
+		// Loads to token_balances (from address)
+		fromTokenBalance := transformLogRawToTokenBalance(logRaw, indexed, true)
+		if fromTokenBalance != nil {
+			crud.SetLoaderAck(fromTokenBalance, consumerTopicMsg.Track())
+			tokenBalanceLoaderChan <- fromTokenBalance
+		}
+
+		// Loads to token_balances (to address)
+		toTokenBalance := transformLogRawToTokenBalance(logRaw, indexed, false)
+		if toTokenBalance != nil {
+			crud.SetLoaderAck(toTokenBalance, consumerTopicMsg.Track())
+			tokenBalanceLoaderChan <- toTokenBalance
+		}
+
 		// Loads to transactions
-		transaction := transformLogRawToTransaction(logRaw)
+		transaction := transformLogRawToTransaction(logRaw, indexed)
 		if transaction != nil {
+			crud.SetLoaderAck(transaction, consumerTopicMsg.Track())
 			transactionLoaderChan <- transaction
 		}
 
+		// Loads to i_score_claims
+		iScoreClaim := transformLogRawToIScoreClaim(logRaw, indexed, data)
+		if iScoreClaim != nil {
+			crud.SetLoaderAck(iScoreClaim, consumerTopicMsg.Track())
+			iScoreClaimLoaderChan <- iScoreClaim
+		}
+
 		// Loads to log_count_by_addresses
 		logCountByPublicKeyFromAddress := transformLogRawToLogCountByPublicKey(logRaw)
+		crud.SetLoaderAck(logCountByPublicKeyFromAddress, consumerTopicMsg.Track())
 		logCountByPublicKeyLoaderChan <- logCountByPublicKeyFromAddress
 
 		// Loads to log_count_by_block_number
 		logCountByBlockNumber := transformLogRawToLogCountByBlockNumber(logRaw)
+		crud.SetLoaderAck(logCountByBlockNumber, consumerTopicMsg.Track())
 		logCountByBlockNumberLoaderChan <- logCountByBlockNumber
+		crud.RUnlockRollbacks()
+
+		///////////////////////////
+		// Balance refresh queue //
+		///////////////////////////
+
+		// Internal ICX transfers and I-Score claims
+		// NOTE token transfers do not change ICX balances
+		if transaction != nil {
+			dirtyAddresses.Add(transaction.FromAddress)
+			dirtyAddresses.Add(transaction.ToAddress)
+		}
+		if iScoreClaim != nil {
+			dirtyAddresses.Add(iScoreClaim.PublicKey)
+		}
+
+		// Offset is marked once loads are persisted
+		consumerTopicMsg.Processed()
 
 		/////////////
 		// Metrics //
@@ -97,26 +194,79 @@ func logsTransformer() {
 
 func convertBytesToLogRawProtoBuf(value []byte) (*models.LogRaw, error) {
 	log := models.LogRaw{}
-	err := proto.Unmarshal(value[6:], &log)
+	err := kafka.GetDecoder(config.Config.ConsumerTopicLogs).Decode(value, &log)
 	if err != nil {
 		zap.S().Error("Error: ", err.Error())
-		zap.S().Error("Value=", hex.Dump(value[6:]))
+		zap.S().Error("Value=", hex.Dump(value))
 	}
 	return &log, err
 }
 
-func transformLogRawToAddress(logRaw *models.LogRaw, useFromAddress bool) *models.Address {
+// parseLogRawIndexed - parse and validate the indexed field of a log
+func parseLogRawIndexed(logRaw *models.LogRaw) ([]string, error) {
 
 	var indexed []string
 	err := json.Unmarshal([]byte(logRaw.Indexed), &indexed)
 	if err != nil {
-		zap.S().Fatal("Unable to parse indexed field in log; indexed=", logRaw.Indexed, " error: ", err.Error())
+		return nil, fmt.Errorf("Unable to parse indexed field in log; indexed=%s error: %w", logRaw.Indexed, err)
 	}
 
+	if len(indexed) == 0 {
+		return nil, errors.New("Empty indexed field in log")
+	}
+
+	// ICXTransfer(Address,Address,int)
 	method := strings.Split(indexed[0], "(")[0]
+	if method == "ICXTransfer" && len(indexed) != 4 {
+		return nil, fmt.Errorf("Invalid ICXTransfer log; indexed=%s", logRaw.Indexed)
+	}
 
-	if method != "ICXTransfer" {
-		// Not internal transaction
+	// IScoreClaimedV2(Address,int,int)
+	if method == "IScoreClaimedV2" && logRaw.Address == utils.IconNodeGovernanceAddress && len(indexed) != 2 {
+		return nil, fmt.Errorf("Invalid IScoreClaimedV2 log; indexed=%s", logRaw.Indexed)
+	}
+
+	return indexed, nil
+}
+
+// parseLogRawData - parse and validate the data field of logs that are loaded from it
+// Returns nil for other logs
+func parseLogRawData(logRaw *models.LogRaw, indexed []string) ([]string, error) {
+
+	if isIScoreClaimLog(logRaw, indexed) == false {
+		return nil, nil
+	}
+
+	var data []string
+	err := json.Unmarshal([]byte(logRaw.Data), &data)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse data field in log; data=%s error: %w", logRaw.Data, err)
+	}
+
+	// data[0] = I-Score, data[1] = ICX
+	if len(data) != 2 {
+		return nil, fmt.Errorf("Invalid IScoreClaimedV2 log; data=%s", logRaw.Data)
+	}
+
+	return data, nil
+}
+
+// isIScoreClaimLog - check if a log is an I-Score claim of the chain score
+// NOTE IScoreClaimed(int,int) logs from before IScoreClaimedV2 have no claimer address, they are not loaded
+func isIScoreClaimLog(logRaw *models.LogRaw, indexed []string) bool {
+	method := strings.Split(indexed[0], "(")[0]
+
+	return method == "IScoreClaimedV2" && logRaw.Address == utils.IconNodeGovernanceAddress
+}
+
+// transformLogRawToAddress - activity of the sender or receiver of an internal ICX transfer or a token transfer
+func transformLogRawToAddress(logRaw *models.LogRaw, indexed []string, useFromAddress bool) *models.Address {
+
+	method := strings.Split(indexed[0], "(")[0]
+
+	isTokenTransfer := indexed[0] == "Transfer(Address,Address,int,bytes)" && len(indexed) == 4
+	if method != "ICXTransfer" && isTokenTransfer == false {
+		// Not internal transaction or token transfer
 		return nil
 	}
 
@@ -131,54 +281,125 @@ func transformLogRawToAddress(logRaw *models.LogRaw, useFromAddress bool) *model
 
 	// Is Contract
 	isContract := false
-	if publicKey[:2] == "cx" {
+	if strings.HasPrefix(publicKey, "cx") == true {
 		isContract = true
 	}
 
 	return &models.Address{
-		PublicKey:        publicKey,
-		IsContract:       isContract,
-		TransactionCount: 0, // Enriched in loader
-		LogCount:         0, // Enriched in loader
-		Balance:          0, // Enriched in loader
+		PublicKey:                publicKey,
+		IsContract:               isContract,
+		TransactionCount:         0,                  // Enriched in loader
+		LogCount:                 0,                  // Enriched in loader
+		Balance:                  0,                  // Enriched in loader
+		FirstSeenBlockNumber:     logRaw.BlockNumber, // Earliest kept in loader
+		FirstSeenTimestamp:       logRaw.BlockTimestamp,
+		FirstSeenTransactionHash: logRaw.TransactionHash,
+		LastActiveBlockNumber:    logRaw.BlockNumber, // Latest kept in loader
+		LastActiveTimestamp:      logRaw.BlockTimestamp,
 	}
 }
 
-func transformLogRawToAddressToken(logRaw *models.LogRaw, useFromAddress bool) *models.AddressToken {
+func transformLogRawToAddressToken(logRaw *models.LogRaw, indexed []string, useFromAddress bool) *models.AddressToken {
 
-	var indexed []string
-	err := json.Unmarshal([]byte(logRaw.Indexed), &indexed)
-	if err != nil {
-		zap.S().Fatal("Unable to parse indexed field in log; indexed=", logRaw.Indexed, " error: ", err.Error())
+	if indexed[0] != "Transfer(Address,Address,int,bytes)" || len(indexed) != 4 {
+		// Not token transfer
+		return nil
 	}
 
+	// Public Key
+	publicKey := ""
+	if useFromAddress == true {
+		publicKey = indexed[1]
+	} else {
+		publicKey = indexed[2]
+	}
+
+	return &models.AddressToken{
+		PublicKey:            publicKey,
+		TokenContractAddress: logRaw.Address,
+	}
+}
+
+func transformLogRawToTokenBalance(logRaw *models.LogRaw, indexed []string, useFromAddress bool) *models.TokenBalance {
+
 	if indexed[0] != "Transfer(Address,Address,int,bytes)" || len(indexed) != 4 {
 		// Not token transfer
 		return nil
 	}
 
+	if indexed[1] == indexed[2] {
+		// Transfer to self, no balance change
+		return nil
+	}
+
 	// Public Key
+	// indexed[3] = value
 	publicKey := ""
+	valueChange := ""
 	if useFromAddress == true {
 		publicKey = indexed[1]
+		valueChange = "-" + indexed[3]
 	} else {
 		publicKey = indexed[2]
+		valueChange = indexed[3]
 	}
 
-	return &models.AddressToken{
+	// Token decimals
+	tokenDecimals := getTokenDecimals(logRaw.Address, utils.GetIconNodeClient().GetTokenDecimals, time.Now())
+
+	return &models.TokenBalance{
+		BlockNumber:          logRaw.BlockNumber,
+		TransactionIndex:     logRaw.TransactionIndex,
+		LogIndex:             int32(logRaw.LogIndex),
 		PublicKey:            publicKey,
 		TokenContractAddress: logRaw.Address,
+		ValueChange:          valueChange,
+		Value:                "", // Enriched in loader
+		ValueDecimal:         0,  // Enriched in loader
+		TokenDecimals:        tokenDecimals,
+		Timestamp:            logRaw.BlockTimestamp,
+		BlockHash:            logRaw.BlockHash,
+		TransactionHash:      logRaw.TransactionHash,
 	}
 }
 
-func transformLogRawToTransaction(logRaw *models.LogRaw) *models.Transaction {
+// getTokenDecimals - decimals of a token from the cache or the node
+// NOTE tokens without decimals default to 18 and are retried after TOKEN_DECIMALS_FALLBACK_TTL_SECONDS
+func getTokenDecimals(
+	tokenContractAddress string,
+	getTokenDecimalsFromNode func(tokenContractAddress string) (uint32, error),
+	now time.Time,
+) uint32 {
+
+	cacheEntry, ok := tokenDecimalsCache[tokenContractAddress]
+	if ok == true && (cacheEntry.expiresAt.IsZero() || now.Before(cacheEntry.expiresAt)) {
+		return cacheEntry.decimals
+	}
 
-	var indexed []string
-	err := json.Unmarshal([]byte(logRaw.Indexed), &indexed)
+	tokenDecimals, err := getTokenDecimalsFromNode(tokenContractAddress)
 	if err != nil {
-		zap.S().Fatal("Unable to parse indexed field in log; indexed=", logRaw.Indexed, " error: ", err.Error())
+		// Most IRC2 tokens use 18 decimals
+		zap.S().Warn(
+			"Logs Transformer: Unable to get token decimals,",
+			"TokenContractAddress=", tokenContractAddress,
+			" - Defaulting to 18, error: ", err.Error(),
+		)
+
+		tokenDecimalsCache[tokenContractAddress] = &tokenDecimalsCacheEntry{
+			decimals:  18,
+			expiresAt: now.Add(time.Duration(config.Config.TokenDecimalsFallbackTTLSeconds) * time.Second),
+		}
+		return 18
 	}
 
+	tokenDecimalsCache[tokenContractAddress] = &tokenDecimalsCacheEntry{
+		decimals: tokenDecimals,
+	}
+	return tokenDecimals
+}
+
+func transformLogRawToTransaction(logRaw *models.LogRaw, indexed []string) *models.Transaction {
+
 	method := strings.Split(indexed[0], "(")[0]
 
 	if method != "ICXTransfer" {
@@ -202,6 +423,34 @@ func transformLogRawToTransaction(logRaw *models.LogRaw) *models.Transaction {
 		BlockTimestamp:   logRaw.BlockTimestamp,
 		TransactionFee:   "0x0", // No fees for internal transactions
 		LogIndex:         int32(logRaw.LogIndex),
+		BlockHash:        logRaw.BlockHash,
+	}
+}
+
+// transformLogRawToIScoreClaim - ICX minted to the claimer, a ledger input of the balance builder
+// NOTE claims are not transactions, they are kept out of the transaction history of addresses
+func transformLogRawToIScoreClaim(logRaw *models.LogRaw, indexed []string, data []string) *models.IScoreClaim {
+
+	if isIScoreClaimLog(logRaw, indexed) == false {
+		// Not I-Score claim
+		return nil
+	}
+
+	// indexed[1] = claimer, data[1] = ICX
+	if data[1] == "0x0" {
+		// No value claim
+		return nil
+	}
+
+	return &models.IScoreClaim{
+		TransactionHash:  logRaw.TransactionHash,
+		LogIndex:         int32(logRaw.LogIndex),
+		PublicKey:        indexed[1],
+		Value:            data[1],
+		BlockNumber:      logRaw.BlockNumber,
+		TransactionIndex: logRaw.TransactionIndex,
+		BlockTimestamp:   logRaw.BlockTimestamp,
+		BlockHash:        logRaw.BlockHash,
 	}
 }
 
@@ -223,5 +472,6 @@ func transformLogRawToLogCountByBlockNumber(logRaw *models.LogRaw) *models.LogCo
 		BlockNumber:           logRaw.BlockNumber,
 		Count:                 0, // Adds in loader
 		MaxCountByTransaction: uint32(logRaw.MaxLogIndex),
+		BlockHash:             logRaw.BlockHash,
 	}
 }
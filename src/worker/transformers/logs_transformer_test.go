@@ -0,0 +1,126 @@
+package transformers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/geometry-labs/icon-addresses/config"
+	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/worker/utils"
+)
+
+func TestGetTokenDecimals(t *testing.T) {
+	assert := assert.New(t)
+
+	config.Config.TokenDecimalsFallbackTTLSeconds = 60
+
+	calls := map[string]int{}
+	getTokenDecimalsFromNode := func(tokenContractAddress string) (uint32, error) {
+		calls[tokenContractAddress]++
+		if tokenContractAddress == "cx0000000000000000000000000000000000000bad" {
+			return 0, errors.New("method not found")
+		}
+		return 6, nil
+	}
+	now := time.Now()
+
+	// Node decimals are cached
+	assert.Equal(uint32(6), getTokenDecimals("cx0000000000000000000000000000000000000001", getTokenDecimalsFromNode, now))
+	assert.Equal(uint32(6), getTokenDecimals("cx0000000000000000000000000000000000000001", getTokenDecimalsFromNode, now.Add(time.Hour)))
+	assert.Equal(1, calls["cx0000000000000000000000000000000000000001"])
+
+	// Fallback is cached until it expires
+	assert.Equal(uint32(18), getTokenDecimals("cx0000000000000000000000000000000000000bad", getTokenDecimalsFromNode, now))
+	assert.Equal(uint32(18), getTokenDecimals("cx0000000000000000000000000000000000000bad", getTokenDecimalsFromNode, now.Add(59*time.Second)))
+	assert.Equal(1, calls["cx0000000000000000000000000000000000000bad"])
+
+	assert.Equal(uint32(18), getTokenDecimals("cx0000000000000000000000000000000000000bad", getTokenDecimalsFromNode, now.Add(61*time.Second)))
+	assert.Equal(2, calls["cx0000000000000000000000000000000000000bad"])
+}
+
+func TestTransformLogRawToIScoreClaim(t *testing.T) {
+	assert := assert.New(t)
+
+	logRaw := &models.LogRaw{
+		Address:         utils.IconNodeGovernanceAddress,
+		Indexed:         `["IScoreClaimedV2(Address,int,int)","hx0000000000000000000000000000000000000001"]`,
+		Data:            `["0x3e8","0xde0b6b3a7640000"]`,
+		TransactionHash: "0xaa",
+		BlockNumber:     10,
+		LogIndex:        2,
+		BlockHash:       "0xbb",
+	}
+
+	indexed, err := parseLogRawIndexed(logRaw)
+	assert.Equal(nil, err)
+	data, err := parseLogRawData(logRaw, indexed)
+	assert.Equal(nil, err)
+
+	// Minted to the claimer
+	iScoreClaim := transformLogRawToIScoreClaim(logRaw, indexed, data)
+	assert.NotEqual(nil, iScoreClaim)
+	assert.Equal("0xaa", iScoreClaim.TransactionHash)
+	assert.Equal(int32(2), iScoreClaim.LogIndex)
+	assert.Equal("hx0000000000000000000000000000000000000001", iScoreClaim.PublicKey)
+	assert.Equal("0xde0b6b3a7640000", iScoreClaim.Value)
+	assert.Equal("0xbb", iScoreClaim.BlockHash)
+
+	// Not transactions
+	assert.Equal((*models.Transaction)(nil), transformLogRawToTransaction(logRaw, indexed))
+
+	// No value claim
+	assert.Equal((*models.IScoreClaim)(nil), transformLogRawToIScoreClaim(logRaw, indexed, []string{"0x0", "0x0"}))
+
+	// Claims of other contracts are not loaded
+	logRaw.Address = "cx0000000000000000000000000000000000000001"
+	data, err = parseLogRawData(logRaw, indexed)
+	assert.Equal(nil, err)
+	assert.Equal(0, len(data))
+	assert.Equal((*models.IScoreClaim)(nil), transformLogRawToIScoreClaim(logRaw, indexed, data))
+
+	// Invalid data
+	logRaw.Address = utils.IconNodeGovernanceAddress
+	logRaw.Data = `["0x3e8"]`
+	_, err = parseLogRawData(logRaw, indexed)
+	assert.NotEqual(nil, err)
+}
+
+func TestTransformLogRawToAddressTokenTransfer(t *testing.T) {
+	assert := assert.New(t)
+
+	logRaw := &models.LogRaw{
+		Address:         "cx0000000000000000000000000000000000000001",
+		Indexed:         `["Transfer(Address,Address,int,bytes)","hx0000000000000000000000000000000000000001","cx0000000000000000000000000000000000000002","0x1"]`,
+		TransactionHash: "0xaa",
+		BlockNumber:     10,
+		BlockTimestamp:  1000,
+	}
+	indexed, err := parseLogRawIndexed(logRaw)
+	assert.Equal(nil, err)
+
+	// Token transfers are activity of both addresses
+	fromAddress := transformLogRawToAddress(logRaw, indexed, true)
+	assert.NotEqual(nil, fromAddress)
+	assert.Equal("hx0000000000000000000000000000000000000001", fromAddress.PublicKey)
+	assert.Equal(false, fromAddress.IsContract)
+	assert.Equal(uint64(10), fromAddress.FirstSeenBlockNumber)
+	assert.Equal("0xaa", fromAddress.FirstSeenTransactionHash)
+	assert.Equal(uint64(1000), fromAddress.LastActiveTimestamp)
+
+	toAddress := transformLogRawToAddress(logRaw, indexed, false)
+	assert.NotEqual(nil, toAddress)
+	assert.Equal("cx0000000000000000000000000000000000000002", toAddress.PublicKey)
+	assert.Equal(true, toAddress.IsContract)
+
+	// Not ICX transactions
+	assert.Equal((*models.Transaction)(nil), transformLogRawToTransaction(logRaw, indexed))
+
+	// Other events are not activity
+	logRaw.Indexed = `["Approval(Address,Address,int)","hx0000000000000000000000000000000000000001","cx0000000000000000000000000000000000000002","0x1"]`
+	indexed, err = parseLogRawIndexed(logRaw)
+	assert.Equal(nil, err)
+	assert.Equal((*models.Address)(nil), transformLogRawToAddress(logRaw, indexed, true))
+}
@@ -1,11 +1,12 @@
 package transformers
 
 import (
+	"context"
+
 	"encoding/hex"
 	"fmt"
 	"math/big"
 
-	"github.com/golang/protobuf/proto"
 	"go.uber.org/zap"
 
 	"github.com/geometry-labs/icon-addresses/config"
@@ -13,13 +14,14 @@ import (
 	"github.com/geometry-labs/icon-addresses/kafka"
 	"github.com/geometry-labs/icon-addresses/metrics"
 	"github.com/geometry-labs/icon-addresses/models"
+	"github.com/geometry-labs/icon-addresses/redis"
 )
 
-func StartTransactionsTransformer() {
-	go transactionsTransformer()
+func StartTransactionsTransformer(ctx context.Context) {
+	go transactionsTransformer(ctx)
 }
 
-func transactionsTransformer() {
+func transactionsTransformer(ctx context.Context) {
 	consumerTopicNameTransactions := config.Config.ConsumerTopicTransactions
 
 	// Input channels
@@ -31,6 +33,7 @@ func transactionsTransformer() {
 	transactionLoaderChan := crud.GetTransactionModel().LoaderChannel
 	transactionCountByPublicKeyLoaderChan := crud.GetTransactionCountByPublicKeyModel().LoaderChannel
 	transactionCountByBlockNumberLoaderChan := crud.GetTransactionCountByBlockNumberModel().LoaderChannel
+	dirtyAddresses := redis.GetDirtyAddresses()
 
 	zap.S().Debug("Transactions Transformer: started working")
 
@@ -40,11 +43,32 @@ func transactionsTransformer() {
 		// Kafka Message //
 		///////////////////
 
-		consumerTopicMsg := <-consumerTopicChanTransactions
+		var consumerTopicMsg *kafka.TopicMessage
+		select {
+		case consumerTopicMsg = <-consumerTopicChanTransactions:
+		case <-ctx.Done():
+			zap.S().Info("Transactions Transformer: stopped working")
+			return
+		}
 		transactionRaw, err := convertBytesToTransactionRawProtoBuf(consumerTopicMsg.Value)
 		zap.S().Debug("Transactions Transformer: Processing transaction hash=", transactionRaw.Hash)
 		if err != nil {
-			zap.S().Fatal("Transactions Transformer: Unable to proceed cannot convert kafka msg value to TransactionRaw, err: ", err.Error())
+			kafka.HandleProcessError(consumerTopicMsg, err)
+			continue
+		}
+
+		////////////
+		// Reorgs //
+		////////////
+
+		// NOTE rollbacks wait until the rows of the message are sent
+		crud.RLockRollbacks()
+		if crud.IsOrphanedBlockHash(transactionRaw.BlockHash) == true {
+			// Rolled back
+			crud.RUnlockRollbacks()
+			zap.S().Debug("Transactions Transformer: Skipping message of orphaned block #", transactionRaw.BlockNumber)
+			consumerTopicMsg.Processed()
+			continue
 		}
 
 		/////////////
@@ -54,24 +78,28 @@ func transactionsTransformer() {
 		// Loads to addresses (from address)
 		fromAddress := transformTransactionRawToAddress(transactionRaw, true)
 		if fromAddress != nil {
+			crud.SetLoaderAck(fromAddress, consumerTopicMsg.Track())
 			addressLoaderChan <- fromAddress
 		}
 
 		// Loads to addresses (to address)
 		toAddress := transformTransactionRawToAddress(transactionRaw, false)
 		if toAddress != nil {
+			crud.SetLoaderAck(toAddress, consumerTopicMsg.Track())
 			addressLoaderChan <- toAddress
 		}
 
 		// Loads to addresses_count (from address) (all)
 		if fromAddress != nil {
 			fromAddressCount := transformAddressToAddressCountAll(fromAddress)
+			crud.SetLoaderAck(fromAddressCount, consumerTopicMsg.Track())
 			addressCountLoaderChan <- fromAddressCount
 		}
 
 		// Loads to addresses_count (to address) (all)
 		if toAddress != nil {
 			toAddressCount := transformAddressToAddressCountAll(toAddress)
+			crud.SetLoaderAck(toAddressCount, consumerTopicMsg.Track())
 			addressCountLoaderChan <- toAddressCount
 		}
 
@@ -79,6 +107,7 @@ func transactionsTransformer() {
 		if fromAddress != nil {
 			fromAddressCountContract := transformAddressToAddressCountContract(fromAddress)
 			if fromAddressCountContract != nil {
+				crud.SetLoaderAck(fromAddressCountContract, consumerTopicMsg.Track())
 				addressCountLoaderChan <- fromAddressCountContract
 			}
 		}
@@ -87,6 +116,7 @@ func transactionsTransformer() {
 		if toAddress != nil {
 			toAddressCountContract := transformAddressToAddressCountContract(toAddress)
 			if toAddressCountContract != nil {
+				crud.SetLoaderAck(toAddressCountContract, consumerTopicMsg.Track())
 				addressCountLoaderChan <- toAddressCountContract
 			}
 		}
@@ -94,24 +124,44 @@ func transactionsTransformer() {
 		// Loads to transactions
 		transaction := transformTransactionRawToTransaction(transactionRaw)
 		if transaction != nil {
+			crud.SetLoaderAck(transaction, consumerTopicMsg.Track())
 			transactionLoaderChan <- transaction
 		}
 
 		// Loads to transaction_count_by_public_key (from address)
 		transactionCountByPublicKeyFromAddress := transformTransactionRawToTransactionCountByPublicKey(transactionRaw, true)
 		if transactionCountByPublicKeyFromAddress != nil {
+			crud.SetLoaderAck(transactionCountByPublicKeyFromAddress, consumerTopicMsg.Track())
 			transactionCountByPublicKeyLoaderChan <- transactionCountByPublicKeyFromAddress
 		}
 
 		// Loads to transaction_count_by_public_key (to address)
 		transactionCountByPublicKeyToAddress := transformTransactionRawToTransactionCountByPublicKey(transactionRaw, false)
 		if transactionCountByPublicKeyToAddress != nil {
+			crud.SetLoaderAck(transactionCountByPublicKeyToAddress, consumerTopicMsg.Track())
 			transactionCountByPublicKeyLoaderChan <- transactionCountByPublicKeyToAddress
 		}
 
 		// Loads to transaction_count_by_block_number
 		transactionCountByBlockNumber := transformTransactionRawTransactionCountByBlockNumber(transactionRaw)
+		crud.SetLoaderAck(transactionCountByBlockNumber, consumerTopicMsg.Track())
 		transactionCountByBlockNumberLoaderChan <- transactionCountByBlockNumber
+		crud.RUnlockRollbacks()
+
+		///////////////////////////
+		// Balance refresh queue //
+		///////////////////////////
+
+		// Fee and value
+		if fromAddress != nil {
+			dirtyAddresses.Add(fromAddress.PublicKey)
+		}
+		if toAddress != nil {
+			dirtyAddresses.Add(toAddress.PublicKey)
+		}
+
+		// Offset is marked once loads are persisted
+		consumerTopicMsg.Processed()
 
 		/////////////
 		// Metrics //
@@ -122,10 +172,10 @@ func transactionsTransformer() {
 
 func convertBytesToTransactionRawProtoBuf(value []byte) (*models.TransactionRaw, error) {
 	tx := models.TransactionRaw{}
-	err := proto.Unmarshal(value[6:], &tx)
+	err := kafka.GetDecoder(config.Config.ConsumerTopicTransactions).Decode(value, &tx)
 	if err != nil {
 		zap.S().Error("Error: ", err.Error())
-		zap.S().Error("Value=", hex.Dump(value[6:]))
+		zap.S().Error("Value=", hex.Dump(value))
 	}
 	return &tx, err
 }
@@ -151,11 +201,16 @@ func transformTransactionRawToAddress(txRaw *models.TransactionRaw, useFromAddre
 	}
 
 	return &models.Address{
-		PublicKey:        publicKey,
-		IsContract:       isContract,
-		TransactionCount: 0, // Enriched in loader
-		LogCount:         0, // Enriched in loader
-		Balance:          0, // Enriched in loader
+		PublicKey:                publicKey,
+		IsContract:               isContract,
+		TransactionCount:         0,                 // Enriched in loader
+		LogCount:                 0,                 // Enriched in loader
+		Balance:                  0,                 // Enriched in loader
+		FirstSeenBlockNumber:     txRaw.BlockNumber, // Earliest kept in loader
+		FirstSeenTimestamp:       txRaw.BlockTimestamp,
+		FirstSeenTransactionHash: txRaw.Hash,
+		LastActiveBlockNumber:    txRaw.BlockNumber, // Latest kept in loader
+		LastActiveTimestamp:      txRaw.BlockTimestamp,
 	}
 }
 
@@ -208,6 +263,7 @@ func transformTransactionRawToTransaction(txRaw *models.TransactionRaw) *models.
 		BlockTimestamp:   txRaw.BlockTimestamp,
 		TransactionFee:   transactionFee,
 		LogIndex:         -1,
+		BlockHash:        txRaw.BlockHash,
 	}
 }
 
@@ -238,5 +294,6 @@ func transformTransactionRawTransactionCountByBlockNumber(txRaw *models.Transact
 		BlockNumber:     txRaw.BlockNumber,
 		TransactionHash: txRaw.Hash,
 		Count:           0, // Adds in loader
+		BlockHash:       txRaw.BlockHash,
 	}
 }
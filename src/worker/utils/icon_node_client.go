@@ -0,0 +1,592 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+
+	"github.com/geometry-labs/icon-addresses/config"
+)
+
+// IconNodeGovernanceAddress - chain score contract, emits the I-Score claim logs
+const IconNodeGovernanceAddress = "cx0000000000000000000000000000000000000000"
+
+// IconNodeClient - reads chain state from ICON nodes
+type IconNodeClient interface {
+	// Batch - send requests in JSON-RPC batches, responses are in request order
+	Batch(requests []*IconNodeRequest) ([]*IconNodeResponse, error)
+
+	// GetBalance - balance of an address in loop, hex
+	GetBalance(publicKey string) (string, error)
+
+	// GetStakedBalance - staked balance of an address in loop, hex
+	GetStakedBalance(publicKey string) (string, error)
+
+	// GetAddressBalances - balances and staked balances of many addresses
+	// Addresses with node errors are left out
+	GetAddressBalances(publicKeys []string) (map[string]*IconNodeAddressBalance, error)
+
+	// GetAddressBalancesAtBlockNumber - balances and staked balances of many addresses after a block
+	// Addresses with node errors are left out
+	GetAddressBalancesAtBlockNumber(publicKeys []string, blockNumber uint64) (map[string]*IconNodeAddressBalance, error)
+
+	// GetAddressStakings - delegated and bonded balances and unclaimed I-Score of many addresses
+	// Addresses with node errors are left out
+	GetAddressStakings(publicKeys []string) (map[string]*IconNodeAddressStaking, error)
+
+	// GetTokenDecimals - decimals of an IRC2 token contract
+	GetTokenDecimals(tokenContractAddress string) (uint32, error)
+}
+
+// IconNodeAddressBalance - balances of an address in loop, hex
+type IconNodeAddressBalance struct {
+	PublicKey        string
+	Balance          string
+	StakedBalance    string
+	UnstakingBalance string // Sum of unstakes
+	Unstakes         []*IconNodeUnstake
+}
+
+// IconNodeUnstake - pending unstake from the getStake unstakes list
+type IconNodeUnstake struct {
+	Value             string `json:"unstake"`            // loop, hex
+	UnlockBlockHeight string `json:"unstakeBlockHeight"` // hex
+}
+
+// IconNodeAddressStaking - staking of an address, hex
+type IconNodeAddressStaking struct {
+	PublicKey        string
+	DelegatedBalance string // loop
+	BondedBalance    string // loop
+	IScore           string // unclaimed, 1000 per ICX
+}
+
+// IconNodeRequest - JSON-RPC 2.0 request
+type IconNodeRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      uint64      `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// IconNodeResponse - JSON-RPC 2.0 response
+type IconNodeResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *IconNodeError  `json:"error,omitempty"`
+}
+
+// IconNodeError - JSON-RPC 2.0 error object
+type IconNodeError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *IconNodeError) Error() string {
+	return "IconNodeError: Code=" + strconv.Itoa(e.Code) + ",Message=" + e.Message
+}
+
+// IconNodeGetBalanceParams - icx_getBalance params
+type IconNodeGetBalanceParams struct {
+	Address string `json:"address"`
+	Height  string `json:"height,omitempty"` // hex block number, latest if empty
+}
+
+// IconNodeCallParams - icx_call params
+type IconNodeCallParams struct {
+	To       string           `json:"to"`
+	DataType string           `json:"dataType"`
+	Data     IconNodeCallData `json:"data"`
+	Height   string           `json:"height,omitempty"` // hex block number, latest if empty
+}
+
+// IconNodeCallData - score method and params of an icx_call
+type IconNodeCallData struct {
+	Method string            `json:"method"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// NewIconNodeGetBalanceRequest - icx_getBalance request
+// height is a hex block number, latest if empty
+func NewIconNodeGetBalanceRequest(publicKey string, height string) *IconNodeRequest {
+	return &IconNodeRequest{
+		Method: "icx_getBalance",
+		Params: &IconNodeGetBalanceParams{
+			Address: publicKey,
+			Height:  height,
+		},
+	}
+}
+
+// NewIconNodeCallRequest - icx_call request
+// height is a hex block number, latest if empty
+func NewIconNodeCallRequest(to string, method string, params map[string]string, height string) *IconNodeRequest {
+	return &IconNodeRequest{
+		Method: "icx_call",
+		Params: &IconNodeCallParams{
+			To:       to,
+			DataType: "call",
+			Data: IconNodeCallData{
+				Method: method,
+				Params: params,
+			},
+			Height: height,
+		},
+	}
+}
+
+// IconNodeClientConfig - settings of a JSON-RPC client
+type IconNodeClientConfig struct {
+	URLs       []string
+	Timeout    time.Duration // per http request
+	MaxRetries uint64        // per batch, across all urls
+	BackoffMin time.Duration
+	BackoffMax time.Duration
+	RateLimit  float64 // http requests per second, 0 for no limit
+	BatchSize  int     // requests per http request
+}
+
+// jsonRPCIconNodeClient - IconNodeClient over JSON-RPC 2.0 http
+type jsonRPCIconNodeClient struct {
+	config     IconNodeClientConfig
+	httpClient *http.Client
+
+	// Failover
+	urlIndex     int
+	urlIndexLock sync.Mutex
+
+	// Rate limit
+	nextRequestTime     time.Time
+	nextRequestTimeLock sync.Mutex
+}
+
+var iconNodeClient IconNodeClient
+var iconNodeClientOnce sync.Once
+
+// GetIconNodeClient - create and/or return the icon node client from config
+func GetIconNodeClient() IconNodeClient {
+	iconNodeClientOnce.Do(func() {
+		urls := []string{}
+		for _, url := range strings.Split(config.Config.IconNodeServiceURLs, ",") {
+			url = strings.TrimSpace(url)
+			if url != "" {
+				urls = append(urls, url)
+			}
+		}
+		if len(urls) == 0 {
+			urls = append(urls, config.Config.IconNodeServiceURL)
+		}
+
+		iconNodeClient = NewIconNodeClient(IconNodeClientConfig{
+			URLs:       urls,
+			Timeout:    time.Duration(config.Config.IconNodeServiceTimeoutMilli) * time.Millisecond,
+			MaxRetries: uint64(config.Config.IconNodeServiceMaxRetries),
+			BackoffMin: time.Duration(config.Config.IconNodeServiceBackoffMinMilli) * time.Millisecond,
+			BackoffMax: time.Duration(config.Config.IconNodeServiceBackoffMaxMilli) * time.Millisecond,
+			RateLimit:  config.Config.IconNodeServiceRateLimit,
+			BatchSize:  config.Config.IconNodeServiceBatchSize,
+		})
+	})
+
+	return iconNodeClient
+}
+
+// NewIconNodeClient - create a JSON-RPC icon node client
+func NewIconNodeClient(clientConfig IconNodeClientConfig) IconNodeClient {
+	if clientConfig.BatchSize < 1 {
+		clientConfig.BatchSize = 1
+	}
+
+	return &jsonRPCIconNodeClient{
+		config: clientConfig,
+		httpClient: &http.Client{
+			Timeout: clientConfig.Timeout,
+		},
+	}
+}
+
+func (c *jsonRPCIconNodeClient) Batch(requests []*IconNodeRequest) ([]*IconNodeResponse, error) {
+	responses := []*IconNodeResponse{}
+
+	for start := 0; start < len(requests); start += c.config.BatchSize {
+		end := start + c.config.BatchSize
+		if end > len(requests) {
+			end = len(requests)
+		}
+
+		batchResponses, err := c.sendBatch(requests[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		responses = append(responses, batchResponses...)
+	}
+
+	return responses, nil
+}
+
+func (c *jsonRPCIconNodeClient) GetBalance(publicKey string) (string, error) {
+	return c.callString(NewIconNodeGetBalanceRequest(publicKey, ""))
+}
+
+func (c *jsonRPCIconNodeClient) GetStakedBalance(publicKey string) (string, error) {
+	responses, err := c.Batch([]*IconNodeRequest{
+		NewIconNodeCallRequest(IconNodeGovernanceAddress, "getStake", map[string]string{"address": publicKey}, ""),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	stake, err := parseIconNodeStake(responses[0])
+	if err != nil {
+		return "", err
+	}
+
+	return stake.Stake, nil
+}
+
+func (c *jsonRPCIconNodeClient) GetAddressBalances(publicKeys []string) (map[string]*IconNodeAddressBalance, error) {
+	return c.getAddressBalances(publicKeys, "")
+}
+
+func (c *jsonRPCIconNodeClient) GetAddressBalancesAtBlockNumber(publicKeys []string, blockNumber uint64) (map[string]*IconNodeAddressBalance, error) {
+	return c.getAddressBalances(publicKeys, fmt.Sprintf("0x%x", blockNumber))
+}
+
+func (c *jsonRPCIconNodeClient) getAddressBalances(publicKeys []string, height string) (map[string]*IconNodeAddressBalance, error) {
+
+	// Balance and stake request for each address
+	requests := []*IconNodeRequest{}
+	for _, publicKey := range publicKeys {
+		requests = append(
+			requests,
+			NewIconNodeGetBalanceRequest(publicKey, height),
+			NewIconNodeCallRequest(IconNodeGovernanceAddress, "getStake", map[string]string{"address": publicKey}, height),
+		)
+	}
+
+	responses, err := c.Batch(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	addressBalances := map[string]*IconNodeAddressBalance{}
+	for i, publicKey := range publicKeys {
+		balance, err := parseIconNodeString(responses[2*i])
+		if err == nil {
+			var stake *iconNodeStake
+			stake, err = parseIconNodeStake(responses[2*i+1])
+			if err == nil {
+				unstakingBalance := big.NewInt(0)
+				for _, unstake := range stake.Unstakes {
+					unstakingBalance.Add(unstakingBalance, StringHexToBigInt(unstake.Value))
+				}
+
+				addressBalances[publicKey] = &IconNodeAddressBalance{
+					PublicKey:        publicKey,
+					Balance:          balance,
+					StakedBalance:    stake.Stake,
+					UnstakingBalance: fmt.Sprintf("0x%x", unstakingBalance),
+					Unstakes:         stake.Unstakes,
+				}
+				continue
+			}
+		}
+
+		zap.S().Warn("IconNodeClient: PublicKey=", publicKey, " - Error: ", err.Error())
+	}
+
+	return addressBalances, nil
+}
+
+func (c *jsonRPCIconNodeClient) GetAddressStakings(publicKeys []string) (map[string]*IconNodeAddressStaking, error) {
+
+	// Delegation, bond and I-Score request for each address
+	requests := []*IconNodeRequest{}
+	for _, publicKey := range publicKeys {
+		params := map[string]string{"address": publicKey}
+		requests = append(
+			requests,
+			NewIconNodeCallRequest(IconNodeGovernanceAddress, "getDelegation", params, ""),
+			NewIconNodeCallRequest(IconNodeGovernanceAddress, "getBond", params, ""),
+			NewIconNodeCallRequest(IconNodeGovernanceAddress, "queryIScore", params, ""),
+		)
+	}
+
+	responses, err := c.Batch(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	addressStakings := map[string]*IconNodeAddressStaking{}
+	for i, publicKey := range publicKeys {
+		delegation := struct {
+			TotalDelegated string `json:"totalDelegated"`
+		}{}
+		bond := struct {
+			TotalBonded string `json:"totalBonded"`
+		}{}
+		iScore := struct {
+			IScore string `json:"iscore"`
+		}{}
+
+		err := parseIconNodeObject(responses[3*i], &delegation)
+		if err == nil {
+			err = parseIconNodeObject(responses[3*i+1], &bond)
+		}
+		if err == nil {
+			err = parseIconNodeObject(responses[3*i+2], &iScore)
+		}
+		if err == nil && (delegation.TotalDelegated == "" || iScore.IScore == "") {
+			err = errors.New("Invalid response")
+		}
+		if err != nil {
+			zap.S().Warn("IconNodeClient: PublicKey=", publicKey, " - Error: ", err.Error())
+			continue
+		}
+
+		// No bonds
+		if bond.TotalBonded == "" {
+			bond.TotalBonded = "0x0"
+		}
+
+		addressStakings[publicKey] = &IconNodeAddressStaking{
+			PublicKey:        publicKey,
+			DelegatedBalance: delegation.TotalDelegated,
+			BondedBalance:    bond.TotalBonded,
+			IScore:           iScore.IScore,
+		}
+	}
+
+	return addressStakings, nil
+}
+
+func (c *jsonRPCIconNodeClient) GetTokenDecimals(tokenContractAddress string) (uint32, error) {
+	decimalsHex, err := c.callString(NewIconNodeCallRequest(tokenContractAddress, "decimals", nil, ""))
+	if err != nil {
+		return 0, err
+	}
+	if len(decimalsHex) < 3 {
+		return 0, errors.New("Invalid response")
+	}
+
+	decimals, err := strconv.ParseUint(decimalsHex[2:], 16, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(decimals), nil
+}
+
+func (c *jsonRPCIconNodeClient) callString(request *IconNodeRequest) (string, error) {
+	responses, err := c.Batch([]*IconNodeRequest{request})
+	if err != nil {
+		return "", err
+	}
+
+	return parseIconNodeString(responses[0])
+}
+
+// sendBatch - send one JSON-RPC batch with retries and failover
+func (c *jsonRPCIconNodeClient) sendBatch(requests []*IconNodeRequest) ([]*IconNodeResponse, error) {
+
+	// Ids match responses to requests
+	batchRequests := make([]IconNodeRequest, len(requests))
+	for i, request := range requests {
+		batchRequests[i] = *request
+		batchRequests[i].JSONRPC = "2.0"
+		batchRequests[i].ID = uint64(i + 1)
+	}
+
+	payload, err := json.Marshal(batchRequests)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses []*IconNodeResponse
+	operation := func() error {
+		var err error
+		responses, err = c.post(payload, len(requests))
+		return err
+	}
+
+	notify := func(err error, wait time.Duration) {
+		zap.S().Warn("IconNodeClient: Retrying in ", wait, " - Error: ", err.Error())
+
+		// Failover
+		c.nextURL()
+	}
+
+	err = backoff.RetryNotify(operation, backoff.WithMaxRetries(c.newBackOff(), c.config.MaxRetries), notify)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// post - send a batch payload to the current url
+// Returns a backoff.PermanentError if retrying will not help
+func (c *jsonRPCIconNodeClient) post(payload []byte, numRequests int) ([]*IconNodeResponse, error) {
+	c.waitRateLimit()
+
+	url := c.getURL()
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, backoff.Permanent(err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	// Execute request
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	// Read body
+	bodyString, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check status code
+	if res.StatusCode != 200 {
+		err = errors.New(
+			"URL=" + url +
+				",StatusCode=" + strconv.Itoa(res.StatusCode) +
+				",Response=" + string(bodyString),
+		)
+		if res.StatusCode >= 400 && res.StatusCode < 500 && res.StatusCode != 429 {
+			return nil, backoff.Permanent(err)
+		}
+		return nil, err
+	}
+
+	// Parse body
+	batchResponses := []*IconNodeResponse{}
+	err = json.Unmarshal(bodyString, &batchResponses)
+	if err != nil {
+		return nil, fmt.Errorf("URL=%s,Response=%s - Invalid batch response: %s", url, string(bodyString), err.Error())
+	}
+
+	// Order by id
+	responses := make([]*IconNodeResponse, numRequests)
+	for _, response := range batchResponses {
+		if response.ID < 1 || response.ID > uint64(numRequests) {
+			continue
+		}
+
+		responses[response.ID-1] = response
+	}
+	for i, response := range responses {
+		if response == nil {
+			return nil, fmt.Errorf("URL=%s - Missing response for request %d", url, i+1)
+		}
+	}
+
+	return responses, nil
+}
+
+func (c *jsonRPCIconNodeClient) newBackOff() backoff.BackOff {
+	exponentialBackOff := backoff.NewExponentialBackOff()
+	exponentialBackOff.InitialInterval = c.config.BackoffMin
+	exponentialBackOff.MaxInterval = c.config.BackoffMax
+	exponentialBackOff.MaxElapsedTime = 0 // Bounded by MaxRetries
+
+	return exponentialBackOff
+}
+
+func (c *jsonRPCIconNodeClient) getURL() string {
+	c.urlIndexLock.Lock()
+	defer c.urlIndexLock.Unlock()
+
+	return c.config.URLs[c.urlIndex]
+}
+
+func (c *jsonRPCIconNodeClient) nextURL() {
+	c.urlIndexLock.Lock()
+	defer c.urlIndexLock.Unlock()
+
+	c.urlIndex = (c.urlIndex + 1) % len(c.config.URLs)
+}
+
+// waitRateLimit - space http requests evenly at the rate limit
+func (c *jsonRPCIconNodeClient) waitRateLimit() {
+	if c.config.RateLimit <= 0 {
+		return
+	}
+
+	interval := time.Duration(float64(time.Second) / c.config.RateLimit)
+
+	c.nextRequestTimeLock.Lock()
+	now := time.Now()
+	if c.nextRequestTime.Before(now) {
+		c.nextRequestTime = now
+	}
+	wait := c.nextRequestTime.Sub(now)
+	c.nextRequestTime = c.nextRequestTime.Add(interval)
+	c.nextRequestTimeLock.Unlock()
+
+	time.Sleep(wait)
+}
+
+func parseIconNodeString(response *IconNodeResponse) (string, error) {
+	if response.Error != nil {
+		return "", response.Error
+	}
+
+	result := ""
+	err := json.Unmarshal(response.Result, &result)
+	if err != nil {
+		return "", errors.New("Invalid response")
+	}
+
+	return result, nil
+}
+
+// iconNodeStake - getStake result
+type iconNodeStake struct {
+	Stake    string             `json:"stake"`
+	Unstakes []*IconNodeUnstake `json:"unstakes"`
+}
+
+func parseIconNodeStake(response *IconNodeResponse) (*iconNodeStake, error) {
+	stake := &iconNodeStake{}
+	err := parseIconNodeObject(response, stake)
+	if err != nil {
+		return nil, err
+	}
+	if stake.Stake == "" {
+		return nil, errors.New("Invalid response")
+	}
+
+	return stake, nil
+}
+
+// parseIconNodeObject - unmarshal an object result
+func parseIconNodeObject(response *IconNodeResponse, result interface{}) error {
+	if response.Error != nil {
+		return response.Error
+	}
+
+	err := json.Unmarshal(response.Result, result)
+	if err != nil {
+		return errors.New("Invalid response")
+	}
+
+	return nil
+}
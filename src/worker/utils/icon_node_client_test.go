@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIconNodeClient(urls ...string) IconNodeClient {
+	return NewIconNodeClient(IconNodeClientConfig{
+		URLs:       urls,
+		Timeout:    100 * time.Millisecond,
+		MaxRetries: 3,
+		BackoffMin: time.Millisecond,
+		BackoffMax: 5 * time.Millisecond,
+		BatchSize:  3,
+	})
+}
+
+func TestIconNodeClientGetBalance(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewFakeIconNodeServer()
+	defer server.Close()
+	server.SetBalance("hx54f7853dc6481b670caf69c5a27c7c8fe5be8269", "0x2961fff8ca4a62327800000")
+	server.SetStakedBalance("hx9d9ad1bc19319bd5cdb5516773c0e376db83b644", "0xde0b6b3a7640000")
+	server.SetTokenDecimals("cx2609b924e33ef00b648a409245c7ea394c467824", "0x12")
+
+	client := newTestIconNodeClient(server.URL())
+
+	balance, err := client.GetBalance("hx54f7853dc6481b670caf69c5a27c7c8fe5be8269")
+	assert.Equal(nil, err)
+	assert.Equal("0x2961fff8ca4a62327800000", balance)
+
+	stakedBalance, err := client.GetStakedBalance("hx9d9ad1bc19319bd5cdb5516773c0e376db83b644")
+	assert.Equal(nil, err)
+	assert.Equal("0xde0b6b3a7640000", stakedBalance)
+
+	decimals, err := client.GetTokenDecimals("cx2609b924e33ef00b648a409245c7ea394c467824")
+	assert.Equal(nil, err)
+	assert.Equal(uint32(18), decimals)
+
+	// JSON-RPC errors are not retried
+	_, err = client.GetTokenDecimals("cx0000000000000000000000000000000000000001")
+	assert.IsType(&IconNodeError{}, err)
+	assert.Equal(4, server.NumRequests())
+}
+
+func TestIconNodeClientGetAddressBalances(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewFakeIconNodeServer()
+	defer server.Close()
+	server.SetBalance("hx1", "0x1")
+	server.SetStakedBalance("hx1", "0x2")
+	server.SetBalance("hx2", "0x3")
+
+	client := newTestIconNodeClient(server.URL())
+
+	addressBalances, err := client.GetAddressBalances([]string{"hx1", "hx2"})
+	assert.Equal(nil, err)
+	assert.Equal(2, len(addressBalances))
+	assert.Equal(&IconNodeAddressBalance{PublicKey: "hx1", Balance: "0x1", StakedBalance: "0x2", UnstakingBalance: "0x0", Unstakes: []*IconNodeUnstake{}}, addressBalances["hx1"])
+	assert.Equal(&IconNodeAddressBalance{PublicKey: "hx2", Balance: "0x3", StakedBalance: "0x0", UnstakingBalance: "0x0", Unstakes: []*IconNodeUnstake{}}, addressBalances["hx2"])
+
+	// 4 requests in batches of 3
+	assert.Equal(2, server.NumRequests())
+}
+
+func TestIconNodeClientGetAddressBalancesAtBlockNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewFakeIconNodeServer()
+	defer server.Close()
+	server.SetBalance("hx1", "0x1")
+	server.SetBalanceAtBlockNumber("hx1", 100, "0x5")
+	server.SetStakedBalanceAtBlockNumber("hx1", 200, "0x2")
+
+	client := newTestIconNodeClient(server.URL())
+
+	addressBalances, err := client.GetAddressBalancesAtBlockNumber([]string{"hx1"}, 99)
+	assert.Equal(nil, err)
+	assert.Equal(&IconNodeAddressBalance{PublicKey: "hx1", Balance: "0x1", StakedBalance: "0x0", UnstakingBalance: "0x0", Unstakes: []*IconNodeUnstake{}}, addressBalances["hx1"])
+
+	addressBalances, err = client.GetAddressBalancesAtBlockNumber([]string{"hx1"}, 150)
+	assert.Equal(nil, err)
+	assert.Equal(&IconNodeAddressBalance{PublicKey: "hx1", Balance: "0x5", StakedBalance: "0x0", UnstakingBalance: "0x0", Unstakes: []*IconNodeUnstake{}}, addressBalances["hx1"])
+
+	// Latest
+	addressBalances, err = client.GetAddressBalances([]string{"hx1"})
+	assert.Equal(nil, err)
+	assert.Equal(&IconNodeAddressBalance{PublicKey: "hx1", Balance: "0x5", StakedBalance: "0x2", UnstakingBalance: "0x0", Unstakes: []*IconNodeUnstake{}}, addressBalances["hx1"])
+}
+
+func TestIconNodeClientGetAddressStakings(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewFakeIconNodeServer()
+	defer server.Close()
+	server.SetStakedBalance("hx1", "0x10")
+	server.SetUnstakes("hx1", []*IconNodeUnstake{
+		{Value: "0x2", UnlockBlockHeight: "0x64"},
+		{Value: "0x3", UnlockBlockHeight: "0xc8"},
+	})
+	server.SetStaking("hx1", "0x8", "0x4", "0x3e8")
+
+	client := newTestIconNodeClient(server.URL())
+
+	addressBalances, err := client.GetAddressBalances([]string{"hx1"})
+	assert.Equal(nil, err)
+	assert.Equal("0x10", addressBalances["hx1"].StakedBalance)
+	assert.Equal("0x5", addressBalances["hx1"].UnstakingBalance)
+	assert.Equal(2, len(addressBalances["hx1"].Unstakes))
+	assert.Equal("0xc8", addressBalances["hx1"].Unstakes[1].UnlockBlockHeight)
+
+	addressStakings, err := client.GetAddressStakings([]string{"hx1", "hx2"})
+	assert.Equal(nil, err)
+	assert.Equal(&IconNodeAddressStaking{PublicKey: "hx1", DelegatedBalance: "0x8", BondedBalance: "0x4", IScore: "0x3e8"}, addressStakings["hx1"])
+	assert.Equal(&IconNodeAddressStaking{PublicKey: "hx2", DelegatedBalance: "0x0", BondedBalance: "0x0", IScore: "0x0"}, addressStakings["hx2"])
+}
+
+func TestIconNodeClientRetries(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewFakeIconNodeServer()
+	defer server.Close()
+	server.SetBalance("hx1", "0x1")
+
+	client := newTestIconNodeClient(server.URL())
+
+	// Retry server errors
+	server.FailRequests(2, 503)
+	balance, err := client.GetBalance("hx1")
+	assert.Equal(nil, err)
+	assert.Equal("0x1", balance)
+	assert.Equal(3, server.NumRequests())
+
+	// Give up after max retries
+	server.FailRequests(10, 503)
+	_, err = client.GetBalance("hx1")
+	assert.NotEqual(nil, err)
+	assert.Equal(7, server.NumRequests())
+
+	// Client errors are not retried
+	server.FailRequests(1, 400)
+	_, err = client.GetBalance("hx1")
+	assert.NotEqual(nil, err)
+	assert.Equal(8, server.NumRequests())
+}
+
+func TestIconNodeClientFailover(t *testing.T) {
+	assert := assert.New(t)
+
+	downServer := NewFakeIconNodeServer()
+	defer downServer.Close()
+	downServer.FailRequests(10, 502)
+
+	slowServer := NewFakeIconNodeServer()
+	defer slowServer.Close()
+	slowServer.SetDelay(300 * time.Millisecond)
+
+	server := NewFakeIconNodeServer()
+	defer server.Close()
+	server.SetBalance("hx1", "0x1")
+
+	client := newTestIconNodeClient(downServer.URL(), slowServer.URL(), server.URL())
+
+	// Fail over from an erroring and a timed out node
+	balance, err := client.GetBalance("hx1")
+	assert.Equal(nil, err)
+	assert.Equal("0x1", balance)
+	assert.Equal(1, downServer.NumRequests())
+	assert.Equal(1, slowServer.NumRequests())
+
+	// Stay on the working node
+	_, err = client.GetBalance("hx1")
+	assert.Equal(nil, err)
+	assert.Equal(2, server.NumRequests())
+}
+
+func TestIconNodeClientRateLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	server := NewFakeIconNodeServer()
+	defer server.Close()
+
+	client := NewIconNodeClient(IconNodeClientConfig{
+		URLs:      []string{server.URL()},
+		Timeout:   time.Second,
+		RateLimit: 20,
+		BatchSize: 1,
+	})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := client.GetBalance("hx1")
+		assert.Equal(nil, err)
+	}
+
+	// 5 requests at 20 per second
+	assert.True(time.Since(start) >= 200*time.Millisecond)
+}
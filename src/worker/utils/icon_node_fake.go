@@ -0,0 +1,311 @@
+package utils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// FakeIconNodeServer - in-process ICON node for tests
+// Serves icx_getBalance and the getStake, getDelegation, getBond, queryIScore and decimals icx_calls,
+// single or batched, at a height or latest
+type FakeIconNodeServer struct {
+	server *httptest.Server
+
+	lock           sync.Mutex
+	balances       map[string]map[uint64]string       // public key -> block number -> hex
+	stakedBalances map[string]map[uint64]string       // public key -> block number -> hex
+	unstakes       map[string][]*IconNodeUnstake      // public key -> latest unstakes
+	stakings       map[string]*IconNodeAddressStaking // public key -> latest staking
+	tokenDecimals  map[string]string                  // token contract address -> hex
+	failRequests   int
+	failStatusCode int
+	delay          time.Duration
+	numRequests    int
+}
+
+// NewFakeIconNodeServer - start a fake ICON node, close it with Close
+func NewFakeIconNodeServer() *FakeIconNodeServer {
+	fakeServer := &FakeIconNodeServer{
+		balances:       map[string]map[uint64]string{},
+		stakedBalances: map[string]map[uint64]string{},
+		unstakes:       map[string][]*IconNodeUnstake{},
+		stakings:       map[string]*IconNodeAddressStaking{},
+		tokenDecimals:  map[string]string{},
+	}
+
+	fakeServer.server = httptest.NewServer(http.HandlerFunc(fakeServer.handle))
+
+	return fakeServer
+}
+
+// URL - JSON-RPC url of the server
+func (s *FakeIconNodeServer) URL() string {
+	return s.server.URL + "/api/v3"
+}
+
+// Close - stop the server
+func (s *FakeIconNodeServer) Close() {
+	s.server.Close()
+}
+
+// SetBalance - set the balance of an address in loop, hex
+func (s *FakeIconNodeServer) SetBalance(publicKey string, balance string) {
+	s.SetBalanceAtBlockNumber(publicKey, 0, balance)
+}
+
+// SetBalanceAtBlockNumber - set the balance of an address from a block on, in loop, hex
+func (s *FakeIconNodeServer) SetBalanceAtBlockNumber(publicKey string, blockNumber uint64, balance string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	setFakeIconNodeValue(s.balances, publicKey, blockNumber, balance)
+}
+
+// SetStakedBalance - set the staked balance of an address in loop, hex
+func (s *FakeIconNodeServer) SetStakedBalance(publicKey string, stakedBalance string) {
+	s.SetStakedBalanceAtBlockNumber(publicKey, 0, stakedBalance)
+}
+
+// SetStakedBalanceAtBlockNumber - set the staked balance of an address from a block on, in loop, hex
+func (s *FakeIconNodeServer) SetStakedBalanceAtBlockNumber(publicKey string, blockNumber uint64, stakedBalance string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	setFakeIconNodeValue(s.stakedBalances, publicKey, blockNumber, stakedBalance)
+}
+
+// SetUnstakes - set the latest pending unstakes of an address
+func (s *FakeIconNodeServer) SetUnstakes(publicKey string, unstakes []*IconNodeUnstake) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.unstakes[publicKey] = unstakes
+}
+
+// SetStaking - set the latest delegated and bonded balances and I-Score of an address, hex
+func (s *FakeIconNodeServer) SetStaking(publicKey string, delegatedBalance string, bondedBalance string, iScore string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.stakings[publicKey] = &IconNodeAddressStaking{
+		PublicKey:        publicKey,
+		DelegatedBalance: delegatedBalance,
+		BondedBalance:    bondedBalance,
+		IScore:           iScore,
+	}
+}
+
+// SetTokenDecimals - set the decimals of a token contract, hex
+func (s *FakeIconNodeServer) SetTokenDecimals(tokenContractAddress string, decimals string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.tokenDecimals[tokenContractAddress] = decimals
+}
+
+// FailRequests - respond to the next http requests with a status code
+func (s *FakeIconNodeServer) FailRequests(numRequests int, statusCode int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.failRequests = numRequests
+	s.failStatusCode = statusCode
+}
+
+// SetDelay - wait before responding to each http request
+func (s *FakeIconNodeServer) SetDelay(delay time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.delay = delay
+}
+
+// NumRequests - number of http requests received
+func (s *FakeIconNodeServer) NumRequests() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.numRequests
+}
+
+func (s *FakeIconNodeServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	s.numRequests++
+	delay := s.delay
+	isFailed := s.failRequests > 0
+	if isFailed == true {
+		s.failRequests--
+	}
+	s.lock.Unlock()
+
+	time.Sleep(delay)
+
+	if isFailed == true {
+		w.WriteHeader(s.failStatusCode)
+		w.Write([]byte(`{"error": "fake failure"}`))
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(400)
+		return
+	}
+
+	// Single or batch request
+	requests := []*fakeIconNodeRequest{}
+	isBatch := len(body) > 0 && body[0] == '['
+	if isBatch == true {
+		err = json.Unmarshal(body, &requests)
+	} else {
+		request := &fakeIconNodeRequest{}
+		err = json.Unmarshal(body, request)
+		requests = append(requests, request)
+	}
+	if err != nil {
+		w.WriteHeader(400)
+		return
+	}
+
+	responses := []*IconNodeResponse{}
+	for _, request := range requests {
+		responses = append(responses, s.respond(request))
+	}
+
+	var response []byte
+	if isBatch == true {
+		response, _ = json.Marshal(responses)
+	} else {
+		response, _ = json.Marshal(responses[0])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+type fakeIconNodeRequest struct {
+	ID     uint64 `json:"id"`
+	Method string `json:"method"`
+	Params struct {
+		Address string           `json:"address"`
+		To      string           `json:"to"`
+		Data    IconNodeCallData `json:"data"`
+		Height  string           `json:"height"`
+	} `json:"params"`
+}
+
+func (s *FakeIconNodeServer) respond(request *fakeIconNodeRequest) *IconNodeResponse {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Latest if no height
+	blockNumber := ^uint64(0)
+	if request.Params.Height != "" {
+		var err error
+		blockNumber, err = strconv.ParseUint(request.Params.Height, 0, 64)
+		if err != nil {
+			return &IconNodeResponse{
+				JSONRPC: "2.0",
+				ID:      request.ID,
+				Error:   &IconNodeError{Code: -32602, Message: "Invalid height"},
+			}
+		}
+	}
+
+	var result interface{}
+	switch {
+	case request.Method == "icx_getBalance":
+		result = getFakeIconNodeValue(s.balances, request.Params.Address, blockNumber)
+	case request.Method == "icx_call" && request.Params.Data.Method == "getStake":
+		unstakes := s.unstakes[request.Params.Data.Params["address"]]
+		if unstakes == nil {
+			unstakes = []*IconNodeUnstake{}
+		}
+		result = &iconNodeStake{
+			Stake:    getFakeIconNodeValue(s.stakedBalances, request.Params.Data.Params["address"], blockNumber),
+			Unstakes: unstakes,
+		}
+	case request.Method == "icx_call" && request.Params.Data.Method == "getDelegation":
+		result = map[string]interface{}{
+			"totalDelegated": s.getFakeStaking(request.Params.Data.Params["address"]).DelegatedBalance,
+			"delegations":    []interface{}{},
+		}
+	case request.Method == "icx_call" && request.Params.Data.Method == "getBond":
+		result = map[string]interface{}{
+			"totalBonded": s.getFakeStaking(request.Params.Data.Params["address"]).BondedBalance,
+			"bonds":       []interface{}{},
+		}
+	case request.Method == "icx_call" && request.Params.Data.Method == "queryIScore":
+		result = map[string]string{
+			"iscore": s.getFakeStaking(request.Params.Data.Params["address"]).IScore,
+		}
+	case request.Method == "icx_call" && request.Params.Data.Method == "decimals":
+		decimals, ok := s.tokenDecimals[request.Params.To]
+		if ok == false {
+			return &IconNodeResponse{
+				JSONRPC: "2.0",
+				ID:      request.ID,
+				Error:   &IconNodeError{Code: -32602, Message: "Contract not found"},
+			}
+		}
+		result = decimals
+	default:
+		return &IconNodeResponse{
+			JSONRPC: "2.0",
+			ID:      request.ID,
+			Error:   &IconNodeError{Code: -32601, Message: "Method not found"},
+		}
+	}
+
+	resultBytes, _ := json.Marshal(result)
+	return &IconNodeResponse{
+		JSONRPC: "2.0",
+		ID:      request.ID,
+		Result:  resultBytes,
+	}
+}
+
+// getFakeStaking - staking of an address, zero if not set
+func (s *FakeIconNodeServer) getFakeStaking(publicKey string) *IconNodeAddressStaking {
+	staking, ok := s.stakings[publicKey]
+	if ok == false {
+		return &IconNodeAddressStaking{
+			PublicKey:        publicKey,
+			DelegatedBalance: "0x0",
+			BondedBalance:    "0x0",
+			IScore:           "0x0",
+		}
+	}
+
+	return staking
+}
+
+func setFakeIconNodeValue(values map[string]map[uint64]string, key string, blockNumber uint64, value string) {
+	if _, ok := values[key]; ok == false {
+		values[key] = map[uint64]string{}
+	}
+
+	values[key][blockNumber] = value
+}
+
+// getFakeIconNodeValue - value set at the highest block at or below a block number, 0x0 if none
+func getFakeIconNodeValue(values map[string]map[uint64]string, key string, blockNumber uint64) string {
+	value := "0x0"
+	valueBlockNumber := uint64(0)
+	isFound := false
+
+	for setBlockNumber, setValue := range values[key] {
+		if setBlockNumber <= blockNumber && (isFound == false || setBlockNumber > valueBlockNumber) {
+			value = setValue
+			valueBlockNumber = setBlockNumber
+			isFound = true
+		}
+	}
+
+	return value
+}
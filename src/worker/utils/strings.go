@@ -24,3 +24,13 @@ func StringHexBase18ToFloat64(hex string) float64 {
 
 	return valueDecimal
 }
+
+// StringHexToBigInt - exact value of a hex string, 0 if invalid
+func StringHexToBigInt(hex string) *big.Int {
+	valueBigInt, ok := new(big.Int).SetString(hex, 0)
+	if ok == false {
+		return new(big.Int)
+	}
+
+	return valueBigInt
+}
@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringHexToBigInt(t *testing.T) {
+	assert := assert.New(t)
+
+	// Above 2^53, float64 would round
+	assert.Equal("800460000000000000000000001", StringHexToBigInt("0x2961fff8ca4a62327800001").String())
+	assert.Equal("0", StringHexToBigInt("0x0").String())
+	assert.Equal("0", StringHexToBigInt("not hex").String())
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Balance discrepancies test
+func TestAddressesEndpointBalanceDiscrepancies(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/balance-discrepancies?limit=5")
+	assert.Equal(nil, err)
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		// No discrepancies
+		assert.Equal("0", resp.Header.Get("X-TOTAL-COUNT"))
+		return
+	}
+	assert.Equal(200, resp.StatusCode)
+
+	// Test headers
+	assert.NotEqual("0", resp.Header.Get("X-TOTAL-COUNT"))
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]map[string]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(bodyMap))
+	assert.LessOrEqual(len(bodyMap), 5)
+
+	// Test sort
+	for i := 1; i < len(bodyMap); i++ {
+		assert.LessOrEqual(
+			bodyMap[i-1]["first_block_number"].(float64),
+			bodyMap[i]["first_block_number"].(float64),
+		)
+	}
+
+	// Test invalid limit
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/balance-discrepancies?limit=1000")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+}
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Balance history by address test
+func TestAddressesEndpointBalanceHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	// Get latest address
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?limit=1")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(bodyMap))
+
+	// Get testable address
+	addressPublicKey := bodyMap[0].(map[string]interface{})["public_key"].(string)
+
+	// Test balance history
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/balance-history/" + addressPublicKey)
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	// Test headers
+	assert.NotEqual("0", resp.Header.Get("X-TOTAL-COUNT"))
+
+	// Test point in time
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/balance-history/" + addressPublicKey + "?block_number=100000000")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	// Test invalid params
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/balance-history/" + addressPublicKey + "?block_number=1&timestamp=1")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+}
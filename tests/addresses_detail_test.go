@@ -50,4 +50,37 @@ func TestAddressesEndpointDetail(t *testing.T) {
 	assert.Equal(200, resp.StatusCode)
 
 	defer resp.Body.Close()
+
+	bytes, err = ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	detailsBodyMap := make(map[string]interface{})
+	err = json.Unmarshal(bytes, &detailsBodyMap)
+	assert.Equal(nil, err)
+
+	// Test staking breakdown
+	for _, field := range []string{
+		"available_balance_loop",
+		"staked_balance_loop",
+		"unstaking_balance_loop",
+		"delegated_balance_loop",
+		"bonded_balance_loop",
+		"unclaimed_iscore",
+	} {
+		assert.Contains(detailsBodyMap, field)
+	}
+
+	// Test activity
+	for _, field := range []string{
+		"first_seen_block_number",
+		"first_seen_timestamp",
+		"first_seen_transaction_hash",
+		"last_active_block_number",
+		"last_active_timestamp",
+	} {
+		assert.Contains(detailsBodyMap, field)
+	}
+	assert.IsType([]interface{}{}, detailsBodyMap["unstakes"])
+	assert.IsType("", detailsBodyMap["type"])
+	assert.IsType([]interface{}{}, detailsBodyMap["labels"])
 }
@@ -49,4 +49,27 @@ func TestAddressesEndpointDetailTokens(t *testing.T) {
 	assert.Equal(nil, err)
 
 	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		// No tokens
+		return
+	}
+	assert.Equal(200, resp.StatusCode)
+
+	bytes, err = ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	tokensBodyMap := make([]map[string]interface{}, 0)
+	err = json.Unmarshal(bytes, &tokensBodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(tokensBodyMap))
+
+	// Test fields
+	for _, token := range tokensBodyMap {
+		assert.Contains(token, "token_contract_address")
+		assert.Contains(token, "token_name")
+		assert.Contains(token, "balance")
+		assert.Contains(token, "balance_decimal")
+		assert.Contains(token, "last_updated_block_number")
+	}
 }
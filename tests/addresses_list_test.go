@@ -3,6 +3,7 @@ package tests
 import (
 	"encoding/json"
 	"io/ioutil"
+	"math/big"
 	"net/http"
 	"os"
 	"testing"
@@ -102,3 +103,302 @@ func TestAddressesEndpointListIsContract(t *testing.T) {
 	assert.Equal(nil, err)
 	assert.NotEqual(0, len(bodyMap))
 }
+
+// List sort and filter test
+func TestAddressesEndpointListSortFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?sort=-transaction_count&is_contract=false&min_balance=1")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	// Test headers
+	assert.NotEqual("0", resp.Header.Get("X-TOTAL-COUNT"))
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(bodyMap))
+
+	// Invalid sort
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?sort=name")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+}
+
+// List cursor test
+func TestAddressesEndpointListCursor(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?limit=2")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	// Test headers
+	nextCursor := resp.Header.Get("X-NEXT-CURSOR")
+	assert.NotEqual("", nextCursor)
+	assert.NotEqual("", resp.Header.Get("Link"))
+
+	// Next page
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?limit=2&cursor=" + nextCursor)
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(bodyMap))
+}
+
+// List exact balance test
+func TestAddressesEndpointListBalanceLoop(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?sort=-balance")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]map[string]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(bodyMap))
+
+	// Exact balance is a decimal string, sorted descending
+	var prevBalanceLoop *big.Int
+	for _, address := range bodyMap {
+		balanceLoopString, ok := address["balance_loop"].(string)
+		assert.Equal(true, ok)
+
+		balanceLoop, ok := new(big.Int).SetString(balanceLoopString, 10)
+		assert.Equal(true, ok)
+
+		if prevBalanceLoop != nil {
+			assert.LessOrEqual(balanceLoop.Cmp(prevBalanceLoop), 0)
+		}
+		prevBalanceLoop = balanceLoop
+	}
+}
+
+// List label filter test
+func TestAddressesEndpointListLabel(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?label=treasury")
+	assert.Equal(nil, err)
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		// No labeled addresses
+		assert.Equal("0", resp.Header.Get("X-TOTAL-COUNT"))
+		return
+	}
+	assert.Equal(200, resp.StatusCode)
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]map[string]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(bodyMap))
+
+	// Test labels
+	for _, address := range bodyMap {
+		assert.Contains(address["labels"], "treasury")
+	}
+
+	// Test invalid label
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?label=Not%20A%20Label")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	// Test type, a label
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?type=treasury")
+	assert.Equal(nil, err)
+
+	defer resp.Body.Close()
+
+	// Same addresses as the label filter
+	assert.Contains([]int{200, 204}, resp.StatusCode)
+
+	bytes, err = ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap = make([]map[string]interface{}, 0)
+	if resp.StatusCode == 200 {
+		err = json.Unmarshal(bytes, &bodyMap)
+		assert.Equal(nil, err)
+	}
+
+	for _, address := range bodyMap {
+		assert.Contains(address["labels"], "treasury")
+		assert.NotEqual("", address["type"])
+	}
+
+	// Test invalid type
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?type=Not%20A%20Type")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+}
+
+// List activity sort and filter test
+func TestAddressesEndpointListActivity(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?sort=-last_active_timestamp&limit=10")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	// Test headers
+	assert.NotEqual("", resp.Header.Get("X-NEXT-CURSOR"))
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]map[string]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(bodyMap))
+
+	// Last active sorted descending, first seen at or before last active
+	prevLastActiveTimestamp := float64(-1)
+	for _, address := range bodyMap {
+		lastActiveTimestamp, ok := address["last_active_timestamp"].(float64)
+		assert.Equal(true, ok)
+		firstSeenTimestamp, ok := address["first_seen_timestamp"].(float64)
+		assert.Equal(true, ok)
+
+		if prevLastActiveTimestamp != -1 {
+			assert.LessOrEqual(lastActiveTimestamp, prevLastActiveTimestamp)
+		}
+		assert.LessOrEqual(firstSeenTimestamp, lastActiveTimestamp)
+		prevLastActiveTimestamp = lastActiveTimestamp
+	}
+
+	// Dormant addresses
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?max_last_active_timestamp=1")
+	assert.Equal(nil, err)
+	assert.Equal(204, resp.StatusCode)
+	assert.Equal("0", resp.Header.Get("X-TOTAL-COUNT"))
+
+	defer resp.Body.Close()
+
+	// Invalid filter
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?min_first_seen_timestamp=yesterday")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+}
+
+// List cursor by sort field test
+func TestAddressesEndpointListCursorSortFields(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	for _, sort := range []string{
+		"balance",
+		"transaction_count",
+		"log_count",
+		"created_timestamp",
+		"first_seen_timestamp",
+		"last_active_timestamp",
+		"public_key",
+	} {
+		resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?limit=2&sort=-" + sort)
+		assert.Equal(nil, err)
+		assert.Equal(200, resp.StatusCode, sort)
+
+		defer resp.Body.Close()
+
+		// Test headers
+		nextCursor := resp.Header.Get("X-NEXT-CURSOR")
+		assert.NotEqual("", nextCursor, sort)
+
+		// Next page
+		resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?limit=2&cursor=" + nextCursor)
+		assert.Equal(nil, err)
+		assert.Contains([]int{200, 204}, resp.StatusCode, sort)
+
+		defer resp.Body.Close()
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Search test
+func TestAddressesEndpointSearch(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	// Get latest address
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?limit=1")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(bodyMap))
+
+	// Get testable prefix
+	addressPublicKey := bodyMap[0].(map[string]interface{})["public_key"].(string)
+	prefix := addressPublicKey[:8]
+
+	// Test prefix
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/search?q=" + prefix + "&limit=5")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	bytes, err = ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	searchBodyMap := make([]map[string]interface{}, 0)
+	err = json.Unmarshal(bytes, &searchBodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(searchBodyMap))
+	assert.LessOrEqual(len(searchBodyMap), 5)
+
+	// Test rank
+	for i := 1; i < len(searchBodyMap); i++ {
+		assert.GreaterOrEqual(
+			searchBodyMap[i-1]["score"].(float64),
+			searchBodyMap[i]["score"].(float64),
+		)
+	}
+	assert.True(strings.HasPrefix(searchBodyMap[0]["public_key"].(string), prefix))
+
+	// Test exact match first
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/search?q=" + addressPublicKey)
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	bytes, err = ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	searchBodyMap = make([]map[string]interface{}, 0)
+	err = json.Unmarshal(bytes, &searchBodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(searchBodyMap))
+	assert.Equal(addressPublicKey, searchBodyMap[0]["public_key"])
+
+	// Test invalid query
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/search?q=h")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+}
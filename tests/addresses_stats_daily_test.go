@@ -0,0 +1,70 @@
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Daily stats test
+func TestAddressesEndpointStatsDaily(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/stats/daily?from=2018-01-01&to=2018-12-31")
+	assert.Equal(nil, err)
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		// No days built
+		assert.Equal("0", resp.Header.Get("X-TOTAL-COUNT"))
+	} else {
+		assert.Equal(200, resp.StatusCode)
+
+		bytes, err := ioutil.ReadAll(resp.Body)
+		assert.Equal(nil, err)
+
+		bodyMap := make([]map[string]interface{}, 0)
+		err = json.Unmarshal(bytes, &bodyMap)
+		assert.Equal(nil, err)
+		assert.NotEqual(0, len(bodyMap))
+
+		// Test sort and range
+		for i := 0; i < len(bodyMap); i++ {
+			assert.GreaterOrEqual(bodyMap[i]["day"].(string), "2018-01-01")
+			assert.LessOrEqual(bodyMap[i]["day"].(string), "2018-12-31")
+			assert.LessOrEqual(bodyMap[i]["new_address_count"].(float64), bodyMap[i]["active_address_count"].(float64))
+
+			if i > 0 {
+				assert.Less(bodyMap[i-1]["day"].(string), bodyMap[i]["day"].(string))
+			}
+		}
+	}
+
+	// Test invalid day
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/stats/daily?from=2018-13-01")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	// Test invalid range
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/stats/daily?from=2018-01-01&to=2020-01-01")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+}
@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Stats test
+func TestAddressesEndpointStats(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/stats")
+	assert.Equal(nil, err)
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		// Snapshot not computed yet
+		return
+	}
+	assert.Equal(200, resp.StatusCode)
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make(map[string]interface{})
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(float64(0), bodyMap["timestamp"])
+	assert.NotEqual(nil, bodyMap["total"])
+
+	// Test top holders
+	topHolders := bodyMap["top_holders"].([]interface{})
+	assert.Equal(3, len(topHolders))
+	for i := 1; i < len(topHolders); i++ {
+		assert.LessOrEqual(
+			topHolders[i-1].(map[string]interface{})["share"].(float64),
+			topHolders[i].(map[string]interface{})["share"].(float64),
+		)
+	}
+
+	// Test balance distribution
+	balanceDistribution := bodyMap["balance_distribution"].([]interface{})
+	assert.Equal(10, len(balanceDistribution))
+	assert.Equal("0", balanceDistribution[0].(map[string]interface{})["key"])
+
+	// Test address types
+	addressTypes := bodyMap["address_types"].([]interface{})
+	assert.Equal(4, len(addressTypes))
+
+	// Test new addresses
+	newAddresses := bodyMap["new_addresses"].([]interface{})
+	assert.NotEqual(0, len(newAddresses))
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Token holders test
+func TestAddressesEndpointTokenHolders(t *testing.T) {
+	assert := assert.New(t)
+
+	addressesServiceURL := os.Getenv("ADDRESSES_SERVICE_URL")
+	if addressesServiceURL == "" {
+		addressesServiceURL = "http://localhost:8000"
+	}
+	addressesServiceRestPrefx := os.Getenv("ADDRESSES_SERVICE_REST_PREFIX")
+	if addressesServiceRestPrefx == "" {
+		addressesServiceRestPrefx = "/api/v1"
+	}
+
+	// Get latest token contract
+	resp, err := http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses?limit=1&is_token=true")
+	assert.Equal(nil, err)
+	assert.Equal(200, resp.StatusCode)
+
+	defer resp.Body.Close()
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	bodyMap := make([]interface{}, 0)
+	err = json.Unmarshal(bytes, &bodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(bodyMap))
+
+	// Get testable token contract
+	tokenContractAddress := bodyMap[0].(map[string]interface{})["public_key"].(string)
+
+	// Test holders
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/token-holders/" + tokenContractAddress + "?limit=5")
+	assert.Equal(nil, err)
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 204 {
+		// No holders
+		assert.Equal("0", resp.Header.Get("X-TOTAL-COUNT"))
+		return
+	}
+	assert.Equal(200, resp.StatusCode)
+
+	// Test headers
+	assert.NotEqual("0", resp.Header.Get("X-TOTAL-COUNT"))
+
+	bytes, err = ioutil.ReadAll(resp.Body)
+	assert.Equal(nil, err)
+
+	holdersBodyMap := make([]map[string]interface{}, 0)
+	err = json.Unmarshal(bytes, &holdersBodyMap)
+	assert.Equal(nil, err)
+	assert.NotEqual(0, len(holdersBodyMap))
+	assert.LessOrEqual(len(holdersBodyMap), 5)
+
+	// Test current holders only
+	for _, holder := range holdersBodyMap {
+		assert.Greater(holder["balance_decimal"].(float64), float64(0))
+	}
+
+	// Test sort
+	for i := 1; i < len(holdersBodyMap); i++ {
+		assert.GreaterOrEqual(
+			holdersBodyMap[i-1]["balance_decimal"].(float64),
+			holdersBodyMap[i]["balance_decimal"].(float64),
+		)
+	}
+
+	// Test invalid limit
+	resp, err = http.Get(addressesServiceURL + addressesServiceRestPrefx + "/addresses/token-holders/" + tokenContractAddress + "?limit=1000")
+	assert.Equal(nil, err)
+	assert.Equal(422, resp.StatusCode)
+
+	defer resp.Body.Close()
+}